@@ -0,0 +1,67 @@
+package cloudsmith
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// defaultCredentialsProfile is the profile read from the credentials file
+// when CLOUDSMITH_PROFILE isn't set.
+const defaultCredentialsProfile = "default"
+
+// defaultCredentialsFile returns the path of the credentials file shared with
+// the Cloudsmith CLI, or an empty string if the home directory is unknown.
+func defaultCredentialsFile() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".cloudsmith", "credentials")
+}
+
+// credentialsFileAPIKey reads the api_key of a profile from an INI formatted
+// credentials file, e.g.
+//
+//	[default]
+//	api_key = my-api-key
+//
+// An empty string is returned if the file or the profile doesn't exist.
+func credentialsFileAPIKey(path, profile string) (string, error) {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	section := ""
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			section = strings.TrimSpace(line[1 : len(line)-1])
+			continue
+		}
+		if section != profile {
+			continue
+		}
+
+		key, value, found := strings.Cut(line, "=")
+		if found && strings.TrimSpace(key) == "api_key" {
+			return strings.TrimSpace(value), nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", fmt.Errorf("error reading credentials file %s: %w", path, err)
+	}
+
+	return "", nil
+}
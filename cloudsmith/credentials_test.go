@@ -0,0 +1,44 @@
+//nolint:testpackage
+package cloudsmith
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCredentialsFileAPIKey(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "credentials")
+	content := `# Cloudsmith credentials
+[default]
+api_key = default-key
+
+[staging]
+; used for the staging organization
+api_key=staging-key
+`
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		path, profile, want string
+	}{
+		{path: path, profile: "default", want: "default-key"},
+		{path: path, profile: "staging", want: "staging-key"},
+		{path: path, profile: "missing", want: ""},
+		{path: filepath.Join(t.TempDir(), "missing"), profile: "default", want: ""},
+	}
+
+	for _, tt := range tests {
+		got, err := credentialsFileAPIKey(tt.path, tt.profile)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if got != tt.want {
+			t.Errorf("credentialsFileAPIKey(%s) = %q, want %q", tt.profile, got, tt.want)
+		}
+	}
+}
@@ -110,7 +110,7 @@ func flattenEntitlementToken(token []cloudsmith.RepositoryToken) []interface{} {
 	return tokenList
 }
 
-func dataSourceEntitlementRead(d *schema.ResourceData, m interface{}) error {
+func dataSourceEntitlementListRead(d *schema.ResourceData, m interface{}) error {
 	pc := m.(*providerConfig)
 
 	namespace := requiredString(d, "namespace")
@@ -139,7 +139,7 @@ func dataSourceEntitlementRead(d *schema.ResourceData, m interface{}) error {
 
 func dataSourceEntitlementList() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceEntitlementRead,
+		Read: dataSourceEntitlementListRead,
 
 		Schema: map[string]*schema.Schema{
 			"namespace": {
@@ -0,0 +1,86 @@
+package cloudsmith
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceEntitlementRead(d *schema.ResourceData, m interface{}) error {
+	pc := m.(*providerConfig)
+
+	namespace := requiredString(d, "namespace")
+	repository := requiredString(d, "repository")
+	slug := requiredString(d, "slug")
+
+	req := pc.APIClient.EntitlementsApi.EntitlementsRead(pc.Auth, namespace, repository, slug)
+	req = req.ShowTokens(true)
+	entitlement, _, err := pc.APIClient.EntitlementsApi.EntitlementsReadExecute(req)
+	if err != nil {
+		return fmt.Errorf("error reading entitlement (%s): %w", slug, err)
+	}
+
+	d.Set("is_active", entitlement.GetIsActive())
+	d.Set("limit_num_downloads", entitlement.GetLimitNumDownloads())
+	d.Set("name", entitlement.GetName())
+	d.Set("slug_perm", entitlement.GetSlugPerm())
+	d.Set("token", entitlement.GetToken())
+
+	d.SetId(fmt.Sprintf("%s.%s.%s", namespace, repository, entitlement.GetSlugPerm()))
+
+	return nil
+}
+
+func dataSourceEntitlement() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceEntitlementRead,
+
+		Schema: map[string]*schema.Schema{
+			"is_active": {
+				Type:        schema.TypeBool,
+				Description: "If true, the entitlement token can be used to download packages.",
+				Computed:    true,
+			},
+			"limit_num_downloads": {
+				Type:        schema.TypeInt,
+				Description: "The maximum number of downloads allowed for the token.",
+				Computed:    true,
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Description: "The name of the entitlement token.",
+				Computed:    true,
+			},
+			"namespace": {
+				Type:         schema.TypeString,
+				Description:  "Namespace to which the entitlement token belongs.",
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"repository": {
+				Type:         schema.TypeString,
+				Description:  "Repository to which the entitlement token belongs.",
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"slug": {
+				Type:         schema.TypeString,
+				Description:  "The slug_perm of the entitlement token to look up.",
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"slug_perm": {
+				Type:        schema.TypeString,
+				Description: "The slug_perm that immutably identifies the entitlement token.",
+				Computed:    true,
+			},
+			"token": {
+				Type:        schema.TypeString,
+				Description: "The value of the entitlement token.",
+				Computed:    true,
+				Sensitive:   true,
+			},
+		},
+	}
+}
@@ -0,0 +1,55 @@
+//nolint:testpackage
+package cloudsmith
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// TestAccDataSourceEntitlement_basic creates an entitlement token, then reads
+// it back using the data source and verifies that its token value and
+// limits match the resource.
+func TestAccDataSourceEntitlement_basic(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccRepositoryCheckDestroy("cloudsmith_repository.test"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceEntitlementConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.cloudsmith_entitlement.test", "name", "TF Test Entitlement Data"),
+					resource.TestCheckResourceAttr("data.cloudsmith_entitlement.test", "is_active", "true"),
+					resource.TestCheckResourceAttr("data.cloudsmith_entitlement.test", "limit_num_downloads", "100"),
+					resource.TestCheckResourceAttrPair("data.cloudsmith_entitlement.test", "token", "cloudsmith_entitlement.test", "token"),
+					resource.TestCheckResourceAttrPair("data.cloudsmith_entitlement.test", "slug_perm", "cloudsmith_entitlement.test", "slug_perm"),
+				),
+			},
+		},
+	})
+}
+
+var testAccDataSourceEntitlementConfig = fmt.Sprintf(`
+resource "cloudsmith_repository" "test" {
+	name      = "terraform-acc-test-entitlement-data"
+	namespace = "%s"
+}
+
+resource "cloudsmith_entitlement" "test" {
+	name                = "TF Test Entitlement Data"
+	namespace           = cloudsmith_repository.test.namespace
+	repository          = cloudsmith_repository.test.slug_perm
+	limit_num_downloads = 100
+}
+
+data "cloudsmith_entitlement" "test" {
+	namespace  = cloudsmith_repository.test.namespace
+	repository = cloudsmith_repository.test.slug_perm
+	slug       = cloudsmith_entitlement.test.slug_perm
+}
+`, os.Getenv("CLOUDSMITH_NAMESPACE"))
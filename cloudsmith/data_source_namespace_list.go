@@ -0,0 +1,138 @@
+package cloudsmith
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/cloudsmith-io/cloudsmith-api-go"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const namespaceListPageSize = 100
+
+// retrieveNamespaceListPages retrieves every namespace the authenticated user
+// belongs to, which includes their own user namespace and the namespaces of
+// their organizations.
+func retrieveNamespaceListPages(pc *providerConfig, pageSize int64) ([]cloudsmith.Namespace, error) {
+	namespaces := []cloudsmith.Namespace{}
+
+	var page, pageTotal int64 = 1, 1
+	for page <= pageTotal {
+		req := pc.APIClient.NamespacesApi.NamespacesList(pc.Auth)
+		req = req.Page(page)
+		req = req.PageSize(pageSize)
+
+		pageData, resp, err := pc.APIClient.NamespacesApi.NamespacesListExecute(req)
+		if err != nil {
+			return nil, err
+		}
+		pageTotal, err = strconv.ParseInt(resp.Header.Get("X-Pagination-Pagetotal"), 10, 64)
+		if err != nil {
+			return nil, err
+		}
+
+		namespaces = append(namespaces, pageData...)
+		page++
+	}
+
+	return namespaces, nil
+}
+
+// filterNamespacesByType returns the namespaces of the given type, either
+// `user` or `organization`, or every namespace if the type is empty.
+func filterNamespacesByType(namespaces []cloudsmith.Namespace, namespaceType string) []cloudsmith.Namespace {
+	if namespaceType == "" {
+		return namespaces
+	}
+
+	filtered := []cloudsmith.Namespace{}
+	for _, namespace := range namespaces {
+		if strings.EqualFold(namespace.GetTypeName(), namespaceType) {
+			filtered = append(filtered, namespace)
+		}
+	}
+	return filtered
+}
+
+func flattenNamespaces(namespaces []cloudsmith.Namespace) []interface{} {
+	out := make([]interface{}, len(namespaces))
+	for i, namespace := range namespaces {
+		out[i] = map[string]interface{}{
+			"name":      namespace.GetName(),
+			"slug":      namespace.GetSlug(),
+			"slug_perm": namespace.GetSlugPerm(),
+			"type":      namespace.GetTypeName(),
+		}
+	}
+	return out
+}
+
+func dataSourceNamespaceListRead(d *schema.ResourceData, m interface{}) error {
+	pc := m.(*providerConfig)
+
+	namespaces, err := retrieveNamespaceListPages(pc, namespaceListPageSize)
+	if err != nil {
+		return fmt.Errorf("error listing namespaces: %w", err)
+	}
+	namespaces = filterNamespacesByType(namespaces, requiredString(d, "type_filter"))
+
+	if err := d.Set("namespaces", flattenNamespaces(namespaces)); err != nil {
+		return err
+	}
+
+	// the ID changes whenever the namespaces listed do, so that changes to
+	// them are shown in plans.
+	slugs := make([]string, len(namespaces))
+	for i, namespace := range namespaces {
+		slugs[i] = namespace.GetSlugPerm()
+	}
+	d.SetId(strconv.Itoa(schema.HashString(strings.Join(slugs, ","))))
+
+	return nil
+}
+
+func dataSourceNamespaceList() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceNamespaceListRead,
+
+		Schema: map[string]*schema.Schema{
+			"namespaces": {
+				Type:        schema.TypeList,
+				Description: "The namespaces the authenticated user belongs to.",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Description: "A descriptive name for the namespace.",
+							Computed:    true,
+						},
+						"slug": {
+							Type:        schema.TypeString,
+							Description: "The slug identifies the namespace in URIs.",
+							Computed:    true,
+						},
+						"slug_perm": {
+							Type:        schema.TypeString,
+							Description: "The slug_perm immutably identifies the namespace.",
+							Computed:    true,
+						},
+						"type": {
+							Type:        schema.TypeString,
+							Description: "Whether the namespace is a user or an organization namespace.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"type_filter": {
+				Type:         schema.TypeString,
+				Description:  "If set, only namespaces of this type are returned, either `user` or `organization`.",
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"user", "organization"}, true),
+			},
+		},
+	}
+}
@@ -0,0 +1,105 @@
+//nolint:testpackage
+package cloudsmith
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"testing"
+
+	"github.com/cloudsmith-io/cloudsmith-api-go"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// TestRetrieveNamespaceListPages serves a paginated list of namespaces and
+// verifies that every page is fetched exactly once.
+func TestRetrieveNamespaceListPages(t *testing.T) {
+	t.Parallel()
+
+	const pageSize, pageTotal = 2, 3
+
+	requests := map[int]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		requests[page]++
+
+		namespaces := []map[string]string{}
+		for i := 0; i < pageSize; i++ {
+			namespaces = append(namespaces, map[string]string{
+				"slug":      fmt.Sprintf("namespace-%d-%d", page, i),
+				"type_name": "Organization",
+			})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Pagination-Pagetotal", strconv.Itoa(pageTotal))
+		_ = json.NewEncoder(w).Encode(namespaces)
+	}))
+	defer server.Close()
+
+	pc := testProviderConfig(server.URL)
+
+	namespaces, err := retrieveNamespaceListPages(pc, pageSize)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(namespaces) != pageSize*pageTotal {
+		t.Fatalf("expected %d namespaces, got %d", pageSize*pageTotal, len(namespaces))
+	}
+	for page := 1; page <= pageTotal; page++ {
+		if requests[page] != 1 {
+			t.Errorf("expected page %d to be requested once, got %d", page, requests[page])
+		}
+	}
+}
+
+func TestFilterNamespacesByType(t *testing.T) {
+	t.Parallel()
+
+	newNamespace := func(slug, typeName string) cloudsmith.Namespace {
+		namespace := cloudsmith.Namespace{}
+		namespace.SetSlug(slug)
+		namespace.SetTypeName(typeName)
+		return namespace
+	}
+	namespaces := []cloudsmith.Namespace{
+		newNamespace("user", "User"),
+		newNamespace("org", "Organization"),
+	}
+
+	if got := filterNamespacesByType(namespaces, ""); len(got) != 2 {
+		t.Errorf("expected every namespace without a filter, got %d", len(got))
+	}
+	if got := filterNamespacesByType(namespaces, "organization"); len(got) != 1 || got[0].GetSlug() != "org" {
+		t.Errorf("expected only the organization namespace, got %v", got)
+	}
+}
+
+// TestAccDataSourceNamespaceList_basic verifies that the configured namespace
+// is listed among the organization namespaces.
+func TestAccDataSourceNamespaceList_basic(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceNamespaceListConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckTypeSetElemNestedAttrs("data.cloudsmith_namespace_list.test", "namespaces.*", map[string]string{
+						"slug": os.Getenv("CLOUDSMITH_NAMESPACE"),
+					}),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceNamespaceListConfig = `
+data "cloudsmith_namespace_list" "test" {
+	type_filter = "organization"
+}
+`
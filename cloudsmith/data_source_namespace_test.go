@@ -0,0 +1,42 @@
+//nolint:testpackage
+package cloudsmith
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// TestAccNamespace_data reads the configured namespace using a data source and
+// verifies that the expected fields are set with appropriate values.
+func TestAccNamespace_data(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNamespaceData,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.cloudsmith_namespace.test", "slug", os.Getenv("CLOUDSMITH_NAMESPACE")),
+					resource.TestCheckResourceAttr("data.cloudsmith_namespace.test", "type_name", "Organization"),
+					resource.TestCheckResourceAttrSet("data.cloudsmith_namespace.test", "name"),
+					resource.TestCheckResourceAttrSet("data.cloudsmith_namespace.test", "slug_perm"),
+					resource.TestCheckResourceAttrPair(
+						"data.cloudsmith_namespace.test", "id",
+						"data.cloudsmith_namespace.test", "slug_perm",
+					),
+				),
+			},
+		},
+	})
+}
+
+var testAccNamespaceData = fmt.Sprintf(`
+data "cloudsmith_namespace" "test" {
+	slug = "%s"
+}
+`, os.Getenv("CLOUDSMITH_NAMESPACE"))
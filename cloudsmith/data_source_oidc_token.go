@@ -0,0 +1,131 @@
+package cloudsmith
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// oidcTokenExchangeURL returns the URL of the endpoint that exchanges OIDC
+// tokens for API tokens. It isn't part of the versioned API, so it is served
+// from the root of the API host.
+func oidcTokenExchangeURL(apiHost, namespace string) (string, error) {
+	u, err := url.Parse(apiHost)
+	if err != nil {
+		return "", fmt.Errorf("invalid API host %s: %w", apiHost, err)
+	}
+	u.Path = fmt.Sprintf("/openid/%s/", url.PathEscape(namespace))
+	u.RawQuery = ""
+	return u.String(), nil
+}
+
+// exchangeOIDCToken exchanges an OIDC token issued to a CI job for a
+// short-lived API token for the given service account. The OIDC token
+// authenticates the request, so the provider's API key isn't sent.
+func exchangeOIDCToken(ctx context.Context, pc *providerConfig, namespace, oidcToken, serviceSlug string) (string, error) {
+	apiHost, err := pc.APIClient.GetConfig().Servers.URL(0, nil)
+	if err != nil {
+		return "", err
+	}
+	exchangeURL, err := oidcTokenExchangeURL(apiHost, namespace)
+	if err != nil {
+		return "", err
+	}
+
+	body, err := json.Marshal(map[string]string{
+		"oidc_token":   oidcToken,
+		"service_slug": serviceSlug,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, exchangeURL, bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := pc.APIClient.GetConfig().HTTPClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("error exchanging OIDC token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return "", fmt.Errorf("error exchanging OIDC token: status code: %d: %s", resp.StatusCode, message)
+	}
+
+	var result struct {
+		Token string `json:"token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("error exchanging OIDC token: invalid response: %w", err)
+	}
+	if result.Token == "" {
+		return "", fmt.Errorf("error exchanging OIDC token: no token returned")
+	}
+
+	return result.Token, nil
+}
+
+func dataSourceOidcTokenRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	pc := m.(*providerConfig)
+
+	namespace := requiredString(d, "namespace")
+	serviceSlug := requiredString(d, "service_account_slug")
+
+	token, err := exchangeOIDCToken(ctx, pc, namespace, requiredString(d, "oidc_token"), serviceSlug)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("api_token", token)
+
+	d.SetId(fmt.Sprintf("%s.%s", namespace, serviceSlug))
+
+	return nil
+}
+
+func dataSourceOidcToken() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceOidcTokenRead,
+
+		Schema: map[string]*schema.Schema{
+			"api_token": {
+				Type:        schema.TypeString,
+				Description: "The short-lived API token of the service account.",
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"namespace": {
+				Type:         schema.TypeString,
+				Description:  "Organization to which the service account belongs.",
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"oidc_token": {
+				Type:         schema.TypeString,
+				Description:  "The OIDC token (JWT) issued to the CI job, e.g. by GitHub Actions.",
+				Required:     true,
+				Sensitive:    true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"service_account_slug": {
+				Type:         schema.TypeString,
+				Description:  "The slug of the service account to authenticate as.",
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+		},
+	}
+}
@@ -0,0 +1,63 @@
+//nolint:testpackage
+package cloudsmith
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestOidcTokenExchangeURL(t *testing.T) {
+	t.Parallel()
+
+	got, err := oidcTokenExchangeURL("https://api.cloudsmith.io/v1", "my-org")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if expected := "https://api.cloudsmith.io/openid/my-org/"; got != expected {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+}
+
+// TestExchangeOIDCToken serves the OIDC token exchange endpoint and verifies
+// that the OIDC token and service account are sent, and the API token
+// returned, or that a rejected exchange is reported.
+func TestExchangeOIDCToken(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/openid/my-org/" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+
+		var body map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&body)
+
+		w.Header().Set("Content-Type", "application/json")
+		if body["oidc_token"] != "jwt" || body["service_slug"] != "ci" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"detail": "Invalid token."}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"token": "api-token"}`))
+	}))
+	defer server.Close()
+
+	pc := testProviderConfig(server.URL + "/v1")
+
+	token, err := exchangeOIDCToken(context.Background(), pc, "my-org", "jwt", "ci")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if token != "api-token" {
+		t.Errorf("expected api-token, got %s", token)
+	}
+
+	_, err = exchangeOIDCToken(context.Background(), pc, "my-org", "other-jwt", "ci")
+	if err == nil || !strings.Contains(err.Error(), "status code: 401") {
+		t.Errorf("expected a 401 error, got %v", err)
+	}
+}
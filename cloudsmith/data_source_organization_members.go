@@ -3,9 +3,12 @@ package cloudsmith
 import (
 	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/samber/lo"
 
 	"github.com/cloudsmith-io/cloudsmith-api-go"
 )
@@ -51,7 +54,7 @@ func retrieveOrgMemeberListPages(pc *providerConfig, organization string, isActi
 	}
 
 	for pageCurrentCount <= pageCount {
-		membersPage, _, err := retrieveOrgMemeberListPage(pc, organization, isActive, pageSize, pageCount)
+		membersPage, _, err := retrieveOrgMemeberListPage(pc, organization, isActive, pageSize, pageCurrentCount)
 		if err != nil {
 			return nil, err
 		}
@@ -74,12 +77,18 @@ func dataSourceOrganizationMembersListRead(d *schema.ResourceData, m interface{}
 		return fmt.Errorf("error retrieving organization members: %s", err)
 	}
 
+	if roleFilter := d.Get("role_filter").(string); roleFilter != "" {
+		members = lo.Filter(members, func(member cloudsmith.OrganizationMembership, _ int) bool {
+			return strings.EqualFold(member.GetRole(), roleFilter)
+		})
+	}
+
 	// Map the filtered members to the schema
 	if err := d.Set("members", flattenOrganizationMembers(members)); err != nil {
 		return fmt.Errorf("error setting members: %s", err)
 	}
 
-	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
+	d.SetId(namespace)
 	return nil
 }
 
@@ -115,6 +124,12 @@ func dataSourceOrganizationMembersList() *schema.Resource {
 				Optional: true,
 				Default:  true,
 			},
+			"role_filter": {
+				Type:         schema.TypeString,
+				Description:  "Only return members with this role, e.g. `Owner` (case-insensitive).",
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
 			"members": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -27,6 +27,13 @@ func TestAccOrganizationMembersList_basic(t *testing.T) {
 					resource.TestCheckResourceAttr("data.cloudsmith_list_org_members.test", "members.0.user", "bblizniak"),
 				),
 			},
+			{
+				Config: testAccCheckOrganizationMembersListConfigRoleFilter(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.cloudsmith_list_org_members.test", "id", os.Getenv("CLOUDSMITH_NAMESPACE")),
+					resource.TestCheckResourceAttr("data.cloudsmith_list_org_members.test", "members.0.role", "Owner"),
+				),
+			},
 		},
 	})
 }
@@ -39,3 +46,12 @@ data "cloudsmith_list_org_members" "test" {
 }
 `, os.Getenv("CLOUDSMITH_NAMESPACE"))
 }
+
+func testAccCheckOrganizationMembersListConfigRoleFilter() string {
+	return fmt.Sprintf(`
+data "cloudsmith_list_org_members" "test" {
+    namespace   = "%s"
+    role_filter = "owner"
+}
+`, os.Getenv("CLOUDSMITH_NAMESPACE"))
+}
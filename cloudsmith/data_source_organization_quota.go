@@ -0,0 +1,99 @@
+package cloudsmith
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// quotaLimit returns the limit that applies to a namespace, which is the
+// configured limit if one has been set, or the limit included in the plan.
+func quotaLimit(configured, planLimit int64) int64 {
+	if configured > 0 {
+		return configured
+	}
+	return planLimit
+}
+
+// quotaPercentUsed returns used as a percentage of limit, or zero if there is
+// no limit.
+func quotaPercentUsed(used, limit int64) float64 {
+	if limit <= 0 {
+		return 0
+	}
+	return float64(used) / float64(limit) * 100
+}
+
+func dataSourceOrganizationQuotaRead(d *schema.ResourceData, m interface{}) error {
+	pc := m.(*providerConfig)
+
+	namespace := requiredString(d, "namespace")
+
+	req := pc.APIClient.QuotaApi.QuotaRead(pc.Auth, namespace)
+	quota, _, err := pc.APIClient.QuotaApi.QuotaReadExecute(req)
+	if err != nil {
+		return err
+	}
+
+	usage := quota.GetUsage()
+	raw := usage.GetRaw()
+	storage := raw.GetStorage()
+	bandwidth := raw.GetBandwidth()
+
+	storageLimit := quotaLimit(storage.GetConfigured(), storage.GetPlanLimit())
+	bandwidthLimit := quotaLimit(bandwidth.GetConfigured(), bandwidth.GetPlanLimit())
+
+	d.SetId(namespace)
+	d.Set("bandwidth_limit_bytes", bandwidthLimit)
+	d.Set("bandwidth_percent_used", quotaPercentUsed(bandwidth.GetUsed(), bandwidthLimit))
+	d.Set("bandwidth_used_bytes", bandwidth.GetUsed())
+	d.Set("storage_limit_bytes", storageLimit)
+	d.Set("storage_percent_used", quotaPercentUsed(storage.GetUsed(), storageLimit))
+	d.Set("storage_used_bytes", storage.GetUsed())
+
+	return nil
+}
+
+func dataSourceOrganizationQuota() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceOrganizationQuotaRead,
+
+		Schema: map[string]*schema.Schema{
+			"bandwidth_limit_bytes": {
+				Type:        schema.TypeInt,
+				Description: "The bandwidth limit of the organization in bytes.",
+				Computed:    true,
+			},
+			"bandwidth_percent_used": {
+				Type:        schema.TypeFloat,
+				Description: "The percentage of the bandwidth limit used.",
+				Computed:    true,
+			},
+			"bandwidth_used_bytes": {
+				Type:        schema.TypeInt,
+				Description: "The bandwidth used by the organization in bytes.",
+				Computed:    true,
+			},
+			"namespace": {
+				Type:         schema.TypeString,
+				Description:  "The slug of the organization to retrieve the quota of.",
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"storage_limit_bytes": {
+				Type:        schema.TypeInt,
+				Description: "The storage limit of the organization in bytes.",
+				Computed:    true,
+			},
+			"storage_percent_used": {
+				Type:        schema.TypeFloat,
+				Description: "The percentage of the storage limit used.",
+				Computed:    true,
+			},
+			"storage_used_bytes": {
+				Type:        schema.TypeInt,
+				Description: "The storage used by the organization in bytes.",
+				Computed:    true,
+			},
+		},
+	}
+}
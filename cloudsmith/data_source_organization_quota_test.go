@@ -0,0 +1,60 @@
+//nolint:testpackage
+package cloudsmith
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestQuotaPercentUsed(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		configured, planLimit, used int64
+		want                        float64
+	}{
+		{configured: 0, planLimit: 200, used: 50, want: 25},
+		{configured: 100, planLimit: 200, used: 50, want: 50},
+		{configured: 0, planLimit: 0, used: 50, want: 0},
+	}
+
+	for _, tt := range tests {
+		limit := quotaLimit(tt.configured, tt.planLimit)
+		if got := quotaPercentUsed(tt.used, limit); got != tt.want {
+			t.Errorf("quotaPercentUsed(%d, %d) = %v, want %v", tt.used, limit, got, tt.want)
+		}
+	}
+}
+
+// TestAccOrganizationQuota_data reads the quota of the configured namespace
+// and verifies that the expected fields are set.
+func TestAccOrganizationQuota_data(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccOrganizationQuotaData,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.cloudsmith_organization_quota.test", "id", os.Getenv("CLOUDSMITH_NAMESPACE")),
+					resource.TestCheckResourceAttrSet("data.cloudsmith_organization_quota.test", "storage_used_bytes"),
+					resource.TestCheckResourceAttrSet("data.cloudsmith_organization_quota.test", "storage_limit_bytes"),
+					resource.TestCheckResourceAttrSet("data.cloudsmith_organization_quota.test", "storage_percent_used"),
+					resource.TestCheckResourceAttrSet("data.cloudsmith_organization_quota.test", "bandwidth_used_bytes"),
+					resource.TestCheckResourceAttrSet("data.cloudsmith_organization_quota.test", "bandwidth_limit_bytes"),
+				),
+			},
+		},
+	})
+}
+
+var testAccOrganizationQuotaData = fmt.Sprintf(`
+data "cloudsmith_organization_quota" "test" {
+	namespace = "%s"
+}
+`, os.Getenv("CLOUDSMITH_NAMESPACE"))
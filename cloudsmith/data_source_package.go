@@ -1,23 +1,35 @@
 package cloudsmith
 
 import (
+	"context"
 	"crypto/md5"
 	"crypto/sha1"
 	"crypto/sha256"
 	"crypto/sha512"
 	"encoding/hex"
+	"errors"
 	"fmt"
+	"hash"
 	"io"
 	"net/http"
 	"net/url"
 	"os"
 	"path"
+	"regexp"
 	"strconv"
+	"strings"
+	"sync"
+	"text/tabwriter"
 	"time"
 
 	cloudsmith_api "github.com/cloudsmith-io/cloudsmith-api-go"
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"golang.org/x/crypto/blake2b"
+	"golang.org/x/crypto/sha3"
 )
 
 type Checksums struct {
@@ -25,22 +37,27 @@ type Checksums struct {
 	SHA1   string
 	SHA256 string
 	SHA512 string
+
+	// SHA3_256 and BLAKE2b256 are only calculated when requested, as the
+	// API doesn't return them for comparison.
+	SHA3_256   string
+	BLAKE2b256 string
 }
 
 func (c Checksums) CompareWithPkg(pkg *cloudsmith_api.Package) error {
 	var errs []error
 
 	if c.MD5 != pkg.GetChecksumMd5() {
-		errs = append(errs, fmt.Errorf(checksumMismatchError(c.MD5, pkg.GetChecksumMd5(), "MD5")))
+		errs = append(errs, errors.New(checksumMismatchError("MD5", pkg.GetChecksumMd5(), c.MD5)))
 	}
 	if c.SHA1 != pkg.GetChecksumSha1() {
-		errs = append(errs, fmt.Errorf(checksumMismatchError(c.SHA1, pkg.GetChecksumSha1(), "SHA1")))
+		errs = append(errs, errors.New(checksumMismatchError("SHA1", pkg.GetChecksumSha1(), c.SHA1)))
 	}
 	if c.SHA256 != pkg.GetChecksumSha256() {
-		errs = append(errs, fmt.Errorf(checksumMismatchError(c.SHA256, pkg.GetChecksumSha256(), "SHA256")))
+		errs = append(errs, errors.New(checksumMismatchError("SHA256", pkg.GetChecksumSha256(), c.SHA256)))
 	}
 	if c.SHA512 != pkg.GetChecksumSha512() {
-		errs = append(errs, fmt.Errorf(checksumMismatchError(c.SHA512, pkg.GetChecksumSha512(), "SHA512")))
+		errs = append(errs, errors.New(checksumMismatchError("SHA512", pkg.GetChecksumSha512(), c.SHA512)))
 	}
 
 	var finalError error = nil
@@ -51,24 +68,345 @@ func (c Checksums) CompareWithPkg(pkg *cloudsmith_api.Package) error {
 	return finalError
 }
 
-func checksumMismatchError(localChecksum string, remoteChecksum string, checksumType string) string {
-	formatString := fmt.Sprintf("Checksum mismatch (%s): expected=%s, got=%s", localChecksum, remoteChecksum, checksumType)
-	return formatString
+// checksumMismatchDiagnostic describes a downloaded package whose checksums
+// don't match those returned by the API, with a table comparing every
+// checksum in its detail.
+func checksumMismatchDiagnostic(c Checksums, pkg *cloudsmith_api.Package) diag.Diagnostic {
+	var b strings.Builder
+	w := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "Checksum\tExpected\tDownloaded\t")
+	for _, row := range []struct{ name, expected, got string }{
+		{"MD5", pkg.GetChecksumMd5(), c.MD5},
+		{"SHA1", pkg.GetChecksumSha1(), c.SHA1},
+		{"SHA256", pkg.GetChecksumSha256(), c.SHA256},
+		{"SHA512", pkg.GetChecksumSha512(), c.SHA512},
+	} {
+		status := ""
+		if row.expected != row.got {
+			status = "mismatch"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", row.name, row.expected, row.got, status)
+	}
+	w.Flush()
+
+	return diag.Diagnostic{
+		Severity: diag.Error,
+		Summary:  fmt.Sprintf("Checksum mismatch for downloaded package %s", pkg.GetSlugPerm()),
+		Detail: "The checksums of the downloaded package don't match those returned by the Cloudsmith API, " +
+			"even after downloading it again. Set ignore_checksums to skip this check.\n\n" + b.String(),
+	}
+}
+
+// flattenPackageTags converts the package tags returned by the API, which map
+// each tag type (e.g. "info" or "version") to a list of tags, into a map of
+// strings that can be stored in TF state. Multiple tags of the same type are
+// comma-separated.
+func flattenPackageTags(tags map[string]interface{}) map[string]interface{} {
+	flattened := make(map[string]interface{}, len(tags))
+	for tagType, value := range tags {
+		switch v := value.(type) {
+		case []interface{}:
+			values := make([]string, len(v))
+			for i, item := range v {
+				values[i] = fmt.Sprint(item)
+			}
+			flattened[tagType] = strings.Join(values, ",")
+		default:
+			flattened[tagType] = fmt.Sprint(v)
+		}
+	}
+	return flattened
+}
+
+// checksumMismatchError describes a mismatch between the checksum expected by
+// the API and the checksum calculated for the downloaded file.
+func checksumMismatchError(checksumType string, expected string, got string) string {
+	return fmt.Sprintf("Checksum mismatch (%s): expected=%s, got=%s", checksumType, expected, got)
+}
+
+const (
+	filenameStrategyNameVersion = "name_version"
+	filenameStrategySlugPerm    = "slug_perm"
+	filenameStrategyURL         = "url"
+)
+
+// packageFilename returns the filename to download a package as using the
+// given strategy. Filenames derived from the package keep the extension of
+// the filename in its CDN URL.
+func packageFilename(pkg *cloudsmith_api.Package, strategy string) string {
+	urlFilename := path.Base(pkg.GetCdnUrl())
+	extension := pkg.GetExtension()
+	if extension == "" || !strings.HasSuffix(urlFilename, extension) {
+		extension = path.Ext(urlFilename)
+	}
+
+	// names may contain path separators, e.g. scoped npm packages, which
+	// can't be used in a filename.
+	sanitize := strings.NewReplacer("/", "_", `\`, "_").Replace
+
+	switch strategy {
+	case filenameStrategyNameVersion:
+		return sanitize(fmt.Sprintf("%s-%s%s", pkg.GetName(), pkg.GetVersion(), extension))
+	case filenameStrategySlugPerm:
+		return sanitize(pkg.GetSlugPerm() + extension)
+	default:
+		return urlFilename
+	}
+}
+
+// errPackageNotFound is returned when retrieving a package if no package
+// matches the given identifier, query or version constraint.
+var errPackageNotFound = errors.New("package not found")
+
+// deletedPackageError is returned when a package can no longer be read by its
+// identifier, which usually means it was deleted since it was found, e.g.
+// between plan and apply.
+type deletedPackageError struct {
+	identifier string
+	namespace  string
+	repository string
+}
+
+func (e *deletedPackageError) Error() string {
+	return fmt.Sprintf("Package %s was not found in %s/%s - it may have been deleted", e.identifier, e.namespace, e.repository)
+}
+
+func (e *deletedPackageError) Unwrap() error {
+	return errPackageNotFound
+}
+
+// checkPackageDeleted reads a package after an error, such as a failed
+// download, returning a deletedPackageError if the package no longer exists
+// and the original error otherwise.
+func checkPackageDeleted(pc *providerConfig, namespace, repository, identifier string, err error) error {
+	req := pc.APIClient.PackagesApi.PackagesRead(pc.Auth, namespace, repository, identifier)
+	if _, resp, readErr := pc.APIClient.PackagesApi.PackagesReadExecute(req); readErr != nil && is404(resp) {
+		return &deletedPackageError{identifier: identifier, namespace: namespace, repository: repository}
+	}
+	return err
+}
+
+// retrieveLatestPackage fetches the most recently uploaded package with the
+// given name.
+func retrieveLatestPackage(pc *providerConfig, namespace, repository, name string) (*cloudsmith_api.Package, error) {
+	// the name is anchored so that packages whose names merely contain it
+	// aren't matched, leaving the newest package with the name first.
+	req := pc.APIClient.PackagesApi.PackagesList(pc.Auth, namespace, repository)
+	req = req.Query(exactPackageQuery("name", name))
+	req = req.Sort("-date")
+	req = req.PageSize(1)
+
+	packages, _, err := pc.APIClient.PackagesApi.PackagesListExecute(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(packages) == 0 || packages[0].GetName() != name {
+		return nil, fmt.Errorf("%w: no packages named %s in %s/%s", errPackageNotFound, name, namespace, repository)
+	}
+
+	return &packages[0], nil
+}
+
+// retrievePackage fetches the package by its identifier, as the first package
+// matching a query, as the package with a name and version, as the highest
+// version of a package satisfying a version constraint, or as the latest
+// package with a name, depending on which has been given.
+func retrievePackage(pc *providerConfig, d *schema.ResourceData, namespace, repository string) (*cloudsmith_api.Package, error) {
+	if requiredBool(d, "latest") {
+		pkg, err := retrieveLatestPackage(pc, namespace, repository, requiredString(d, "name"))
+		if err != nil {
+			return nil, err
+		}
+
+		d.Set("identifier", pkg.GetSlugPerm())
+		return pkg, nil
+	}
+
+	if v, ok := d.GetOk("version"); ok {
+		name := requiredString(d, "name")
+		query := fmt.Sprintf("%s AND %s", exactPackageQuery("name", name), exactPackageQuery("version", v.(string)))
+		packages, err := retrievePackageListPages(pc, namespace, repository, query, -1, -1)
+		if err != nil {
+			return nil, err
+		}
+
+		pkg, err := selectPackageByNameVersion(packages, name, v.(string))
+		if err != nil {
+			return nil, fmt.Errorf("error selecting package in %s/%s: %w", namespace, repository, err)
+		}
+
+		d.Set("identifier", pkg.GetSlugPerm())
+		return pkg, nil
+	}
+
+	if constraint, ok := d.GetOk("version_constraint"); ok {
+		name := requiredString(d, "name")
+		packages, err := retrievePackageListPages(pc, namespace, repository, fmt.Sprintf("name:%s", name), -1, -1)
+		if err != nil {
+			return nil, err
+		}
+
+		pkg, err := selectPackageVersion(packages, name, constraint.(string))
+		if err != nil {
+			return nil, fmt.Errorf("error selecting package in %s/%s: %w", namespace, repository, err)
+		}
+
+		d.Set("identifier", pkg.GetSlugPerm())
+		return pkg, nil
+	}
+
+	query, ok := d.GetOk("query")
+	if !ok {
+		identifier := requiredString(d, "identifier")
+		req := pc.APIClient.PackagesApi.PackagesRead(pc.Auth, namespace, repository, identifier)
+		pkg, resp, err := pc.APIClient.PackagesApi.PackagesReadExecute(req)
+		if is404(resp) {
+			return nil, &deletedPackageError{identifier: identifier, namespace: namespace, repository: repository}
+		}
+		return pkg, err
+	}
+
+	// fetch a second result when a single match is required, so that we can
+	// tell whether the query is ambiguous.
+	pageSize := int64(1)
+	if requiredBool(d, "query_single") {
+		pageSize = 2
+	}
+
+	packages, _, err := retrievePackageListPage(pc, namespace, repository, query.(string), pageSize, 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(packages) == 0 {
+		return nil, fmt.Errorf("%w: no packages in %s/%s match query: %s", errPackageNotFound, namespace, repository, query)
+	}
+	if len(packages) > 1 {
+		return nil, fmt.Errorf("more than one package in %s/%s matches query: %s", namespace, repository, query)
+	}
+
+	pkg := packages[0]
+	d.Set("identifier", pkg.GetSlugPerm())
+	return &pkg, nil
+}
+
+// selectPackageByNameVersion returns the only package with exactly the given
+// name and version, as search queries also match partially.
+func selectPackageByNameVersion(packages []cloudsmith_api.Package, name, version string) (*cloudsmith_api.Package, error) {
+	var selected *cloudsmith_api.Package
+	for i := range packages {
+		if packages[i].GetName() != name || packages[i].GetVersion() != version {
+			continue
+		}
+		if selected != nil {
+			return nil, fmt.Errorf("more than one package named %s has version %s", name, version)
+		}
+		selected = &packages[i]
+	}
+
+	if selected == nil {
+		return nil, fmt.Errorf("%w: no package named %s has version %s", errPackageNotFound, name, version)
+	}
+	return selected, nil
+}
+
+// selectPackageVersion returns the package with the given name and the highest
+// version satisfying the constraint. Packages whose versions can't be parsed
+// are ignored.
+func selectPackageVersion(packages []cloudsmith_api.Package, name string, constraint string) (*cloudsmith_api.Package, error) {
+	constraints, err := version.NewConstraint(constraint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid version constraint %q: %w", constraint, err)
+	}
+
+	var selected *cloudsmith_api.Package
+	var selectedVersion *version.Version
+	for i := range packages {
+		// the search query matches names partially, so check for an exact match
+		if packages[i].GetName() != name {
+			continue
+		}
+
+		v, err := version.NewVersion(packages[i].GetVersion())
+		if err != nil || !constraints.Check(v) {
+			continue
+		}
+
+		if selectedVersion == nil || v.GreaterThan(selectedVersion) {
+			selected = &packages[i]
+			selectedVersion = v
+		}
+	}
+
+	if selected == nil {
+		return nil, fmt.Errorf("%w: no versions of %s satisfy constraint %q", errPackageNotFound, name, constraint)
+	}
+	return selected, nil
+}
+
+// defaultPackageSyncPollInterval is the default time between reads of a
+// package while waiting for it to finish synchronising.
+const defaultPackageSyncPollInterval = 5 * time.Second
+
+// waitForPackageRead reads a package until it has either finished
+// synchronising or failed to, returning the package as last read. Unlike
+// waitForPackageSync, a failed sync isn't an error, as the data source
+// exposes the sync status of the package.
+func waitForPackageRead(pc *providerConfig, namespace, repository, slugPerm string, timeout, interval time.Duration) (*cloudsmith_api.Package, error) {
+	var pkg *cloudsmith_api.Package
+	checkerFunc := func() error {
+		req := pc.APIClient.PackagesApi.PackagesRead(pc.Auth, namespace, repository, slugPerm)
+		read, resp, err := pc.APIClient.PackagesApi.PackagesReadExecute(req)
+		if is404(resp) {
+			return &deletedPackageError{identifier: slugPerm, namespace: namespace, repository: repository}
+		}
+		if err != nil {
+			return err
+		}
+
+		pkg = read
+		if pkg.GetIsSyncFailed() || pkg.GetIsSyncCompleted() {
+			return nil
+		}
+		return errKeepWaiting
+	}
+	if err := waiter(checkerFunc, timeout, interval); err != nil {
+		return nil, fmt.Errorf("error waiting for package (%s) to sync: %w", slugPerm, err)
+	}
+
+	return pkg, nil
 }
 
-func dataSourcePackageRead(d *schema.ResourceData, m interface{}) error {
+func dataSourcePackageReadWithContext(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	pc := m.(*providerConfig)
 	namespace := requiredString(d, "namespace")
 	repository := requiredString(d, "repository")
-	identifier := requiredString(d, "identifier")
 	download := requiredBool(d, "download")
 	downloadDir := requiredString(d, "download_dir")
+	outputFilename := requiredString(d, "output_filename")
+	fileMode := requiredString(d, "file_mode")
 	ignoreChecksum := requiredBool(d, "ignore_checksums")
 
-	req := pc.APIClient.PackagesApi.PackagesRead(pc.Auth, namespace, repository, identifier)
-	pkg, _, err := pc.APIClient.PackagesApi.PackagesReadExecute(req)
+	pkg, err := retrievePackage(pc, d, namespace, repository)
+	if errors.Is(err, errPackageNotFound) && requiredBool(d, "ignore_not_found") {
+		// data sources must have an ID for their state to be stored, so one
+		// is set even though the package doesn't exist, leaving every other
+		// attribute blank.
+		tflog.Debug(ctx, "Package not found, ignoring", map[string]interface{}{"error": err.Error()})
+		d.SetId(fmt.Sprintf("%s_%s", namespace, repository))
+		return nil
+	}
 	if err != nil {
-		return err
+		return diag.FromErr(err)
+	}
+
+	if requiredBool(d, "wait_for_sync") {
+		timeout := time.Duration(d.Get("sync_timeout").(int)) * time.Second
+		interval := time.Duration(d.Get("sync_poll_interval").(int)) * time.Second
+		pkg, err = waitForPackageRead(pc, namespace, repository, pkg.GetSlugPerm(), timeout, interval)
+		if err != nil {
+			return diag.FromErr(err)
+		}
 	}
 
 	d.Set("cdn_url", pkg.GetCdnUrl())
@@ -81,6 +419,7 @@ func dataSourcePackageRead(d *schema.ResourceData, m interface{}) error {
 	d.Set("name", pkg.GetName())
 	d.Set("slug", pkg.GetSlug())
 	d.Set("slug_perm", pkg.GetSlugPerm())
+	d.Set("tags", flattenPackageTags(pkg.GetTags()))
 	d.Set("version", pkg.GetVersion())
 	// Grab the checksum from API in case they don't want to download the file directly via terraform (when returning just the cdn_url)
 	d.Set("checksum_md5", pkg.GetChecksumMd5())
@@ -90,38 +429,93 @@ func dataSourcePackageRead(d *schema.ResourceData, m interface{}) error {
 
 	d.SetId(fmt.Sprintf("%s_%s_%s", namespace, repository, pkg.GetSlugPerm()))
 
+	if outputFilename == "" {
+		outputFilename = packageFilename(pkg, requiredString(d, "filename_strategy"))
+	}
+	d.Set("output_filename", outputFilename)
+
 	if !download {
+		if requiredBool(d, "extract_archive") {
+			return diag.Errorf("download must be true to extract the archive")
+		}
+		if requiredBool(d, "extended_checksums") {
+			return diag.Errorf("download must be true to calculate extended checksums")
+		}
+
 		d.Set("output_path", pkg.GetCdnUrl())
 		d.Set("output_directory", "")
 		return nil
 	}
 
+	if err := ensureDownloadDir(downloadDir, requiredBool(d, "output_dir_create")); err != nil {
+		return diag.FromErr(err)
+	}
+
 	bustCache := false
 	retryTimes := 0
 	var checksumError error = nil
 	var localChecksums Checksums
 
-	for retryTimes < 2 {
-		outputPath, err := downloadPackage(pkg.GetCdnUrl(), downloadDir, pc, bustCache)
+	// a package restored from the cache is verified like a download, so a
+	// corrupt cache entry is replaced by downloading the package again.
+	cached := false
+	if pc.CacheDir != "" {
+		outputPath := path.Join(downloadDir, outputFilename)
+		cached, err = restoreCachedPackage(pc.CacheDir, pc.CacheTTL, pkg.GetChecksumSha256(), outputPath, time.Now())
 		if err != nil {
-			return err
+			return diag.FromErr(err)
+		}
+		if cached {
+			tflog.Debug(ctx, "Package restored from cache", map[string]interface{}{
+				"slug_perm": pkg.GetSlugPerm(),
+				"cache_dir": pc.CacheDir,
+			})
+		}
+	}
+
+	for retryTimes < 2 {
+		outputPath := path.Join(downloadDir, outputFilename)
+		if cached {
+			if err := setPackageFileMode(outputPath, fileMode); err != nil {
+				return diag.FromErr(err)
+			}
+		} else {
+			outputPath, err = downloadPackage(ctx, pkg.GetCdnUrl(), downloadDir, outputFilename, fileMode, pc, bustCache)
+			if err != nil {
+				return diag.FromErr(checkPackageDeleted(pc, namespace, repository, pkg.GetSlugPerm(), err))
+			}
 		}
 
 		d.Set("output_path", outputPath)
 		d.Set("output_directory", downloadDir)
 
 		// Calculate checksums for the downloaded file
-		localChecksums, err = calculateChecksums(outputPath)
+		localChecksums, err = calculateChecksums(outputPath, requiredBool(d, "extended_checksums"))
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 
 		if ignoreChecksum {
-			fmt.Println("Warning: ignore_checksums set to true, downloading mismatched checksum file.")
+			tflog.Warn(ctx, "ignore_checksums is set, so the checksums of the downloaded package are not verified", map[string]interface{}{
+				"namespace": namespace,
+				"slug_perm": pkg.GetSlugPerm(),
+				"url":       pkg.GetCdnUrl(),
+			})
 			break
 		}
 
 		if checksumError = localChecksums.CompareWithPkg(pkg); checksumError != nil {
+			if cached {
+				cached = false
+				continue
+			}
+
+			tflog.Info(ctx, "Package checksum mismatch, downloading again with bustCache", map[string]interface{}{
+				"namespace": namespace,
+				"slug_perm": pkg.GetSlugPerm(),
+				"url":       pkg.GetCdnUrl(),
+				"error":     checksumError.Error(),
+			})
 			bustCache = true
 			retryTimes++
 		} else {
@@ -130,26 +524,122 @@ func dataSourcePackageRead(d *schema.ResourceData, m interface{}) error {
 	}
 
 	if checksumError != nil {
-		return checksumError
+		return diag.Diagnostics{checksumMismatchDiagnostic(localChecksums, pkg)}
+	}
+
+	if pc.CacheDir != "" && !cached && !ignoreChecksum {
+		if err := storeCachedPackage(pc.CacheDir, localChecksums.SHA256, requiredString(d, "output_path")); err != nil {
+			tflog.Warn(ctx, "Unable to cache package", map[string]interface{}{
+				"slug_perm": pkg.GetSlugPerm(),
+				"cache_dir": pc.CacheDir,
+				"error":     err.Error(),
+			})
+		}
 	}
 
 	d.Set("checksum_md5", localChecksums.MD5)
 	d.Set("checksum_sha1", localChecksums.SHA1)
 	d.Set("checksum_sha256", localChecksums.SHA256)
 	d.Set("checksum_sha512", localChecksums.SHA512)
+	// the API doesn't return SHA3 or BLAKE2 checksums, so these are only
+	// available for downloaded packages.
+	d.Set("checksum_sha3_256", localChecksums.SHA3_256)
+	d.Set("checksum_blake2b_256", localChecksums.BLAKE2b256)
+
+	if requiredBool(d, "extract_archive") {
+		extractDir := requiredString(d, "extract_dir")
+		if extractDir == "" {
+			extractDir = path.Join(downloadDir, pkg.GetSlugPerm())
+		}
+
+		files, err := extractArchive(requiredString(d, "output_path"), extractDir)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		d.Set("extract_dir", extractDir)
+		d.Set("extracted_files", files)
+	}
 
 	return nil
 }
 
-func downloadPackage(downloadUrl string, downloadDir string, pc *providerConfig, bustCache bool) (string, error) {
-	req, err := http.NewRequest(http.MethodGet, downloadUrl, nil)
+// ensureDownloadDir checks that the directory packages are downloaded to
+// exists, creating it if create is true.
+func ensureDownloadDir(downloadDir string, create bool) error {
+	info, err := os.Stat(downloadDir)
+	switch {
+	case err == nil && !info.IsDir():
+		return fmt.Errorf("download_dir %s is not a directory", downloadDir)
+	case err == nil:
+		return nil
+	case !errors.Is(err, os.ErrNotExist):
+		return err
+	case !create:
+		return fmt.Errorf("download_dir %s does not exist, set output_dir_create to true to create it", downloadDir)
+	}
+
+	return os.MkdirAll(downloadDir, 0o755)
+}
+
+// setPackageFileMode sets the permissions of a downloaded package to the given
+// octal mode, if one is set.
+func setPackageFileMode(outputPath, fileMode string) error {
+	if fileMode == "" {
+		return nil
+	}
+
+	mode, err := strconv.ParseUint(fileMode, 8, 32)
+	if err != nil {
+		return fmt.Errorf("invalid file mode %q: %w", fileMode, err)
+	}
+	return os.Chmod(outputPath, os.FileMode(mode))
+}
+
+func downloadPackage(ctx context.Context, downloadUrl string, downloadDir string, outputFilename string, fileMode string, pc *providerConfig, bustCache bool) (string, error) {
+	for attempt := 0; ; attempt++ {
+		outputPath, err := downloadPackageOnce(ctx, downloadUrl, downloadDir, outputFilename, pc, bustCache)
+		if err == nil {
+			if err := setPackageFileMode(outputPath, fileMode); err != nil {
+				return "", err
+			}
+			return outputPath, nil
+		}
+
+		// report cancellation as such, rather than whatever error the
+		// interrupted request happened to fail with.
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return "", ctxErr
+		}
+
+		if !isRetryableDownloadError(err) || attempt >= pc.MaxRetries {
+			return "", err
+		}
+
+		wait := downloadRetryWait(attempt, pc.RetryWaitMin, pc.RetryWaitMax)
+		tflog.Debug(ctx, "Package download failed, retrying", map[string]interface{}{
+			"url":     downloadUrl,
+			"attempt": attempt + 1,
+			"wait":    wait.String(),
+			"error":   err.Error(),
+		})
+
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+func downloadPackageOnce(ctx context.Context, downloadUrl string, downloadDir string, outputFilename string, pc *providerConfig, bustCache bool) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, downloadUrl, nil)
 	if err != nil {
 		return "", err
 	}
 
 	req.Header.Add("Authorization", fmt.Sprintf("Token %s", pc.GetAPIKey()))
 
-	client := pc.APIClient.GetConfig().HTTPClient
+	client := pc.DownloadClient()
 	if bustCache {
 		timestamp := time.Now().Unix()
 		parsedURL, err := url.Parse(downloadUrl)
@@ -166,33 +656,184 @@ func downloadPackage(downloadUrl string, downloadDir string, pc *providerConfig,
 
 	resp, err := client.Do(req)
 	if err != nil {
-		return "", err
+		return "", &retryableDownloadError{err: err}
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("failed to download file: %s, status code: %d", downloadUrl, resp.StatusCode)
+		err := fmt.Errorf("failed to download file: %s, status code: %d", downloadUrl, resp.StatusCode)
+		// 429 Too Many Requests has already been retried by the transport,
+		// honouring Retry-After, so retrying it here would only multiply the
+		// requests made while rate limited.
+		if resp.StatusCode >= http.StatusInternalServerError {
+			return "", &retryableDownloadError{err: err}
+		}
+		return "", err
 	}
 
-	// Extract filename from CDN URL
-	filename := path.Base(downloadUrl)
+	// Extract filename from CDN URL, unless one has been provided
+	filename := outputFilename
+	if filename == "" {
+		filename = path.Base(downloadUrl)
+	}
 	outputPath := path.Join(downloadDir, filename)
 
-	outputFile, err := os.Create(outputPath)
+	// download into a temporary file alongside the destination and only move
+	// it into place once complete, so that a failed download never leaves a
+	// truncated file at the output path.
+	tempFile, err := os.CreateTemp(downloadDir, "."+filename+".*.tmp")
 	if err != nil {
 		return "", err
 	}
-	defer outputFile.Close()
+	defer func() {
+		tempFile.Close()
+		os.Remove(tempFile.Name())
+	}()
 
-	_, err = io.Copy(outputFile, resp.Body)
-	if err != nil {
+	var body io.Reader = resp.Body
+	if pc.ShowDownloadProgress {
+		body = newProgressReader(ctx, resp.Body, filename, resp.ContentLength)
+	}
+
+	if _, err := io.Copy(tempFile, body); err != nil {
+		if errors.Is(err, io.ErrUnexpectedEOF) {
+			return "", &retryableDownloadError{err: err}
+		}
+		return "", err
+	}
+	if err := tempFile.Close(); err != nil {
+		return "", err
+	}
+
+	// temporary files are only readable by their owner, so match the
+	// permissions the package would have had if created directly.
+	if err := os.Chmod(tempFile.Name(), 0o644); err != nil {
+		return "", err
+	}
+	if err := os.Rename(tempFile.Name(), outputPath); err != nil {
 		return "", err
 	}
 
 	return outputPath, nil
 }
 
-func calculateChecksums(filePath string) (Checksums, error) {
+// downloadProgressInterval is the minimum time between progress log entries.
+var downloadProgressInterval = time.Second * 5
+
+// progressReader wraps the body of a package download, logging how much of it
+// has been read so that long downloads don't appear to have hung.
+type progressReader struct {
+	ctx      context.Context
+	reader   io.Reader
+	filename string
+	total    int64
+
+	read    int64
+	started time.Time
+	logged  time.Time
+}
+
+func newProgressReader(ctx context.Context, reader io.Reader, filename string, total int64) *progressReader {
+	now := time.Now()
+	return &progressReader{
+		ctx:      ctx,
+		reader:   reader,
+		filename: filename,
+		total:    total,
+		started:  now,
+		logged:   now,
+	}
+}
+
+func (r *progressReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.read += int64(n)
+
+	if err == io.EOF || time.Since(r.logged) >= downloadProgressInterval {
+		r.logged = time.Now()
+		tflog.Debug(r.ctx, "package download progress", r.fields())
+	}
+
+	return n, err
+}
+
+// fields returns the progress of the download as structured log fields.
+func (r *progressReader) fields() map[string]interface{} {
+	fields := map[string]interface{}{
+		"filename":         r.filename,
+		"bytes_downloaded": r.read,
+	}
+
+	if elapsed := time.Since(r.started).Seconds(); elapsed > 0 {
+		fields["bytes_per_second"] = int64(float64(r.read) / elapsed)
+	}
+
+	// the total size is unknown if the server didn't send a Content-Length
+	if r.total > 0 {
+		fields["bytes_total"] = r.total
+		fields["percent_complete"] = float64(r.read) / float64(r.total) * 100
+	}
+
+	return fields
+}
+
+// retryableDownloadError wraps errors caused by transient failures (server
+// errors or dropped connections) which are worth retrying.
+type retryableDownloadError struct {
+	err error
+}
+
+func (e *retryableDownloadError) Error() string {
+	return e.err.Error()
+}
+
+func (e *retryableDownloadError) Unwrap() error {
+	return e.err
+}
+
+func isRetryableDownloadError(err error) bool {
+	var retryable *retryableDownloadError
+	return errors.As(err, &retryable)
+}
+
+// downloadRetryWait returns the exponential backoff delay for the given retry
+// attempt, bounded by the configured minimum and maximum wait times.
+func downloadRetryWait(attempt int, waitMin, waitMax time.Duration) time.Duration {
+	wait := waitMin
+	for i := 0; i < attempt && wait < waitMax; i++ {
+		wait *= 2
+	}
+
+	if wait > waitMax {
+		return waitMax
+	}
+	return wait
+}
+
+const (
+	// checksumChunkSize is the size of each chunk read from the file when
+	// calculating checksums.
+	checksumChunkSize = 1 << 20
+
+	// checksumChunkCount is the number of chunks that can be in flight at
+	// once, allowing the file to be read ahead of the slowest hash.
+	checksumChunkCount = 4
+)
+
+// checksumChunk is a chunk of a file being hashed, shared by every hash.
+// pending counts the hashes that haven't yet consumed data, and must reach
+// zero before buf can be reused.
+type checksumChunk struct {
+	buf     []byte
+	data    []byte
+	pending sync.WaitGroup
+}
+
+// calculateChecksums reads the file at filePath once, in chunks which are
+// passed to a goroutine per hash so the hashes are calculated concurrently.
+// The SHA3-256 and BLAKE2b-256 checksums are only calculated if extended is
+// true.
+func calculateChecksums(filePath string, extended bool) (Checksums, error) {
 	var checksums Checksums
 
 	file, err := os.Open(filePath)
@@ -201,26 +842,77 @@ func calculateChecksums(filePath string) (Checksums, error) {
 	}
 	defer file.Close()
 
-	md5hash := md5.New()
-	sha1hash := sha1.New()
-	sha256hash := sha256.New()
-	sha512hash := sha512.New()
+	hashes := []hash.Hash{md5.New(), sha1.New(), sha256.New(), sha512.New()}
+	if extended {
+		// blake2b.New256 only returns an error for keys that are too long.
+		blake2b256, _ := blake2b.New256(nil)
+		hashes = append(hashes, sha3.New256(), blake2b256)
+	}
+
+	var wg sync.WaitGroup
+	channels := make([]chan *checksumChunk, len(hashes))
+	for i, h := range hashes {
+		channels[i] = make(chan *checksumChunk, checksumChunkCount)
+		wg.Add(1)
+		go func(h hash.Hash, chunks <-chan *checksumChunk) {
+			defer wg.Done()
+			for chunk := range chunks {
+				h.Write(chunk.data)
+				chunk.pending.Done()
+			}
+		}(h, channels[i])
+	}
 
-	if _, err := io.Copy(io.MultiWriter(md5hash, sha1hash, sha256hash, sha512hash), file); err != nil {
-		return checksums, err
+	chunks := make([]checksumChunk, checksumChunkCount)
+	for i := range chunks {
+		chunks[i].buf = make([]byte, checksumChunkSize)
+	}
+
+	var readErr error
+	for i := 0; ; i++ {
+		chunk := &chunks[i%len(chunks)]
+		chunk.pending.Wait()
+
+		n, err := file.Read(chunk.buf)
+		if n > 0 {
+			chunk.data = chunk.buf[:n]
+			chunk.pending.Add(len(hashes))
+			for _, ch := range channels {
+				ch <- chunk
+			}
+		}
+		if err != nil {
+			if !errors.Is(err, io.EOF) {
+				readErr = err
+			}
+			break
+		}
 	}
 
-	checksums.MD5 = hex.EncodeToString(md5hash.Sum(nil))
-	checksums.SHA1 = hex.EncodeToString(sha1hash.Sum(nil))
-	checksums.SHA256 = hex.EncodeToString(sha256hash.Sum(nil))
-	checksums.SHA512 = hex.EncodeToString(sha512hash.Sum(nil))
+	for _, ch := range channels {
+		close(ch)
+	}
+	wg.Wait()
+
+	if readErr != nil {
+		return checksums, readErr
+	}
+
+	checksums.MD5 = hex.EncodeToString(hashes[0].Sum(nil))
+	checksums.SHA1 = hex.EncodeToString(hashes[1].Sum(nil))
+	checksums.SHA256 = hex.EncodeToString(hashes[2].Sum(nil))
+	checksums.SHA512 = hex.EncodeToString(hashes[3].Sum(nil))
+	if extended {
+		checksums.SHA3_256 = hex.EncodeToString(hashes[4].Sum(nil))
+		checksums.BLAKE2b256 = hex.EncodeToString(hashes[5].Sum(nil))
+	}
 
 	return checksums, nil
 }
 
 func dataSourcePackage() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourcePackageRead,
+		ReadContext: dataSourcePackageReadWithContext,
 
 		Schema: map[string]*schema.Schema{
 			"cdn_url": {
@@ -248,6 +940,16 @@ func dataSourcePackage() *schema.Resource {
 				Description: "SHA512 hash of the package",
 				Computed:    true,
 			},
+			"checksum_sha3_256": {
+				Type:        schema.TypeString,
+				Description: "SHA3-256 hash of the downloaded package",
+				Computed:    true,
+			},
+			"checksum_blake2b_256": {
+				Type:        schema.TypeString,
+				Description: "BLAKE2b-256 hash of the downloaded package",
+				Computed:    true,
+			},
 			"download": {
 				Type:        schema.TypeBool,
 				Description: "If set to true, download the package",
@@ -260,6 +962,52 @@ func dataSourcePackage() *schema.Resource {
 				Optional:    true,
 				Default:     os.TempDir(),
 			},
+			"output_dir_create": {
+				Type:        schema.TypeBool,
+				Description: "If true, download_dir is created if it doesn't exist.",
+				Optional:    true,
+				Default:     false,
+			},
+			"extended_checksums": {
+				Type: schema.TypeBool,
+				Description: "If true, the SHA3-256 and BLAKE2b-256 checksums of the downloaded package are also " +
+					"calculated. Requires download to be true.",
+				Optional: true,
+				Default:  false,
+			},
+			"extract_archive": {
+				Type: schema.TypeBool,
+				Description: "If true, the downloaded package is extracted into extract_dir once its checksums " +
+					"are verified. Supports .zip, .tar, .tar.gz, .tgz, .tar.bz2, .tar.xz and .txz archives.",
+				Optional: true,
+				Default:  false,
+			},
+			"extract_dir": {
+				Type:        schema.TypeString,
+				Description: "The directory into which the package is extracted. Defaults to `<download_dir>/<slug_perm>`.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"extracted_files": {
+				Type:        schema.TypeList,
+				Description: "The paths of the extracted files, relative to extract_dir.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Computed:    true,
+			},
+			"filename_strategy": {
+				Type: schema.TypeString,
+				Description: "How the filename of the downloaded package is chosen when output_filename is not set: " +
+					"`url` uses the filename from the package's CDN URL, `name_version` uses the package name and " +
+					"version, and `slug_perm` uses the immutable identifier of the package.",
+				Optional:      true,
+				Default:       filenameStrategyURL,
+				ConflictsWith: []string{"output_filename"},
+				ValidateFunc: validation.StringInSlice([]string{
+					filenameStrategyNameVersion,
+					filenameStrategySlugPerm,
+					filenameStrategyURL,
+				}, false),
+			},
 			"format": {
 				Type:        schema.TypeString,
 				Description: "The format of the package",
@@ -273,10 +1021,18 @@ func dataSourcePackage() *schema.Resource {
 			},
 			"identifier": {
 				Type:         schema.TypeString,
-				Description:  "The identifier for this repository.",
-				Required:     true,
+				Description:  "The identifier for this package.",
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"identifier", "latest", "query", "version", "version_constraint"},
 				ValidateFunc: validation.StringIsNotEmpty,
 			},
+			"ignore_not_found": {
+				Type:        schema.TypeBool,
+				Description: "If true, the data source is left empty rather than raising an error when no package is found.",
+				Optional:    true,
+				Default:     false,
+			},
 			"is_sync_awaiting": {
 				Type:        schema.TypeBool,
 				Description: "Is the package awaiting synchronization",
@@ -302,10 +1058,29 @@ func dataSourcePackage() *schema.Resource {
 				Description: "Is the package synchronization currently in-progress",
 				Computed:    true,
 			},
+			"latest": {
+				Type: schema.TypeBool,
+				Description: "If true, the most recently uploaded package with the given `name` is used " +
+					"instead of an identifier.",
+				Optional:      true,
+				ConflictsWith: []string{"identifier", "query", "version", "version_constraint"},
+				RequiredWith:  []string{"name"},
+				// latest = false would otherwise count as selecting the package,
+				// both conflicting with every other way of doing so and
+				// selecting nothing by itself.
+				ValidateFunc: func(v interface{}, k string) ([]string, []error) {
+					if !v.(bool) {
+						return nil, []error{fmt.Errorf("%s must be true if set, omit it to select the package another way", k)}
+					}
+					return nil, nil
+				},
+			},
 			"name": {
-				Type:        schema.TypeString,
-				Description: "A descriptive name for the package.",
-				Computed:    true,
+				Type:         schema.TypeString,
+				Description:  "A descriptive name for the package. Required when `version`, `version_constraint` or `latest` is set.",
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
 			},
 			"namespace": {
 				Type:         schema.TypeString,
@@ -318,11 +1093,46 @@ func dataSourcePackage() *schema.Resource {
 				Description: "The directory where the file is downloaded",
 				Computed:    true,
 			},
+			"file_mode": {
+				Type: schema.TypeString,
+				Description: "The octal permissions to set on the downloaded package, e.g. `0755`. " +
+					"Defaults to the permissions the file was created with, subject to umask.",
+				Optional: true,
+				ValidateFunc: validation.StringMatch(
+					regexp.MustCompile(`^0?[0-7]{3}$`),
+					"must be an octal permission string, e.g. 0755",
+				),
+			},
+			"output_filename": {
+				Type: schema.TypeString,
+				Description: "The filename to save the downloaded package as. " +
+					"Defaults to a filename chosen by filename_strategy.",
+				Optional: true,
+				Computed: true,
+				ValidateFunc: validation.All(
+					validation.StringIsNotEmpty,
+					validation.StringDoesNotContainAny(`/\`),
+					validation.StringNotInSlice([]string{".", ".."}, false),
+				),
+			},
 			"output_path": {
 				Type:        schema.TypeString,
 				Description: "The location of the package",
 				Computed:    true,
 			},
+			"query": {
+				Type: schema.TypeString,
+				Description: "A search query used to find the package instead of an identifier, " +
+					"e.g. `name:mylib AND version:^1.`. The first matching package is used.",
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"query_single": {
+				Type:        schema.TypeBool,
+				Description: "If true, an error is raised when the query matches more than one package.",
+				Optional:    true,
+				Default:     false,
+			},
 			"repository": {
 				Type:         schema.TypeString,
 				Description:  "The repository of the package",
@@ -340,10 +1150,49 @@ func dataSourcePackage() *schema.Resource {
 					"It will never change once a package has been created.",
 				Computed: true,
 			},
+			"sync_poll_interval": {
+				Type:         schema.TypeInt,
+				Description:  "The time in seconds between reads of the package while waiting for it to sync.",
+				Optional:     true,
+				Default:      int(defaultPackageSyncPollInterval.Seconds()),
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"sync_timeout": {
+				Type:         schema.TypeInt,
+				Description:  "The time in seconds to wait for the package to finish synchronising.",
+				Optional:     true,
+				Default:      300,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"tags": {
+				Type: schema.TypeMap,
+				Description: "The tags attached to the package, keyed by tag type. " +
+					"Multiple tags of the same type are comma-separated.",
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Computed: true,
+			},
 			"version": {
-				Type:        schema.TypeString,
-				Description: "The version of the package",
-				Computed:    true,
+				Type: schema.TypeString,
+				Description: "The version of the package. If set, it's used with `name` to find the package " +
+					"instead of an identifier.",
+				Optional:     true,
+				Computed:     true,
+				RequiredWith: []string{"name"},
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"version_constraint": {
+				Type: schema.TypeString,
+				Description: "A version constraint, e.g. `~> 2.3`, used with `name` to select the highest " +
+					"matching version of a package instead of an identifier.",
+				Optional:     true,
+				RequiredWith: []string{"name"},
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"wait_for_sync": {
+				Type:        schema.TypeBool,
+				Description: "If true, wait for the package to finish synchronising before it is read.",
+				Optional:    true,
+				Default:     false,
 			},
 		},
 	}
@@ -0,0 +1,116 @@
+package cloudsmith
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cloudsmith-io/cloudsmith-api-go"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// dependencyVersionConstraint joins the operator and version of a dependency
+// into a single constraint, e.g. `>= 1.2`.
+func dependencyVersionConstraint(dependency cloudsmith.PackageDependency) string {
+	return strings.TrimSpace(dependency.GetOperator() + " " + dependency.GetVersion())
+}
+
+// flattenPackageDependencies converts the dependencies of a package to the
+// list of maps exposed by the data source. Dependencies share the format of
+// the package that depends on them.
+func flattenPackageDependencies(format string, dependencies []cloudsmith.PackageDependency) []interface{} {
+	out := make([]interface{}, len(dependencies))
+	for i, dependency := range dependencies {
+		out[i] = map[string]interface{}{
+			"dep_type":           dependency.GetDepType(),
+			"name":               dependency.GetName(),
+			"package_format":     format,
+			"version_constraint": dependencyVersionConstraint(dependency),
+		}
+	}
+	return out
+}
+
+func dataSourcePackageDependenciesRead(d *schema.ResourceData, m interface{}) error {
+	pc := m.(*providerConfig)
+
+	namespace := requiredString(d, "namespace")
+	repository := requiredString(d, "repository")
+	identifier := requiredString(d, "identifier")
+
+	pkgReq := pc.APIClient.PackagesApi.PackagesRead(pc.Auth, namespace, repository, identifier)
+	pkg, _, err := pc.APIClient.PackagesApi.PackagesReadExecute(pkgReq)
+	if err != nil {
+		return fmt.Errorf("error reading package (%s): %w", identifier, err)
+	}
+
+	req := pc.APIClient.PackagesApi.PackagesDependencies(pc.Auth, namespace, repository, identifier)
+	dependencies, _, err := pc.APIClient.PackagesApi.PackagesDependenciesExecute(req)
+	if err != nil {
+		return fmt.Errorf("error reading dependencies of package (%s): %w", identifier, err)
+	}
+
+	if err := d.Set("dependencies", flattenPackageDependencies(pkg.GetFormat(), dependencies.GetDependencies())); err != nil {
+		return err
+	}
+
+	d.SetId(fmt.Sprintf("%s_%s_%s", namespace, repository, identifier))
+
+	return nil
+}
+
+func dataSourcePackageDependencies() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourcePackageDependenciesRead,
+
+		Schema: map[string]*schema.Schema{
+			"dependencies": {
+				Type:        schema.TypeList,
+				Description: "The dependencies of the package.",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"dep_type": {
+							Type:        schema.TypeString,
+							Description: "The type of the dependency, e.g. `Depends` or `Build`.",
+							Computed:    true,
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Description: "The name of the dependency.",
+							Computed:    true,
+						},
+						"package_format": {
+							Type:        schema.TypeString,
+							Description: "The format of the dependency, which is the format of the package.",
+							Computed:    true,
+						},
+						"version_constraint": {
+							Type:        schema.TypeString,
+							Description: "The versions of the dependency that satisfy the package, e.g. `>= 1.2`.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"identifier": {
+				Type:         schema.TypeString,
+				Description:  "The slug_perm of the package.",
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"namespace": {
+				Type:         schema.TypeString,
+				Description:  "The namespace of the package.",
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"repository": {
+				Type:         schema.TypeString,
+				Description:  "The repository of the package.",
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+		},
+	}
+}
@@ -0,0 +1,94 @@
+//nolint:testpackage
+package cloudsmith
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/cloudsmith-io/cloudsmith-api-go"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestFlattenPackageDependencies(t *testing.T) {
+	t.Parallel()
+
+	withVersion := cloudsmith.PackageDependency{}
+	withVersion.SetDepType("Depends")
+	withVersion.SetName("requests")
+	withVersion.SetOperator(">=")
+	withVersion.SetVersion("2.0")
+
+	withoutVersion := cloudsmith.PackageDependency{}
+	withoutVersion.SetDepType("Depends")
+	withoutVersion.SetName("six")
+
+	expected := []interface{}{
+		map[string]interface{}{
+			"dep_type":           "Depends",
+			"name":               "requests",
+			"package_format":     "python",
+			"version_constraint": ">= 2.0",
+		},
+		map[string]interface{}{
+			"dep_type":           "Depends",
+			"name":               "six",
+			"package_format":     "python",
+			"version_constraint": "",
+		},
+	}
+
+	got := flattenPackageDependencies("python", []cloudsmith.PackageDependency{withVersion, withoutVersion})
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
+
+// TestAccDataSourcePackageDependencies_basic uploads a raw package, which has
+// no dependencies, and verifies that the data source returns an empty list.
+func TestAccDataSourcePackageDependencies_basic(t *testing.T) {
+	t.Parallel()
+
+	packageFile := filepath.Join(t.TempDir(), "terraform-acc-test-package-dependencies.txt")
+	if err := os.WriteFile(packageFile, []byte("terraform-acc-test-package-dependencies"), 0o600); err != nil {
+		t.Fatalf("unable to write package file: %s", err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccRepositoryCheckDestroy("cloudsmith_repository.test"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourcePackageDependenciesConfig(packageFile),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.cloudsmith_package_dependencies.test", "dependencies.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourcePackageDependenciesConfig(packageFile string) string {
+	return fmt.Sprintf(`
+resource "cloudsmith_repository" "test" {
+	name      = "terraform-acc-test-package-dependencies"
+	namespace = "%s"
+}
+
+resource "cloudsmith_package_upload" "test" {
+	namespace      = cloudsmith_repository.test.namespace
+	repository     = cloudsmith_repository.test.slug_perm
+	package_format = "raw"
+	package_file   = "%s"
+}
+
+data "cloudsmith_package_dependencies" "test" {
+	namespace  = cloudsmith_repository.test.namespace
+	repository = cloudsmith_repository.test.slug_perm
+	identifier = cloudsmith_package_upload.test.slug_perm
+}
+`, os.Getenv("CLOUDSMITH_NAMESPACE"), packageFile)
+}
@@ -1,17 +1,26 @@
 package cloudsmith
 
 import (
-	"log"
+	"context"
+	"fmt"
+	"os"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
 	"github.com/cloudsmith-io/cloudsmith-api-go"
 )
 
+// defaultPackageListPageSize is the page size used when a specific page of
+// packages is requested without a page size.
+const defaultPackageListPageSize = 25
+
 func retrievePackageListPage(pc *providerConfig, namespace string, repository string, query string, pageSize int64, pageCount int64) ([]cloudsmith.Package, int64, error) {
 	req := pc.APIClient.PackagesApi.PackagesList(pc.Auth, namespace, repository)
 	req = req.Page(pageCount)
@@ -53,7 +62,7 @@ func retrievePackageListPages(pc *providerConfig, namespace string, repository s
 	}
 
 	for pageCurrentCount <= pageCount {
-		packagesPage, _, err := retrievePackageListPage(pc, namespace, repository, query, pageSize, pageCount)
+		packagesPage, _, err := retrievePackageListPage(pc, namespace, repository, query, pageSize, pageCurrentCount)
 		if err != nil {
 			return nil, err
 		}
@@ -64,6 +73,83 @@ func retrievePackageListPages(pc *providerConfig, namespace string, repository s
 	return packagesList, nil
 }
 
+// downloadPackages downloads packages into downloadDir, with at most parallel
+// downloads in flight, verifying each against the checksums of the package.
+// Packages are named by their slug_perm so that packages with the same
+// filename don't overwrite each other. The path of each downloaded package is
+// returned keyed by slug_perm.
+func downloadPackages(ctx context.Context, pc *providerConfig, packages []cloudsmith.Package, downloadDir string, parallel int) (map[string]string, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	jobs := make(chan cloudsmith.Package)
+	outputPaths := make(map[string]string, len(packages))
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		firstErr error
+	)
+
+	for i := 0; i < parallel; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for pkg := range jobs {
+				outputPath, err := downloadListedPackage(ctx, pc, &pkg, downloadDir)
+
+				mu.Lock()
+				if err != nil && firstErr == nil {
+					firstErr = err
+					// stop the remaining downloads, as the read fails anyway.
+					cancel()
+				}
+				if err == nil {
+					outputPaths[pkg.GetSlugPerm()] = outputPath
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for _, pkg := range packages {
+		if ctx.Err() != nil {
+			break
+		}
+		jobs <- pkg
+	}
+	close(jobs)
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return outputPaths, nil
+}
+
+// downloadListedPackage downloads a single package for downloadPackages.
+func downloadListedPackage(ctx context.Context, pc *providerConfig, pkg *cloudsmith.Package, downloadDir string) (string, error) {
+	filename := packageFilename(pkg, filenameStrategySlugPerm)
+	outputPath, err := downloadPackage(ctx, pkg.GetCdnUrl(), downloadDir, filename, "", pc, false)
+	if err != nil {
+		return "", fmt.Errorf("error downloading package (%s): %w", pkg.GetSlugPerm(), err)
+	}
+
+	checksums, err := calculateChecksums(outputPath, false)
+	if err != nil {
+		return "", err
+	}
+	if err := checksums.CompareWithPkg(pkg); err != nil {
+		os.Remove(outputPath)
+		return "", fmt.Errorf("error downloading package (%s): %w", pkg.GetSlugPerm(), err)
+	}
+
+	tflog.Debug(ctx, "Downloaded package", map[string]interface{}{
+		"slug_perm":   pkg.GetSlugPerm(),
+		"output_path": outputPath,
+	})
+	return outputPath, nil
+}
+
 func buildQueryString(set *schema.Set) string {
 	var query strings.Builder
 	for _, v := range set.List() {
@@ -73,37 +159,73 @@ func buildQueryString(set *schema.Set) string {
 	return query.String()
 }
 
-func dataSourcePackageListRead(d *schema.ResourceData, m interface{}) error {
+func dataSourcePackageListRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	pc := m.(*providerConfig)
 
 	namespace := requiredString(d, "namespace")
 	repository := requiredString(d, "repository")
-	query := buildQueryString(d.Get("filters").(*schema.Set))
+	query := strings.TrimSpace(d.Get("query").(string) + " " + buildQueryString(d.Get("filters").(*schema.Set)))
 	mostRecent := requiredBool(d, "most_recent")
 
-	var pageCount, pageSize int64 = -1, -1
-	if mostRecent {
-		pageCount = 1
-		pageSize = 1
-	}
-	packagesList, err := retrievePackageListPages(pc, namespace, repository, query, pageSize, pageCount)
-	if err != nil {
-		return err
+	var packagesList []cloudsmith.Package
+	var err error
+	if page, ok := d.GetOk("page"); ok {
+		// a specific page was requested, so fetch only that page rather than
+		// scanning the whole repository.
+		pageSize := int64(defaultPackageListPageSize)
+		if v, ok := d.GetOk("page_size"); ok {
+			pageSize = int64(v.(int))
+		}
+
+		var pageTotal int64
+		packagesList, pageTotal, err = retrievePackageListPage(pc, namespace, repository, query, pageSize, int64(page.(int)))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		d.Set("page_total", pageTotal)
+	} else {
+		var pageCount, pageSize int64 = -1, int64(d.Get("page_size").(int))
+		if mostRecent {
+			pageCount = 1
+			pageSize = 1
+		}
+		packagesList, err = retrievePackageListPages(pc, namespace, repository, query, pageSize, pageCount)
+		if err != nil {
+			return diag.FromErr(err)
+		}
 	}
-	packages := flattenPackages(packagesList)
+	packages := flattenPackages(ctx, packagesList)
 	if err := d.Set("packages", packages); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
+	outputPaths := map[string]string{}
+	if requiredBool(d, "download") {
+		downloadDir := requiredString(d, "download_dir")
+		if err := ensureDownloadDir(downloadDir, false); err != nil {
+			return diag.FromErr(err)
+		}
+
+		outputPaths, err = downloadPackages(ctx, pc, packagesList, downloadDir, d.Get("parallel_downloads").(int))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	d.Set("output_paths", outputPaths)
+
 	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
 
 	return nil
 }
 
-func flattenPackages(packages []cloudsmith.Package) []interface{} {
+func flattenPackages(ctx context.Context, packages []cloudsmith.Package) []interface{} {
 	pkgs := make([]interface{}, len(packages))
 	for i, packageItem := range packages {
-		log.Printf("[DEBUG] package: %s", packageItem.GetName())
+		tflog.Debug(ctx, "Listed package", map[string]interface{}{
+			"namespace": packageItem.GetNamespace(),
+			"name":      packageItem.GetName(),
+			"slug_perm": packageItem.GetSlugPerm(),
+		})
 		pkg := make(map[string]interface{})
 		pkg["repository"] = packageItem.GetRepository()
 		pkg["namespace"] = packageItem.GetNamespace()
@@ -112,6 +234,12 @@ func flattenPackages(packages []cloudsmith.Package) []interface{} {
 		pkg["slug_perm"] = packageItem.GetSlugPerm()
 		pkg["format"] = packageItem.GetFormat()
 		pkg["version"] = packageItem.GetVersion()
+		pkg["cdn_url"] = packageItem.GetCdnUrl()
+		pkg["checksum_md5"] = packageItem.GetChecksumMd5()
+		pkg["checksum_sha1"] = packageItem.GetChecksumSha1()
+		pkg["checksum_sha256"] = packageItem.GetChecksumSha256()
+		pkg["checksum_sha512"] = packageItem.GetChecksumSha512()
+		pkg["tags"] = flattenPackageTags(packageItem.GetTags())
 		pkg["is_sync_awaiting"] = packageItem.GetIsSyncAwaiting()
 		pkg["is_sync_completed"] = packageItem.GetIsSyncCompleted()
 		pkg["is_sync_failed"] = packageItem.GetIsSyncFailed()
@@ -125,7 +253,7 @@ func flattenPackages(packages []cloudsmith.Package) []interface{} {
 
 func dataSourcePackageList() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourcePackageListRead,
+		ReadContext: dataSourcePackageListRead,
 
 		Schema: map[string]*schema.Schema{
 			"repository": {
@@ -140,6 +268,18 @@ func dataSourcePackageList() *schema.Resource {
 				Required:     true,
 				ValidateFunc: validation.StringIsNotEmpty,
 			},
+			"download": {
+				Type:        schema.TypeBool,
+				Description: "If true, every listed package is downloaded into download_dir.",
+				Optional:    true,
+				Default:     false,
+			},
+			"download_dir": {
+				Type:        schema.TypeString,
+				Description: "The directory into which packages are downloaded if download is set to true.",
+				Optional:    true,
+				Default:     os.TempDir(),
+			},
 			"filters": {
 				Type: schema.TypeSet,
 				Elem: &schema.Schema{
@@ -148,9 +288,46 @@ func dataSourcePackageList() *schema.Resource {
 				Optional: true,
 			},
 			"most_recent": {
-				Type:        schema.TypeBool,
-				Description: "Only return the most recent package",
-				Optional:    true,
+				Type:          schema.TypeBool,
+				Description:   "Only return the most recent package",
+				Optional:      true,
+				ConflictsWith: []string{"page"},
+			},
+			"output_paths": {
+				Type:        schema.TypeMap,
+				Description: "The paths of the downloaded packages, keyed by slug_perm.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Computed:    true,
+			},
+			"page": {
+				Type:         schema.TypeInt,
+				Description:  "The page of results to return. If not set, all pages are returned.",
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"page_size": {
+				Type:         schema.TypeInt,
+				Description:  "The number of packages per page. Defaults to 25 when `page` is set.",
+				Optional:     true,
+				ValidateFunc: validation.IntBetween(1, 100),
+			},
+			"page_total": {
+				Type:        schema.TypeInt,
+				Description: "The total number of pages of results, when `page` is set.",
+				Computed:    true,
+			},
+			"parallel_downloads": {
+				Type:         schema.TypeInt,
+				Description:  "The maximum number of packages downloaded at the same time.",
+				Optional:     true,
+				Default:      1,
+				ValidateFunc: validation.IntBetween(1, 10),
+			},
+			"query": {
+				Type:         schema.TypeString,
+				Description:  "A search query used to filter the packages, combined with any filters.",
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
 			},
 			"packages": {
 				Type:     schema.TypeList,
@@ -198,6 +375,32 @@ func dataSourcePackageList() *schema.Resource {
 							Description: "The CDN URL of the package to download.",
 							Computed:    true,
 						},
+						"checksum_md5": {
+							Type:        schema.TypeString,
+							Description: "MD5 hash of the package",
+							Computed:    true,
+						},
+						"checksum_sha1": {
+							Type:        schema.TypeString,
+							Description: "SHA1 hash of the package",
+							Computed:    true,
+						},
+						"checksum_sha256": {
+							Type:        schema.TypeString,
+							Description: "SHA256 hash of the package",
+							Computed:    true,
+						},
+						"checksum_sha512": {
+							Type:        schema.TypeString,
+							Description: "SHA512 hash of the package",
+							Computed:    true,
+						},
+						"tags": {
+							Type:        schema.TypeMap,
+							Description: "The tags attached to the package, keyed by tag type.",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Computed:    true,
+						},
 						"is_sync_awaiting": {
 							Type:        schema.TypeBool,
 							Description: "Is the package awaiting synchronisation",
@@ -0,0 +1,157 @@
+//nolint:testpackage
+package cloudsmith
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cloudsmith-io/cloudsmith-api-go"
+)
+
+// TestRetrievePackageListPages serves a paginated list of packages and
+// verifies that every page is fetched exactly once, and that a single page
+// can be fetched on its own.
+func TestRetrievePackageListPages(t *testing.T) {
+	t.Parallel()
+
+	const pageSize, pageTotal = 2, 3
+
+	requests := map[int]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		requests[page]++
+
+		packages := []map[string]string{}
+		for i := 0; i < pageSize; i++ {
+			packages = append(packages, map[string]string{
+				"slug_perm": fmt.Sprintf("package-%d-%d", page, i),
+			})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Pagination-Pagetotal", strconv.Itoa(pageTotal))
+		_ = json.NewEncoder(w).Encode(packages)
+	}))
+	defer server.Close()
+
+	pc := testProviderConfig(server.URL)
+
+	packages, err := retrievePackageListPages(pc, "namespace", "repository", "", pageSize, -1)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(packages) != pageSize*pageTotal {
+		t.Fatalf("expected %d packages, got %d", pageSize*pageTotal, len(packages))
+	}
+	for page := 1; page <= pageTotal; page++ {
+		if requests[page] != 1 {
+			t.Errorf("expected page %d to be requested once, got %d", page, requests[page])
+		}
+	}
+	if last := packages[len(packages)-1].GetSlugPerm(); last != fmt.Sprintf("package-%d-%d", pageTotal, pageSize-1) {
+		t.Errorf("unexpected last package %s", last)
+	}
+
+	packages, total, err := retrievePackageListPage(pc, "namespace", "repository", "", pageSize, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if total != pageTotal || len(packages) != pageSize || packages[0].GetSlugPerm() != "package-2-0" {
+		t.Errorf("unexpected page 2: total=%d, packages=%d", total, len(packages))
+	}
+}
+
+// TestDownloadPackages downloads several packages and verifies that each is
+// written to the download directory, that no more than the given number of
+// downloads are in flight at once, and that a checksum mismatch fails the
+// downloads.
+func TestDownloadPackages(t *testing.T) {
+	t.Parallel()
+
+	const parallel = 3
+
+	var (
+		mu                  sync.Mutex
+		inFlight, maxFlight int
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		inFlight++
+		if inFlight > maxFlight {
+			maxFlight = inFlight
+		}
+		mu.Unlock()
+
+		time.Sleep(20 * time.Millisecond)
+		fmt.Fprintf(w, "content of %s", strings.TrimPrefix(r.URL.Path, "/"))
+
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+	}))
+	defer server.Close()
+
+	newPackage := func(slugPerm string, content string) cloudsmith.Package {
+		contentPath := filepath.Join(t.TempDir(), slugPerm)
+		if err := os.WriteFile(contentPath, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		checksums, err := calculateChecksums(contentPath, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		pkg := cloudsmith.Package{}
+		pkg.SetSlugPerm(slugPerm)
+		pkg.SetFilename(slugPerm + ".txt")
+		pkg.SetCdnUrl(server.URL + "/" + slugPerm + ".txt")
+		pkg.SetChecksumMd5(checksums.MD5)
+		pkg.SetChecksumSha1(checksums.SHA1)
+		pkg.SetChecksumSha256(checksums.SHA256)
+		pkg.SetChecksumSha512(checksums.SHA512)
+		return pkg
+	}
+
+	packages := []cloudsmith.Package{}
+	for i := 0; i < 8; i++ {
+		slugPerm := fmt.Sprintf("package-%d", i)
+		packages = append(packages, newPackage(slugPerm, fmt.Sprintf("content of %s.txt", slugPerm)))
+	}
+
+	pc := testProviderConfig(server.URL)
+	downloadDir := t.TempDir()
+
+	outputPaths, err := downloadPackages(context.Background(), pc, packages, downloadDir, parallel)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(outputPaths) != len(packages) {
+		t.Fatalf("expected %d output paths, got %d", len(packages), len(outputPaths))
+	}
+	for _, pkg := range packages {
+		outputPath := filepath.Join(downloadDir, pkg.GetSlugPerm()+".txt")
+		if outputPaths[pkg.GetSlugPerm()] != outputPath {
+			t.Errorf("expected %s to be downloaded to %s, got %s", pkg.GetSlugPerm(), outputPath, outputPaths[pkg.GetSlugPerm()])
+		}
+		if err := checkFileContent(outputPath, fmt.Sprintf("content of %s.txt", pkg.GetSlugPerm())); err != nil {
+			t.Error(err)
+		}
+	}
+	if maxFlight > parallel {
+		t.Errorf("expected at most %d downloads in flight, got %d", parallel, maxFlight)
+	}
+
+	packages = append(packages, newPackage("corrupt", "other content"))
+	if _, err := downloadPackages(context.Background(), pc, packages, t.TempDir(), parallel); err == nil || !strings.Contains(err.Error(), "corrupt") {
+		t.Errorf("expected checksum mismatch error for corrupt package, got %v", err)
+	}
+}
@@ -0,0 +1,170 @@
+package cloudsmith
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cloudsmith-io/cloudsmith-api-go"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	statisticsPeriodDay   = "day"
+	statisticsPeriodWeek  = "week"
+	statisticsPeriodMonth = "month"
+)
+
+// statisticsPeriodStart returns the start of the period ending at now.
+func statisticsPeriodStart(now time.Time, period string) time.Time {
+	switch period {
+	case statisticsPeriodDay:
+		return now.AddDate(0, 0, -1)
+	case statisticsPeriodWeek:
+		return now.AddDate(0, 0, -7)
+	default:
+		return now.AddDate(0, -1, 0)
+	}
+}
+
+// bandwidthUnitSizes maps the units in which the API reports bandwidth to
+// their size in bytes. Larger amounts are reported in binary multiples.
+var bandwidthUnitSizes = map[string]int64{
+	"":      1,
+	"b":     1,
+	"bytes": 1,
+	"kb":    1 << 10,
+	"mb":    1 << 20,
+	"gb":    1 << 30,
+	"tb":    1 << 40,
+}
+
+// bandwidthBytes converts a bandwidth metric to bytes, as the API reports it
+// in whichever unit suits the amount, e.g. 3 with units of GB.
+func bandwidthBytes(value cloudsmith.CommonBandwidthMetricsValue) (int64, error) {
+	size, ok := bandwidthUnitSizes[strings.ToLower(strings.TrimSpace(value.GetUnits()))]
+	if !ok {
+		return 0, fmt.Errorf("unsupported bandwidth units %q", value.GetUnits())
+	}
+	return value.GetValue() * size, nil
+}
+
+// retrievePackageMetrics fetches the usage metrics of the packages in a
+// repository, optionally limited to the given packages and to usage since
+// start. All packages in the repository are included if slugPerms is empty,
+// and all recorded usage if start is zero.
+func retrievePackageMetrics(pc *providerConfig, namespace, repository, slugPerms string, start time.Time) (*cloudsmith.CommonMetrics, error) {
+	req := pc.APIClient.MetricsApi.MetricsPackagesList(pc.Auth, namespace, repository)
+	if slugPerms != "" {
+		req = req.Packages(slugPerms)
+	}
+	if !start.IsZero() {
+		req = req.Start(start.UTC().Format(time.RFC3339))
+	}
+
+	metrics, _, err := pc.APIClient.MetricsApi.MetricsPackagesListExecute(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &metrics.Packages, nil
+}
+
+func dataSourcePackageStatisticsRead(d *schema.ResourceData, m interface{}) error {
+	pc := m.(*providerConfig)
+
+	namespace := requiredString(d, "namespace")
+	repository := requiredString(d, "repository")
+	identifier := requiredString(d, "identifier")
+	period := requiredString(d, "period")
+
+	req := pc.APIClient.PackagesApi.PackagesRead(pc.Auth, namespace, repository, identifier)
+	pkg, _, err := pc.APIClient.PackagesApi.PackagesReadExecute(req)
+	if err != nil {
+		return fmt.Errorf("error reading package (%s): %w", identifier, err)
+	}
+	slugPerm := pkg.GetSlugPerm()
+
+	total, err := retrievePackageMetrics(pc, namespace, repository, slugPerm, time.Time{})
+	if err != nil {
+		return fmt.Errorf("error reading package (%s) metrics: %w", identifier, err)
+	}
+
+	inPeriod, err := retrievePackageMetrics(pc, namespace, repository, slugPerm, statisticsPeriodStart(time.Now(), period))
+	if err != nil {
+		return fmt.Errorf("error reading package (%s) metrics: %w", identifier, err)
+	}
+
+	bandwidth, err := bandwidthBytes(total.Bandwidth.Total)
+	if err != nil {
+		return fmt.Errorf("error reading package (%s) metrics: %w", identifier, err)
+	}
+
+	d.Set("bandwidth_total_bytes", bandwidth)
+	d.Set("downloads_in_period", inPeriod.Downloads.Total.GetValue())
+	d.Set("downloads_total", pkg.GetDownloads())
+	d.Set("slug_perm", slugPerm)
+
+	d.SetId(fmt.Sprintf("%s/%s/%s/%s", namespace, repository, slugPerm, period))
+
+	return nil
+}
+
+func dataSourcePackageStatistics() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourcePackageStatisticsRead,
+
+		Schema: map[string]*schema.Schema{
+			"bandwidth_total_bytes": {
+				Type:        schema.TypeInt,
+				Description: "The bandwidth used by downloads of the package in bytes, converted from the units reported by the API.",
+				Computed:    true,
+			},
+			"downloads_in_period": {
+				Type:        schema.TypeInt,
+				Description: "The number of times the package was downloaded during the period.",
+				Computed:    true,
+			},
+			"downloads_total": {
+				Type:        schema.TypeInt,
+				Description: "The number of times the package has been downloaded.",
+				Computed:    true,
+			},
+			"identifier": {
+				Type:         schema.TypeString,
+				Description:  "The identifier of the package.",
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"namespace": {
+				Type:         schema.TypeString,
+				Description:  "Namespace to which the package belongs.",
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"period": {
+				Type:        schema.TypeString,
+				Description: "The period ending now over which downloads_in_period is counted, one of `day`, `week` or `month`.",
+				Optional:    true,
+				Default:     statisticsPeriodMonth,
+				ValidateFunc: validation.StringInSlice([]string{
+					statisticsPeriodDay,
+					statisticsPeriodWeek,
+					statisticsPeriodMonth,
+				}, false),
+			},
+			"repository": {
+				Type:         schema.TypeString,
+				Description:  "Repository to which the package belongs.",
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"slug_perm": {
+				Type:        schema.TypeString,
+				Description: "The slug_perm of the package.",
+				Computed:    true,
+			},
+		},
+	}
+}
@@ -0,0 +1,120 @@
+//nolint:testpackage
+package cloudsmith
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/cloudsmith-io/cloudsmith-api-go"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestStatisticsPeriodStart(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2023, time.March, 15, 12, 0, 0, 0, time.UTC)
+
+	tests := map[string]time.Time{
+		statisticsPeriodDay:   time.Date(2023, time.March, 14, 12, 0, 0, 0, time.UTC),
+		statisticsPeriodWeek:  time.Date(2023, time.March, 8, 12, 0, 0, 0, time.UTC),
+		statisticsPeriodMonth: time.Date(2023, time.February, 15, 12, 0, 0, 0, time.UTC),
+	}
+
+	for period, want := range tests {
+		if got := statisticsPeriodStart(now, period); !got.Equal(want) {
+			t.Errorf("statisticsPeriodStart(%s) = %s, want %s", period, got, want)
+		}
+	}
+}
+
+func TestBandwidthBytes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		units   string
+		value   int64
+		want    int64
+		wantErr bool
+	}{
+		{units: "bytes", value: 512, want: 512},
+		{units: "KB", value: 3, want: 3 * 1024},
+		{units: "MB", value: 3, want: 3 * 1024 * 1024},
+		{units: "GB", value: 3, want: 3 * 1024 * 1024 * 1024},
+		{units: "furlongs", value: 3, wantErr: true},
+	}
+
+	for _, tc := range tests {
+		value := cloudsmith.NewCommonBandwidthMetricsValue("", tc.value)
+		value.SetUnits(tc.units)
+
+		got, err := bandwidthBytes(*value)
+		if tc.wantErr {
+			if err == nil {
+				t.Errorf("bandwidthBytes(%d %s): expected an error, got nil", tc.value, tc.units)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("bandwidthBytes(%d %s): unexpected error: %s", tc.value, tc.units, err)
+		}
+		if got != tc.want {
+			t.Errorf("bandwidthBytes(%d %s) = %d, want %d", tc.value, tc.units, got, tc.want)
+		}
+	}
+}
+
+// TestAccDataSourcePackageStatistics_basic uploads a raw package, which hasn't
+// been downloaded, and verifies that its statistics are read.
+func TestAccDataSourcePackageStatistics_basic(t *testing.T) {
+	t.Parallel()
+
+	packageFile := filepath.Join(t.TempDir(), "terraform-acc-test-package-statistics.txt")
+	if err := os.WriteFile(packageFile, []byte("terraform-acc-test-package-statistics"), 0o600); err != nil {
+		t.Fatalf("unable to write package file: %s", err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccRepositoryCheckDestroy("cloudsmith_repository.test"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourcePackageStatisticsConfig(packageFile),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.cloudsmith_package_statistics.test", "downloads_total", "0"),
+					resource.TestCheckResourceAttr("data.cloudsmith_package_statistics.test", "downloads_in_period", "0"),
+					resource.TestCheckResourceAttrPair(
+						"data.cloudsmith_package_statistics.test", "slug_perm",
+						"cloudsmith_package_upload.test", "slug_perm",
+					),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourcePackageStatisticsConfig(packageFile string) string {
+	return fmt.Sprintf(`
+resource "cloudsmith_repository" "test" {
+	name      = "terraform-acc-test-package-statistics"
+	namespace = "%s"
+}
+
+resource "cloudsmith_package_upload" "test" {
+	namespace      = cloudsmith_repository.test.namespace
+	repository     = cloudsmith_repository.test.slug_perm
+	package_format = "raw"
+	package_file   = "%s"
+}
+
+data "cloudsmith_package_statistics" "test" {
+	namespace  = cloudsmith_repository.test.namespace
+	repository = cloudsmith_repository.test.slug_perm
+	identifier = cloudsmith_package_upload.test.slug_perm
+	period     = "week"
+}
+`, os.Getenv("CLOUDSMITH_NAMESPACE"), packageFile)
+}
@@ -0,0 +1,149 @@
+package cloudsmith
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/cloudsmith-io/cloudsmith-api-go"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const defaultPackageTagSearchMaxResults = 100
+
+// packageTagSearchTags converts a map of tags into the tags applied to
+// packages, where each is `key:value`, or just `key` if the value is empty.
+// The tags are sorted so the query built from them is stable.
+func packageTagSearchTags(tags map[string]interface{}) []string {
+	out := make([]string, 0, len(tags))
+	for key, value := range tags {
+		if value.(string) == "" {
+			out = append(out, key)
+		} else {
+			out = append(out, fmt.Sprintf("%s:%s", key, value))
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// packageTagSearchQuery builds a search query matching packages with every
+// one of the given tags.
+func packageTagSearchQuery(tags []string) string {
+	terms := make([]string, len(tags))
+	for i, tag := range tags {
+		terms[i] = fmt.Sprintf("tag:%s", strconv.Quote(tag))
+	}
+	return strings.Join(terms, " AND ")
+}
+
+// filterPackagesByTags returns the packages with every one of the given tags,
+// as search queries also match tags partially.
+func filterPackagesByTags(packages []cloudsmith.Package, tags []string) []cloudsmith.Package {
+	filtered := []cloudsmith.Package{}
+	for _, pkg := range packages {
+		applied := map[string]bool{}
+		for _, tag := range packageInfoTags(pkg.GetTags()) {
+			applied[tag] = true
+		}
+
+		matches := true
+		for _, tag := range tags {
+			if !applied[tag] {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			filtered = append(filtered, pkg)
+		}
+	}
+	return filtered
+}
+
+func dataSourcePackageTagSearchRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	pc := m.(*providerConfig)
+
+	namespace := requiredString(d, "namespace")
+	repository := requiredString(d, "repository")
+	maxResults := int64(d.Get("max_results").(int))
+	tags := packageTagSearchTags(d.Get("tags").(map[string]interface{}))
+	query := packageTagSearchQuery(tags)
+
+	packages, pageTotal, err := retrievePackageListPage(pc, namespace, repository, query, maxResults, 1)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("packages", flattenPackages(ctx, filterPackagesByTags(packages, tags))); err != nil {
+		return diag.FromErr(err)
+	}
+	d.Set("query", query)
+
+	d.SetId(fmt.Sprintf("%s_%s_%s", namespace, repository, strconv.Itoa(schema.HashString(query))))
+
+	if pageTotal > 1 {
+		return diag.Diagnostics{{
+			Severity: diag.Warning,
+			Summary:  "Package tag search results truncated",
+			Detail: fmt.Sprintf(
+				"More than %d packages in %s/%s match the tags, so only the first %d are returned. "+
+					"Increase max_results, or add tags to narrow the search.",
+				maxResults, namespace, repository, maxResults,
+			),
+		}}
+	}
+
+	return nil
+}
+
+func dataSourcePackageTagSearch() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcePackageTagSearchRead,
+
+		Schema: map[string]*schema.Schema{
+			"max_results": {
+				Type: schema.TypeInt,
+				Description: "The maximum number of packages to return. A warning is shown if more " +
+					"packages match the tags.",
+				Optional:     true,
+				Default:      defaultPackageTagSearchMaxResults,
+				ValidateFunc: validation.IntBetween(1, 100),
+			},
+			"namespace": {
+				Type:         schema.TypeString,
+				Description:  "The namespace to which the packages belong.",
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"packages": {
+				Type:        schema.TypeList,
+				Description: "The packages with every one of the tags, most recent first.",
+				Computed:    true,
+				Elem:        dataSourcePackageList().Schema["packages"].Elem,
+			},
+			"query": {
+				Type:        schema.TypeString,
+				Description: "The search query generated from the tags.",
+				Computed:    true,
+			},
+			"repository": {
+				Type:         schema.TypeString,
+				Description:  "The repository to which the packages belong.",
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"tags": {
+				Type: schema.TypeMap,
+				Description: "The tags the packages must have. Each entry matches a tag of the form " +
+					"`key:value`, or `key` if the value is empty.",
+				Required: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
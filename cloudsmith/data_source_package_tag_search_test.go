@@ -0,0 +1,110 @@
+//nolint:testpackage
+package cloudsmith
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/cloudsmith-io/cloudsmith-api-go"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestPackageTagSearchQuery(t *testing.T) {
+	t.Parallel()
+
+	tags := packageTagSearchTags(map[string]interface{}{
+		"promoted": "true",
+		"approved": "",
+	})
+	if expected := []string{"approved", "promoted:true"}; !reflect.DeepEqual(tags, expected) {
+		t.Fatalf("expected tags %v, got %v", expected, tags)
+	}
+
+	expected := `tag:"approved" AND tag:"promoted:true"`
+	if query := packageTagSearchQuery(tags); query != expected {
+		t.Errorf("expected query %s, got %s", expected, query)
+	}
+}
+
+func TestFilterPackagesByTags(t *testing.T) {
+	t.Parallel()
+
+	newPackage := func(slugPerm string, tags ...interface{}) cloudsmith.Package {
+		pkg := cloudsmith.Package{}
+		pkg.SetSlugPerm(slugPerm)
+		pkg.SetTags(map[string]interface{}{packageTagType: tags})
+		return pkg
+	}
+	packages := []cloudsmith.Package{
+		newPackage("promoted", "promoted:true", "approved"),
+		newPackage("unpromoted", "promoted:false", "approved"),
+		newPackage("untagged"),
+	}
+
+	filtered := filterPackagesByTags(packages, []string{"approved", "promoted:true"})
+	if len(filtered) != 1 || filtered[0].GetSlugPerm() != "promoted" {
+		t.Errorf("expected only the promoted package, got %v", filtered)
+	}
+}
+
+// TestAccDataSourcePackageTagSearch_basic uploads and tags a raw package and
+// verifies that it's found by its tags.
+func TestAccDataSourcePackageTagSearch_basic(t *testing.T) {
+	t.Parallel()
+
+	packageFile := filepath.Join(t.TempDir(), "terraform-acc-test-package-tag-search.txt")
+	if err := os.WriteFile(packageFile, []byte("terraform-acc-test-package-tag-search"), 0o600); err != nil {
+		t.Fatalf("unable to write package file: %s", err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccRepositoryCheckDestroy("cloudsmith_repository.test"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourcePackageTagSearchConfig(packageFile),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.cloudsmith_package_tag_search.test", "packages.#", "1"),
+					resource.TestCheckResourceAttrPair(
+						"data.cloudsmith_package_tag_search.test", "packages.0.slug_perm",
+						"cloudsmith_package_upload.test", "slug_perm",
+					),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourcePackageTagSearchConfig(packageFile string) string {
+	return fmt.Sprintf(`
+resource "cloudsmith_repository" "test" {
+	name      = "terraform-acc-test-package-tag-search"
+	namespace = "%s"
+}
+
+resource "cloudsmith_package_upload" "test" {
+	namespace      = cloudsmith_repository.test.namespace
+	repository     = cloudsmith_repository.test.slug_perm
+	package_format = "raw"
+	package_file   = "%s"
+}
+
+resource "cloudsmith_package_tag" "test" {
+	namespace  = cloudsmith_repository.test.namespace
+	repository = cloudsmith_repository.test.slug_perm
+	identifier = cloudsmith_package_upload.test.slug_perm
+	tags       = ["promoted:true"]
+}
+
+data "cloudsmith_package_tag_search" "test" {
+	namespace  = cloudsmith_repository.test.namespace
+	repository = cloudsmith_repository.test.slug_perm
+	tags       = { promoted = "true" }
+	depends_on = [cloudsmith_package_tag.test]
+}
+`, os.Getenv("CLOUDSMITH_NAMESPACE"), packageFile)
+}
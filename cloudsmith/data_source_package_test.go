@@ -2,20 +2,33 @@ package cloudsmith
 
 import (
 	"bytes"
+	"context"
+	"crypto/md5"
+	"crypto/sha1"
 	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"hash"
 	"io"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"regexp"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/cloudsmith-io/cloudsmith-api-go"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"golang.org/x/crypto/blake2b"
+	"golang.org/x/crypto/sha3"
 )
 
 var (
@@ -47,11 +60,24 @@ func TestAccPackage_data(t *testing.T) {
 					resource.TestCheckResourceAttr("data.cloudsmith_package.test", "repository", dsPackageTestRepository),
 				),
 			},
+			{
+				Config: testAccPackageDataReadPackageQuery(dsPackageTestNamespace, dsPackageTestRepository),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.cloudsmith_package.test", "format", "raw"),
+					resource.TestCheckResourceAttrPair(
+						"data.cloudsmith_package.test", "identifier",
+						"data.cloudsmith_package.test", "slug_perm",
+					),
+				),
+			},
 			{
 				Config: testAccPackageDataReadPackageDownload(dsPackageTestNamespace, dsPackageTestRepository),
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr("data.cloudsmith_package.test", "namespace", dsPackageTestNamespace),
 					resource.TestCheckResourceAttr("data.cloudsmith_package.test", "repository", dsPackageTestRepository),
+					testAccCheckPackageDownloadChecksum("data.cloudsmith_package.test"),
+					resource.TestCheckResourceAttrSet("data.cloudsmith_package.test", "checksum_sha3_256"),
+					resource.TestCheckResourceAttrSet("data.cloudsmith_package.test", "checksum_blake2b_256"),
 					// Custom TestCheckFunc to check if the file exists at the output path
 					func(s *terraform.State) error {
 						filePath := filepath.Join(os.TempDir(), "hello.txt")
@@ -80,6 +106,13 @@ func TestAccPackage_data(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr("data.cloudsmith_package.test", "namespace", dsPackageTestNamespace),
 					resource.TestCheckResourceAttr("data.cloudsmith_package.test", "repository", dsPackageTestRepository),
+					// pass the package to the next step through a variable, as
+					// it can no longer be listed once deleted.
+					func(s *terraform.State) error {
+						slugPerm := s.RootModule().Resources["data.cloudsmith_package.test"].Primary.Attributes["slug_perm"]
+						t.Cleanup(func() { os.Unsetenv("TF_VAR_deleted_package") })
+						return os.Setenv("TF_VAR_deleted_package", slugPerm)
+					},
 					func(s *terraform.State) error {
 						filePath := filepath.Join(os.TempDir(), "hello.txt")
 						if _, err := os.Stat(filePath); os.IsNotExist(err) {
@@ -95,9 +128,178 @@ func TestAccPackage_data(t *testing.T) {
 					},
 				),
 			},
+			{
+				PreConfig: func() {
+					pc := testAccProvider.Meta().(*providerConfig)
+					req := pc.APIClient.PackagesApi.PackagesDelete(pc.Auth, dsPackageTestNamespace, dsPackageTestRepository, os.Getenv("TF_VAR_deleted_package"))
+					if _, err := pc.APIClient.PackagesApi.PackagesDeleteExecute(req); err != nil {
+						t.Fatalf("failed to delete package: %s", err)
+					}
+				},
+				Config:      testAccPackageDataReadPackageDeleted(dsPackageTestNamespace, dsPackageTestRepository),
+				ExpectError: regexp.MustCompile("was not found in .+ - it may have been deleted"),
+			},
 		},
 	})
 }
+func TestDownloadPackage_retries(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		failures     int
+		failStatus   int
+		maxRetries   int
+		wantRequests int
+		wantErr      bool
+	}{
+		{name: "ServerErrorRecovers", failures: 2, failStatus: http.StatusServiceUnavailable, maxRetries: 3, wantRequests: 3},
+		{name: "RateLimitRecovers", failures: 1, failStatus: http.StatusTooManyRequests, maxRetries: 3, wantRequests: 2},
+		{name: "RateLimitExhausted", failures: 10, failStatus: http.StatusTooManyRequests, maxRetries: 3, wantRequests: maxRateLimitRetries + 1, wantErr: true},
+		{name: "RetriesExhausted", failures: 5, failStatus: http.StatusBadGateway, maxRetries: 1, wantRequests: 2, wantErr: true},
+		{name: "NotRetryable", failures: 1, failStatus: http.StatusNotFound, maxRetries: 3, wantRequests: 1, wantErr: true},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			requests := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				if requests <= tc.failures {
+					w.Header().Set("Retry-After", "0")
+					w.WriteHeader(tc.failStatus)
+					return
+				}
+				fmt.Fprint(w, "Hello world")
+			}))
+			defer server.Close()
+
+			pc := testProviderConfig(server.URL)
+			pc.APIClient.GetConfig().HTTPClient.Transport = newHTTPTransport(nil, nil, nil, 0, nil)
+			pc.MaxRetries = tc.maxRetries
+
+			outputPath, err := downloadPackage(context.Background(), server.URL+"/hello.txt", t.TempDir(), "", "", pc, false)
+			if requests != tc.wantRequests {
+				t.Errorf("expected %d requests, got %d", tc.wantRequests, requests)
+			}
+			if tc.wantErr {
+				if err == nil {
+					t.Fatal("expected an error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if err := checkFileContent(outputPath, "Hello world"); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+// TestDownloadPackage_cancelled cancels the context part way through a
+// download and verifies that the download stops and the partially written
+// file is removed.
+func TestDownloadPackage_cancelled(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	done := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Length", "1048576")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, "partial content")
+		w.(http.Flusher).Flush()
+
+		// cancel once the client has started receiving the body, then stall
+		// until the client goes away.
+		cancel()
+		select {
+		case <-r.Context().Done():
+		case <-done:
+		}
+	}))
+	defer server.Close()
+	defer close(done)
+
+	pc := testProviderConfig(server.URL)
+	pc.MaxRetries = 3
+
+	downloadDir := t.TempDir()
+	_, err := downloadPackage(ctx, server.URL+"/large.bin", downloadDir, "", "", pc, false)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(downloadDir, "large.bin")); !os.IsNotExist(err) {
+		t.Fatalf("expected partial download to be removed, got %v", err)
+	}
+}
+
+func TestFlattenPackageTags(t *testing.T) {
+	t.Parallel()
+
+	tags := map[string]interface{}{
+		"info":    []interface{}{"latest", "stable"},
+		"version": []interface{}{"1.0.0"},
+		"empty":   []interface{}{},
+	}
+
+	flattened := flattenPackageTags(tags)
+	expected := map[string]string{"info": "latest,stable", "version": "1.0.0", "empty": ""}
+	if len(flattened) != len(expected) {
+		t.Fatalf("expected %d tag types, got %d", len(expected), len(flattened))
+	}
+	for tagType, want := range expected {
+		if got := flattened[tagType]; got != want {
+			t.Errorf("tag type %q: expected %q, got %q", tagType, want, got)
+		}
+	}
+}
+
+func TestDownloadRetryWait(t *testing.T) {
+	t.Parallel()
+
+	waitMin, waitMax := time.Second, 10*time.Second
+	expected := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 10 * time.Second, 10 * time.Second}
+	for attempt, want := range expected {
+		if got := downloadRetryWait(attempt, waitMin, waitMax); got != want {
+			t.Errorf("attempt %d: expected wait of %s, got %s", attempt, want, got)
+		}
+	}
+}
+
+// testAccCheckPackageDownloadChecksum verifies that the package downloaded by
+// a data source exists at its output_path and that the file matches its
+// checksum_sha256.
+func testAccCheckPackageDownloadChecksum(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", resourceName)
+		}
+
+		outputPath := rs.Primary.Attributes["output_path"]
+		content, err := os.ReadFile(outputPath)
+		if err != nil {
+			return fmt.Errorf("error reading downloaded package: %w", err)
+		}
+
+		sum := sha256.Sum256(content)
+		if actual, expected := hex.EncodeToString(sum[:]), rs.Primary.Attributes["checksum_sha256"]; actual != expected {
+			return fmt.Errorf("expected %s to have sha256 %s, got %s", outputPath, expected, actual)
+		}
+
+		return nil
+	}
+}
+
 func checkFileContent(filePath string, expectedContent string) error {
 	content, err := os.ReadFile(filePath)
 	if err != nil {
@@ -234,6 +436,23 @@ func testAccPackageDataReadPackage(namespace, repository string) string {
 		`, repository, namespace, repository, namespace, repository, namespace)
 }
 
+func testAccPackageDataReadPackageQuery(namespace, repository string) string {
+	return fmt.Sprintf(`
+		resource "cloudsmith_repository" "test" {
+			name      = "%s"
+			namespace = "%s"
+			replace_packages_by_default = true
+		}
+
+		data "cloudsmith_package" "test" {
+			repository   = cloudsmith_repository.test.name
+			namespace    = cloudsmith_repository.test.namespace
+			query        = "filename:hello.txt"
+			query_single = true
+		}
+		`, repository, namespace)
+}
+
 func testAccPackageDataReadPackageDownload(namespace, repository string) string {
 	return fmt.Sprintf(`
 		resource "cloudsmith_repository" "test" {
@@ -252,10 +471,32 @@ func testAccPackageDataReadPackageDownload(namespace, repository string) string
 			namespace  = "%s"
 			identifier = data.cloudsmith_package_list.test.packages[0].slug_perm
 			download   = true
+
+			extended_checksums = true
 		}
 		`, repository, namespace, repository, namespace, repository, namespace)
 }
 
+func testAccPackageDataReadPackageDeleted(namespace, repository string) string {
+	return fmt.Sprintf(`
+		resource "cloudsmith_repository" "test" {
+			name      = "%s"
+			namespace = "%s"
+			replace_packages_by_default = true
+		}
+
+		variable "deleted_package" {
+			type = string
+		}
+
+		data "cloudsmith_package" "test" {
+			repository = "%s"
+			namespace  = "%s"
+			identifier = var.deleted_package
+		}
+		`, repository, namespace, repository, namespace)
+}
+
 func testAccPackageDataReadPackageDownloadRepublish(namespace, repository string) string {
 	return fmt.Sprintf(`
 		resource "cloudsmith_repository" "test" {
@@ -277,3 +518,1113 @@ func testAccPackageDataReadPackageDownloadRepublish(namespace, repository string
 		}
 		`, repository, namespace, repository, namespace, repository, namespace)
 }
+
+// TestCalculateChecksums verifies that checksums calculated by reading the
+// file match those computed by hashing the file contents directly.
+func TestCalculateChecksums(t *testing.T) {
+	t.Parallel()
+
+	content := bytes.Repeat([]byte("terraform-provider-cloudsmith"), 100000)
+	filePath := filepath.Join(t.TempDir(), "checksums.txt")
+	if err := os.WriteFile(filePath, content, 0o600); err != nil {
+		t.Fatalf("unable to write test file: %s", err)
+	}
+
+	checksums, err := calculateChecksums(filePath, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	md5sum := md5.Sum(content)
+	sha1sum := sha1.Sum(content)
+	sha256sum := sha256.Sum256(content)
+	sha512sum := sha512.Sum512(content)
+
+	expected := Checksums{
+		MD5:    hex.EncodeToString(md5sum[:]),
+		SHA1:   hex.EncodeToString(sha1sum[:]),
+		SHA256: hex.EncodeToString(sha256sum[:]),
+		SHA512: hex.EncodeToString(sha512sum[:]),
+	}
+	if checksums != expected {
+		t.Fatalf("expected %+v, got %+v", expected, checksums)
+	}
+
+	checksums, err = calculateChecksums(filePath, true)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	sha3sum := sha3.Sum256(content)
+	blake2bsum := blake2b.Sum256(content)
+
+	expected.SHA3_256 = hex.EncodeToString(sha3sum[:])
+	expected.BLAKE2b256 = hex.EncodeToString(blake2bsum[:])
+	if checksums != expected {
+		t.Fatalf("expected %+v, got %+v", expected, checksums)
+	}
+
+	if _, err := calculateChecksums(filepath.Join(t.TempDir(), "missing.txt"), false); err == nil {
+		t.Fatal("expected error for missing file")
+	}
+}
+
+// TestCalculateChecksums_golden verifies the checksums of a known file against
+// precomputed values, so a change to how any of them is encoded is caught.
+func TestCalculateChecksums_golden(t *testing.T) {
+	t.Parallel()
+
+	filePath := filepath.Join(t.TempDir(), "hello.txt")
+	if err := os.WriteFile(filePath, []byte("Hello world"), 0o600); err != nil {
+		t.Fatalf("unable to write test file: %s", err)
+	}
+
+	checksums, err := calculateChecksums(filePath, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := Checksums{
+		MD5:    "3e25960a79dbc69b674cd4ec67a72c62",
+		SHA1:   "7b502c3a1f48c8609ae212cdfb639dee39673f5e",
+		SHA256: "64ec88ca00b268e5ba1a35678a1b5316d212f4f366b2477232534a8aeca37f3c",
+		SHA512: "b7f783baed8297f0db917462184ff4f08e69c2d5e5f79a942600f9725f58ce1f" +
+			"29c18139bf80b06c0fff2bdd34738452ecf40c488c22a7e3d80cdf6f9c1c0d47",
+	}
+	if checksums != expected {
+		t.Fatalf("expected %+v, got %+v", expected, checksums)
+	}
+}
+
+// TestCalculateChecksums_sequential verifies that the checksums calculated
+// concurrently match those of a single sequential pass over the file, for
+// sizes on either side of the chunk boundaries.
+func TestCalculateChecksums_sequential(t *testing.T) {
+	t.Parallel()
+
+	sizes := []int{
+		0,
+		1,
+		checksumChunkSize - 1,
+		checksumChunkSize,
+		checksumChunkSize + 1,
+		checksumChunkSize*(checksumChunkCount+2) + checksumChunkSize/2,
+	}
+
+	for _, size := range sizes {
+		size := size
+		t.Run(fmt.Sprintf("size=%d", size), func(t *testing.T) {
+			t.Parallel()
+
+			content := make([]byte, size)
+			for i := range content {
+				content[i] = byte(i * 31 % 251)
+			}
+			filePath := filepath.Join(t.TempDir(), "checksums.bin")
+			if err := os.WriteFile(filePath, content, 0o600); err != nil {
+				t.Fatalf("unable to write test file: %s", err)
+			}
+
+			expected, err := calculateChecksumsSequential(filePath)
+			if err != nil {
+				t.Fatalf("unable to hash content: %s", err)
+			}
+
+			checksums, err := calculateChecksums(filePath, true)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if checksums != expected {
+				t.Fatalf("expected %+v, got %+v", expected, checksums)
+			}
+		})
+	}
+}
+
+// calculateChecksumsSequential calculates every checksum of the file at
+// filePath in a single sequential pass, as a reference for calculateChecksums.
+func calculateChecksumsSequential(filePath string) (Checksums, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return Checksums{}, err
+	}
+	defer file.Close()
+
+	blake2b256, _ := blake2b.New256(nil)
+	hashes := []hash.Hash{md5.New(), sha1.New(), sha256.New(), sha512.New(), sha3.New256(), blake2b256}
+	writers := make([]io.Writer, len(hashes))
+	for i, h := range hashes {
+		writers[i] = h
+	}
+	if _, err := io.Copy(io.MultiWriter(writers...), file); err != nil {
+		return Checksums{}, err
+	}
+
+	return Checksums{
+		MD5:        hex.EncodeToString(hashes[0].Sum(nil)),
+		SHA1:       hex.EncodeToString(hashes[1].Sum(nil)),
+		SHA256:     hex.EncodeToString(hashes[2].Sum(nil)),
+		SHA512:     hex.EncodeToString(hashes[3].Sum(nil)),
+		SHA3_256:   hex.EncodeToString(hashes[4].Sum(nil)),
+		BLAKE2b256: hex.EncodeToString(hashes[5].Sum(nil)),
+	}, nil
+}
+
+// BenchmarkCalculateChecksums hashes a 1 GB synthetic file with the standard
+// checksums, as when uploading packages, and with the extended checksums, as
+// when the package data source sets extended_checksums. The sequential case
+// hashes every checksum in a single pass, as a baseline for the extended case
+// on machines with more than one CPU.
+func BenchmarkCalculateChecksums(b *testing.B) {
+	const size = 1 << 30
+
+	filePath := filepath.Join(b.TempDir(), "checksums.bin")
+	file, err := os.Create(filePath)
+	if err != nil {
+		b.Fatalf("unable to create test file: %s", err)
+	}
+	if err := file.Truncate(size); err != nil {
+		b.Fatalf("unable to size test file: %s", err)
+	}
+	file.Close()
+
+	for _, extended := range []bool{false, true} {
+		extended := extended
+		b.Run(fmt.Sprintf("extended=%t", extended), func(b *testing.B) {
+			b.SetBytes(size)
+			for i := 0; i < b.N; i++ {
+				if _, err := calculateChecksums(filePath, extended); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+
+	b.Run("sequential", func(b *testing.B) {
+		b.SetBytes(size)
+		for i := 0; i < b.N; i++ {
+			if _, err := calculateChecksumsSequential(filePath); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func TestChecksumMismatchError(t *testing.T) {
+	t.Parallel()
+
+	got := checksumMismatchError("SHA256", "remote-checksum", "local-checksum")
+	expected := "Checksum mismatch (SHA256): expected=remote-checksum, got=local-checksum"
+	if got != expected {
+		t.Fatalf("expected %q, got %q", expected, got)
+	}
+
+	pkg := cloudsmith.NewPackage()
+	pkg.SetChecksumMd5("remote-md5")
+	checksums := Checksums{MD5: "local-md5"}
+
+	err := checksums.CompareWithPkg(pkg)
+	if err == nil {
+		t.Fatal("expected a checksum mismatch error, got nil")
+	}
+	if !strings.Contains(err.Error(), "Checksum mismatch (MD5): expected=remote-md5, got=local-md5") {
+		t.Fatalf("unexpected error message: %s", err)
+	}
+}
+
+// TestProgressReader verifies that wrapping a download in a progressReader
+// passes the content through unchanged while tracking progress.
+func TestProgressReader(t *testing.T) {
+	t.Parallel()
+
+	content := bytes.Repeat([]byte("a"), 1000)
+	reader := newProgressReader(context.Background(), bytes.NewReader(content), "progress.bin", int64(len(content)))
+
+	var out bytes.Buffer
+	if _, err := io.Copy(&out, reader); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !bytes.Equal(out.Bytes(), content) {
+		t.Fatal("content was modified by the progress reader")
+	}
+
+	fields := reader.fields()
+	if fields["bytes_downloaded"] != int64(len(content)) {
+		t.Errorf("expected %d bytes downloaded, got %v", len(content), fields["bytes_downloaded"])
+	}
+	if fields["percent_complete"] != float64(100) {
+		t.Errorf("expected 100 percent complete, got %v", fields["percent_complete"])
+	}
+
+	unknown := newProgressReader(context.Background(), bytes.NewReader(content), "progress.bin", -1)
+	if _, ok := unknown.fields()["percent_complete"]; ok {
+		t.Error("expected no percent_complete when the total size is unknown")
+	}
+}
+
+// TestDownloadPackage_outputFilename verifies that a provided output filename
+// is used in place of the filename from the download URL.
+func TestDownloadPackage_outputFilename(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "Hello world")
+	}))
+	defer server.Close()
+
+	downloadDir := t.TempDir()
+	outputPath, err := downloadPackage(context.Background(), server.URL+"/0a1b2c3d", downloadDir, "hello-1.0.txt", "", testProviderConfig(server.URL), false)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if expected := filepath.Join(downloadDir, "hello-1.0.txt"); outputPath != expected {
+		t.Fatalf("expected output path %s, got %s", expected, outputPath)
+	}
+	if err := checkFileContent(outputPath, "Hello world"); err != nil {
+		t.Error(err)
+	}
+
+	validate := dataSourcePackage().Schema["output_filename"].ValidateFunc
+	for _, filename := range []string{"../hello.txt", "dir/hello.txt", `dir\hello.txt`, ".."} {
+		if _, errs := validate(filename, "output_filename"); len(errs) == 0 {
+			t.Errorf("expected %q to be rejected", filename)
+		}
+	}
+	if _, errs := validate("hello-1.0.txt", "output_filename"); len(errs) != 0 {
+		t.Errorf("expected hello-1.0.txt to be accepted, got %v", errs)
+	}
+}
+
+// TestDownloadPackage_fileMode verifies that the downloaded package is given
+// the requested permissions, and that invalid modes are rejected.
+func TestDownloadPackage_fileMode(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "#!/bin/sh\necho hello\n")
+	}))
+	defer server.Close()
+
+	outputPath, err := downloadPackage(context.Background(), server.URL+"/hello.sh", t.TempDir(), "", "0755", testProviderConfig(server.URL), false)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	info, err := os.Stat(outputPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mode := info.Mode().Perm(); mode != 0o755 {
+		t.Errorf("expected file mode 0755, got %#o", mode)
+	}
+
+	validate := dataSourcePackage().Schema["file_mode"].ValidateFunc
+	for _, mode := range []string{"755", "0644", "0600"} {
+		if _, errs := validate(mode, "file_mode"); len(errs) != 0 {
+			t.Errorf("expected %q to be accepted, got %v", mode, errs)
+		}
+	}
+	for _, mode := range []string{"", "0855", "rwxr-xr-x", "75", "00755"} {
+		if _, errs := validate(mode, "file_mode"); len(errs) == 0 {
+			t.Errorf("expected %q to be rejected", mode)
+		}
+	}
+}
+
+// TestDownloadPackage_requestTimeout verifies that a download attempt whose
+// response doesn't start within the configured request timeout is abandoned,
+// while a download which starts in time may take longer to complete.
+func TestDownloadPackage_requestTimeout(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/large.txt" {
+			fmt.Fprint(w, "hello ")
+			w.(http.Flusher).Flush()
+			time.Sleep(300 * time.Millisecond)
+			fmt.Fprint(w, "world")
+			return
+		}
+
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	}))
+	defer server.Close()
+
+	pc := testProviderConfig(server.URL)
+	pc.MaxRetries = 0
+	pc.APIClient.GetConfig().HTTPClient.Transport = newHTTPTransport(nil, nil, nil, 100*time.Millisecond, nil)
+
+	outputPath, err := downloadPackage(context.Background(), server.URL+"/large.txt", t.TempDir(), "", "", pc, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if content, _ := os.ReadFile(outputPath); string(content) != "hello world" {
+		t.Errorf("expected downloaded content %q, got %q", "hello world", content)
+	}
+
+	started := time.Now()
+	if _, err := downloadPackage(context.Background(), server.URL+"/slow.txt", t.TempDir(), "", "", pc, false); err == nil {
+		t.Fatal("expected an error, got nil")
+	}
+	if elapsed := time.Since(started); elapsed > 2*time.Second {
+		t.Errorf("expected download to time out promptly, took %s", elapsed)
+	}
+}
+
+// TestSelectPackageVersion verifies that the highest version of the named
+// package satisfying the constraint is selected.
+func TestSelectPackageVersion(t *testing.T) {
+	t.Parallel()
+
+	newPackage := func(name, version string) cloudsmith.Package {
+		pkg := cloudsmith.Package{}
+		pkg.SetName(name)
+		pkg.SetVersion(version)
+		pkg.SetSlugPerm(fmt.Sprintf("%s-%s", name, version))
+		return pkg
+	}
+	packages := []cloudsmith.Package{
+		newPackage("mylib", "2.2.9"),
+		newPackage("mylib", "2.3.1"),
+		newPackage("mylib", "2.3.4"),
+		newPackage("mylib", "2.4.0"),
+		newPackage("mylib", "not-a-version"),
+		newPackage("mylib-extra", "2.3.9"),
+	}
+
+	for _, tc := range []struct {
+		constraint string
+		want       string
+		wantErr    bool
+	}{
+		{constraint: "~> 2.3.0", want: "mylib-2.3.4"},
+		{constraint: ">= 2.0, < 2.4", want: "mylib-2.3.4"},
+		{constraint: ">= 2.0", want: "mylib-2.4.0"},
+		{constraint: "2.2.9", want: "mylib-2.2.9"},
+		{constraint: "> 3.0", wantErr: true},
+		{constraint: "not a constraint", wantErr: true},
+	} {
+		pkg, err := selectPackageVersion(packages, "mylib", tc.constraint)
+		if tc.wantErr {
+			if err == nil {
+				t.Errorf("%s: expected an error, got %s", tc.constraint, pkg.GetSlugPerm())
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", tc.constraint, err)
+			continue
+		}
+		if pkg.GetSlugPerm() != tc.want {
+			t.Errorf("%s: expected %s, got %s", tc.constraint, tc.want, pkg.GetSlugPerm())
+		}
+	}
+}
+
+// TestDownloadPackage_partial verifies that a download which fails part way
+// through leaves neither a truncated file at the output path nor a temporary
+// file in the download directory.
+func TestDownloadPackage_partial(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// advertise more content than is sent, then drop the connection.
+		w.Header().Set("Content-Length", "1048576")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, "partial content")
+		w.(http.Flusher).Flush()
+
+		conn, _, err := w.(http.Hijacker).Hijack()
+		if err == nil {
+			conn.Close()
+		}
+	}))
+	defer server.Close()
+
+	pc := testProviderConfig(server.URL)
+	pc.MaxRetries = 0
+
+	downloadDir := t.TempDir()
+	if _, err := downloadPackage(context.Background(), server.URL+"/partial.bin", downloadDir, "", "", pc, false); err == nil {
+		t.Fatal("expected an error, got nil")
+	}
+
+	entries, err := os.ReadDir(downloadDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, entry := range entries {
+		t.Errorf("expected download directory to be empty, found %s", entry.Name())
+	}
+}
+
+// TestDownloadPackage_proxy verifies that downloads are routed through the
+// configured proxy, while the API client is left unchanged.
+func TestDownloadPackage_proxy(t *testing.T) {
+	t.Parallel()
+
+	var proxied, header string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// requests to a proxy carry the absolute URL of the target
+		proxied = r.URL.String()
+		header = r.Header.Get("X-Custom-Header")
+		fmt.Fprint(w, "Hello world")
+	}))
+	defer proxy.Close()
+
+	pc := testProviderConfig(proxy.URL)
+	pc.headers = map[string]interface{}{"X-Custom-Header": "custom"}
+	if err := pc.SetDownloadProxy(proxy.URL); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if pc.APIClient.GetConfig().HTTPClient == pc.DownloadClient() {
+		t.Fatal("expected a separate HTTP client for downloads")
+	}
+
+	outputPath, err := downloadPackage(context.Background(), "http://cdn.example.com/hello.txt", t.TempDir(), "", "", pc, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if proxied != "http://cdn.example.com/hello.txt" {
+		t.Errorf("expected download to be proxied, proxy received %q", proxied)
+	}
+	if header != "custom" {
+		t.Errorf("expected custom header to be sent through the proxy, got %q", header)
+	}
+	if err := checkFileContent(outputPath, "Hello world"); err != nil {
+		t.Error(err)
+	}
+}
+
+// TestDataSourcePackageRead_ignoreNotFound checks that a missing package only
+// raises an error when ignore_not_found is not set.
+func TestDataSourcePackageRead_ignoreNotFound(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprintln(w, `{"detail": "Not found."}`)
+	}))
+	defer server.Close()
+
+	pc := testProviderConfig(server.URL)
+
+	for _, ignoreNotFound := range []bool{false, true} {
+		d := schema.TestResourceDataRaw(t, dataSourcePackage().Schema, map[string]interface{}{
+			"namespace":        "namespace",
+			"repository":       "repository",
+			"identifier":       "missing",
+			"ignore_not_found": ignoreNotFound,
+		})
+
+		diags := dataSourcePackageReadWithContext(context.Background(), d, pc)
+		if !ignoreNotFound {
+			if !diags.HasError() || diags[0].Summary != "Package missing was not found in namespace/repository - it may have been deleted" {
+				t.Errorf("expected package not found error, got %v", diags)
+			}
+			continue
+		}
+
+		if diags.HasError() {
+			t.Fatalf("unexpected error: %v", diags)
+		}
+		if d.Id() == "" {
+			t.Error("expected ID to be set")
+		}
+		if slugPerm := d.Get("slug_perm").(string); slugPerm != "" {
+			t.Errorf("expected slug_perm to be empty, got %s", slugPerm)
+		}
+	}
+}
+
+// TestWaitForPackageRead serves a sequence of package reads and verifies that
+// reading continues until the package has either finished synchronising or
+// failed to, returning the package as last read.
+func TestWaitForPackageRead(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		packages   []map[string]interface{}
+		syncFailed bool
+	}{
+		{
+			name: "Completed",
+			packages: []map[string]interface{}{
+				{"slug_perm": "slug-perm", "is_sync_in_progress": true},
+				{"slug_perm": "slug-perm", "is_sync_in_progress": true},
+				{"slug_perm": "slug-perm", "is_sync_completed": true},
+			},
+		},
+		{
+			name: "Failed",
+			packages: []map[string]interface{}{
+				{"slug_perm": "slug-perm", "is_sync_awaiting": true},
+				{"slug_perm": "slug-perm", "is_sync_failed": true},
+			},
+			syncFailed: true,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			requests := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				pkg := tc.packages[requests]
+				requests++
+
+				w.Header().Set("Content-Type", "application/json")
+				_ = json.NewEncoder(w).Encode(pkg)
+			}))
+			defer server.Close()
+
+			pc := testProviderConfig(server.URL)
+
+			pkg, err := waitForPackageRead(pc, "namespace", "repository", "slug-perm", time.Minute, time.Millisecond)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if pkg.GetIsSyncFailed() != tc.syncFailed {
+				t.Errorf("expected is_sync_failed to be %t", tc.syncFailed)
+			}
+			if requests != len(tc.packages) {
+				t.Errorf("expected %d requests, got %d", len(tc.packages), requests)
+			}
+		})
+	}
+}
+
+// TestChecksumMismatchDiagnostic verifies that the detail of a checksum
+// mismatch compares every checksum, flagging those that don't match.
+func TestChecksumMismatchDiagnostic(t *testing.T) {
+	t.Parallel()
+
+	pkg := cloudsmith.NewPackage()
+	pkg.SetSlugPerm("slug-perm")
+	pkg.SetChecksumMd5("remote-md5")
+	pkg.SetChecksumSha1("sha1")
+	pkg.SetChecksumSha256("sha256")
+	pkg.SetChecksumSha512("sha512")
+	checksums := Checksums{MD5: "local-md5", SHA1: "sha1", SHA256: "sha256", SHA512: "sha512"}
+
+	d := checksumMismatchDiagnostic(checksums, pkg)
+	if d.Severity != diag.Error {
+		t.Errorf("expected an error diagnostic, got %v", d.Severity)
+	}
+	if !strings.Contains(d.Summary, "slug-perm") {
+		t.Errorf("expected the summary to name the package, got %q", d.Summary)
+	}
+
+	lines := strings.Split(d.Detail, "\n")
+	for _, expected := range []string{
+		"MD5       remote-md5  local-md5   mismatch",
+		"SHA1      sha1        sha1",
+	} {
+		found := false
+		for _, line := range lines {
+			if strings.TrimSpace(line) == expected {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("expected a line %q in detail:\n%s", expected, d.Detail)
+		}
+	}
+}
+
+func TestPackageFilename(t *testing.T) {
+	t.Parallel()
+
+	newPackage := func(name, extension, cdnURL string) *cloudsmith.Package {
+		pkg := cloudsmith.NewPackage()
+		pkg.SetName(name)
+		pkg.SetVersion("1.2.3")
+		pkg.SetSlugPerm("AbCdEf123")
+		pkg.SetExtension(extension)
+		pkg.SetCdnUrl(cdnURL)
+		return pkg
+	}
+	deb := newPackage("mypkg", ".deb", "https://dl.cloudsmith.io/public/ns/repo/deb/any/pool/mypkg_1.2.3_amd64.deb")
+	tarball := newPackage("@scope/mypkg", ".tgz", "https://dl.cloudsmith.io/public/ns/repo/npm/mypkg-1.2.3.tgz")
+	noExtension := newPackage("mypkg", "", "https://dl.cloudsmith.io/public/ns/repo/raw/files/mypkg.tar.gz")
+
+	for _, tc := range []struct {
+		pkg      *cloudsmith.Package
+		strategy string
+		expected string
+	}{
+		{deb, filenameStrategyURL, "mypkg_1.2.3_amd64.deb"},
+		{deb, filenameStrategyNameVersion, "mypkg-1.2.3.deb"},
+		{deb, filenameStrategySlugPerm, "AbCdEf123.deb"},
+		{tarball, filenameStrategyNameVersion, "@scope_mypkg-1.2.3.tgz"},
+		{noExtension, filenameStrategySlugPerm, "AbCdEf123.gz"},
+	} {
+		if got := packageFilename(tc.pkg, tc.strategy); got != tc.expected {
+			t.Errorf("%s with %s: expected %q, got %q", tc.pkg.GetName(), tc.strategy, tc.expected, got)
+		}
+	}
+}
+
+// TestRetrieveLatestPackage verifies that the latest package is looked up
+// newest first with an anchored and escaped name query, and that a missing
+// package is reported as not found.
+func TestRetrieveLatestPackage(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if sort := r.URL.Query().Get("sort"); sort != "-date" {
+			t.Errorf("unexpected sort %q", sort)
+		}
+		if pageSize := r.URL.Query().Get("page_size"); pageSize != "1" {
+			t.Errorf("unexpected page size %q", pageSize)
+		}
+
+		packages := []map[string]interface{}{}
+		switch r.URL.Query().Get("query") {
+		case "name:^shared-lib$":
+			packages = append(packages, map[string]interface{}{"slug_perm": "latest", "name": "shared-lib", "version": "2.1.0"})
+		case `name:^shared\.lib\+\+$`:
+			packages = append(packages, map[string]interface{}{"slug_perm": "escaped", "name": "shared.lib++", "version": "1.0.0"})
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(packages)
+	}))
+	defer server.Close()
+
+	pc := testProviderConfig(server.URL)
+
+	pkg, err := retrieveLatestPackage(pc, "namespace", "repository", "shared-lib")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if pkg.GetSlugPerm() != "latest" {
+		t.Errorf("expected package latest, got %s", pkg.GetSlugPerm())
+	}
+
+	pkg, err = retrieveLatestPackage(pc, "namespace", "repository", "shared.lib++")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if pkg.GetSlugPerm() != "escaped" {
+		t.Errorf("expected package escaped, got %s", pkg.GetSlugPerm())
+	}
+
+	if _, err := retrieveLatestPackage(pc, "namespace", "repository", "other-lib"); !errors.Is(err, errPackageNotFound) {
+		t.Errorf("expected errPackageNotFound, got %v", err)
+	}
+}
+
+// TestDataSourcePackage_latest verifies that latest only selects the package
+// when true, rather than latest = false counting as a way of selecting it, and
+// that name can be used with each way of selecting a package that needs it.
+func TestDataSourcePackage_latest(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		config  map[string]interface{}
+		wantErr bool
+	}{
+		{name: "True", config: map[string]interface{}{"latest": true, "name": "shared-lib"}},
+		{name: "False", config: map[string]interface{}{"latest": false, "name": "shared-lib"}, wantErr: true},
+		{name: "FalseWithVersion", config: map[string]interface{}{"latest": false, "name": "shared-lib", "version": "1.0.0"}, wantErr: true},
+		{name: "Version", config: map[string]interface{}{"name": "shared-lib", "version": "1.0.0"}},
+		{name: "VersionConstraint", config: map[string]interface{}{"name": "shared-lib", "version_constraint": "~> 1.0"}},
+		{name: "VersionConstraintWithoutName", config: map[string]interface{}{"version_constraint": "~> 1.0"}, wantErr: true},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			config := map[string]interface{}{"namespace": "namespace", "repository": "repository"}
+			for k, v := range tc.config {
+				config[k] = v
+			}
+
+			diags := dataSourcePackage().Validate(terraform.NewResourceConfigRaw(config))
+			if diags.HasError() != tc.wantErr {
+				t.Errorf("expected error=%t, got %v", tc.wantErr, diags)
+			}
+		})
+	}
+}
+
+// TestDataSourcePackageRead_bustCache serves a corrupted package from the CDN
+// until the download bypasses the cache, and verifies that a checksum
+// mismatch is retried with a cache-busting query, and reported if it persists.
+func TestDataSourcePackageRead_bustCache(t *testing.T) {
+	t.Parallel()
+
+	const content = "Hello world"
+	contentPath := filepath.Join(t.TempDir(), "hello.txt")
+	if err := os.WriteFile(contentPath, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	checksums, err := calculateChecksums(contentPath, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name          string
+		corruptAlways bool
+	}{
+		{name: "Recovered"},
+		{name: "Persistent", corruptAlways: true},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var downloads []string
+			mux := http.NewServeMux()
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			mux.HandleFunc("/packages/namespace/repository/slug-perm/", func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				_ = json.NewEncoder(w).Encode(map[string]interface{}{
+					"slug_perm":       "slug-perm",
+					"filename":        "hello.txt",
+					"cdn_url":         server.URL + "/cdn/hello.txt",
+					"checksum_md5":    checksums.MD5,
+					"checksum_sha1":   checksums.SHA1,
+					"checksum_sha256": checksums.SHA256,
+					"checksum_sha512": checksums.SHA512,
+				})
+			})
+			mux.HandleFunc("/cdn/hello.txt", func(w http.ResponseWriter, r *http.Request) {
+				downloads = append(downloads, r.URL.RawQuery)
+				if tc.corruptAlways || r.URL.Query().Get("time") == "" {
+					fmt.Fprint(w, "Hello w0rld")
+					return
+				}
+				fmt.Fprint(w, content)
+			})
+
+			pc := testProviderConfig(server.URL)
+
+			downloadDir := t.TempDir()
+			d := schema.TestResourceDataRaw(t, dataSourcePackage().Schema, map[string]interface{}{
+				"namespace":    "namespace",
+				"repository":   "repository",
+				"identifier":   "slug-perm",
+				"download":     true,
+				"download_dir": downloadDir,
+			})
+
+			diags := dataSourcePackageReadWithContext(context.Background(), d, pc)
+
+			if len(downloads) != 2 {
+				t.Fatalf("expected 2 downloads, got %d", len(downloads))
+			}
+			if downloads[0] != "" {
+				t.Errorf("expected the first download not to bust the cache, got query %q", downloads[0])
+			}
+			if !strings.HasPrefix(downloads[1], "time=") {
+				t.Errorf("expected the second download to bust the cache, got query %q", downloads[1])
+			}
+
+			if tc.corruptAlways {
+				if !diags.HasError() || !strings.Contains(diags[0].Summary, "Checksum mismatch") {
+					t.Errorf("expected checksum mismatch error, got %v", diags)
+				}
+				return
+			}
+
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			if err := checkFileContent(filepath.Join(downloadDir, "hello.txt"), content); err != nil {
+				t.Error(err)
+			}
+			if checksum := d.Get("checksum_sha256").(string); checksum != checksums.SHA256 {
+				t.Errorf("unexpected checksum_sha256 %s", checksum)
+			}
+		})
+	}
+}
+
+func TestEnsureDownloadDir(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	missing := filepath.Join(dir, "missing", "nested")
+
+	if err := ensureDownloadDir(dir, false); err != nil {
+		t.Errorf("unexpected error for existing directory: %s", err)
+	}
+
+	if err := ensureDownloadDir(missing, false); err == nil || !strings.Contains(err.Error(), "output_dir_create") {
+		t.Errorf("expected error suggesting output_dir_create, got %v", err)
+	}
+
+	if err := ensureDownloadDir(missing, true); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if info, err := os.Stat(missing); err != nil || !info.IsDir() {
+		t.Errorf("expected %s to be created, got %v", missing, err)
+	}
+
+	file := filepath.Join(dir, "file.txt")
+	if err := os.WriteFile(file, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := ensureDownloadDir(file, true); err == nil {
+		t.Error("expected error for a file")
+	}
+}
+
+// TestDataSourcePackageRead_cache reads the same package into two download
+// directories and verifies that it's only downloaded once when a cache
+// directory is configured.
+func TestDataSourcePackageRead_cache(t *testing.T) {
+	t.Parallel()
+
+	const content = "Hello world"
+	contentPath := filepath.Join(t.TempDir(), "hello.txt")
+	if err := os.WriteFile(contentPath, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	checksums, err := calculateChecksums(contentPath, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	downloads := 0
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	mux.HandleFunc("/packages/namespace/repository/slug-perm/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"slug_perm":       "slug-perm",
+			"filename":        "hello.txt",
+			"cdn_url":         server.URL + "/cdn/hello.txt",
+			"checksum_md5":    checksums.MD5,
+			"checksum_sha1":   checksums.SHA1,
+			"checksum_sha256": checksums.SHA256,
+			"checksum_sha512": checksums.SHA512,
+		})
+	})
+	mux.HandleFunc("/cdn/hello.txt", func(w http.ResponseWriter, r *http.Request) {
+		downloads++
+		fmt.Fprint(w, content)
+	})
+
+	pc := testProviderConfig(server.URL)
+	pc.CacheDir = filepath.Join(t.TempDir(), "cache")
+
+	for i := 0; i < 2; i++ {
+		downloadDir := t.TempDir()
+		d := schema.TestResourceDataRaw(t, dataSourcePackage().Schema, map[string]interface{}{
+			"namespace":    "namespace",
+			"repository":   "repository",
+			"identifier":   "slug-perm",
+			"download":     true,
+			"download_dir": downloadDir,
+		})
+
+		if diags := dataSourcePackageReadWithContext(context.Background(), d, pc); diags.HasError() {
+			t.Fatalf("unexpected error: %v", diags)
+		}
+		if err := checkFileContent(filepath.Join(downloadDir, "hello.txt"), content); err != nil {
+			t.Error(err)
+		}
+	}
+
+	if downloads != 1 {
+		t.Errorf("expected 1 download, got %d", downloads)
+	}
+}
+
+// TestDataSourcePackageRead_deleted verifies that a package deleted after it
+// was read, so that downloading it fails, is reported as deleted rather than
+// as a failed download.
+func TestDataSourcePackageRead_deleted(t *testing.T) {
+	t.Parallel()
+
+	reads := 0
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	mux.HandleFunc("/packages/namespace/repository/slug-perm/", func(w http.ResponseWriter, r *http.Request) {
+		reads++
+		w.Header().Set("Content-Type", "application/json")
+		if reads > 1 {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprintln(w, `{"detail": "Not found."}`)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"slug_perm": "slug-perm",
+			"filename":  "hello.txt",
+			"cdn_url":   server.URL + "/cdn/hello.txt",
+		})
+	})
+	mux.HandleFunc("/cdn/hello.txt", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	pc := testProviderConfig(server.URL)
+
+	d := schema.TestResourceDataRaw(t, dataSourcePackage().Schema, map[string]interface{}{
+		"namespace":    "namespace",
+		"repository":   "repository",
+		"identifier":   "slug-perm",
+		"download":     true,
+		"download_dir": t.TempDir(),
+	})
+
+	diags := dataSourcePackageReadWithContext(context.Background(), d, pc)
+	if !diags.HasError() || diags[0].Summary != "Package slug-perm was not found in namespace/repository - it may have been deleted" {
+		t.Errorf("expected package deleted error, got %v", diags)
+	}
+}
+
+// TestDataSourcePackageRead_extractArchive downloads a zip package with
+// extract_archive set and verifies it's extracted into the default extract
+// directory.
+func TestDataSourcePackageRead_extractArchive(t *testing.T) {
+	t.Parallel()
+
+	archivePath := filepath.Join(t.TempDir(), "package.zip")
+	writeTestZip(t, archivePath, testArchiveFiles)
+	checksums, err := calculateChecksums(archivePath, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	mux.HandleFunc("/packages/namespace/repository/slug-perm/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"slug_perm":       "slug-perm",
+			"filename":        "package.zip",
+			"cdn_url":         server.URL + "/cdn/package.zip",
+			"checksum_md5":    checksums.MD5,
+			"checksum_sha1":   checksums.SHA1,
+			"checksum_sha256": checksums.SHA256,
+			"checksum_sha512": checksums.SHA512,
+		})
+	})
+	mux.HandleFunc("/cdn/package.zip", func(w http.ResponseWriter, r *http.Request) {
+		http.ServeFile(w, r, archivePath)
+	})
+
+	pc := testProviderConfig(server.URL)
+
+	downloadDir := t.TempDir()
+	d := schema.TestResourceDataRaw(t, dataSourcePackage().Schema, map[string]interface{}{
+		"namespace":       "namespace",
+		"repository":      "repository",
+		"identifier":      "slug-perm",
+		"download":        true,
+		"download_dir":    downloadDir,
+		"extract_archive": true,
+	})
+
+	if diags := dataSourcePackageReadWithContext(context.Background(), d, pc); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	extractDir := filepath.Join(downloadDir, "slug-perm")
+	if d.Get("extract_dir") != extractDir {
+		t.Errorf("expected extract_dir %s, got %s", extractDir, d.Get("extract_dir"))
+	}
+	if files := d.Get("extracted_files").([]interface{}); len(files) != 2 || files[0] != "docs/readme.md" || files[1] != "hello.txt" {
+		t.Errorf("unexpected extracted_files %v", files)
+	}
+	if err := checkFileContent(filepath.Join(extractDir, "hello.txt"), "Hello world"); err != nil {
+		t.Error(err)
+	}
+}
+
+// TestRetrievePackage_version verifies that a package looked up by name and
+// version is searched for with the name and version anchored and escaped, and
+// that only the package with exactly that name and version is selected.
+func TestRetrievePackage_version(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if query := r.URL.Query().Get("query"); query != `name:^shared-lib$ AND version:^2\.3\.1$` {
+			t.Errorf("unexpected query %q", query)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Pagination-Pagetotal", "1")
+		_ = json.NewEncoder(w).Encode([]map[string]string{
+			{"slug_perm": "plugin", "name": "shared-lib-plugin", "version": "2.3.1"},
+			{"slug_perm": "exact", "name": "shared-lib", "version": "2.3.1"},
+		})
+	}))
+	defer server.Close()
+
+	d := schema.TestResourceDataRaw(t, dataSourcePackage().Schema, map[string]interface{}{
+		"namespace":  "namespace",
+		"repository": "repository",
+		"name":       "shared-lib",
+		"version":    "2.3.1",
+	})
+
+	pkg, err := retrievePackage(testProviderConfig(server.URL), d, "namespace", "repository")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if pkg.GetSlugPerm() != "exact" {
+		t.Errorf("expected package exact, got %s", pkg.GetSlugPerm())
+	}
+}
+
+func TestSelectPackageByNameVersion(t *testing.T) {
+	t.Parallel()
+
+	newPackage := func(slugPerm, name, version string) cloudsmith.Package {
+		pkg := cloudsmith.Package{}
+		pkg.SetSlugPerm(slugPerm)
+		pkg.SetName(name)
+		pkg.SetVersion(version)
+		return pkg
+	}
+
+	packages := []cloudsmith.Package{
+		newPackage("plugin", "shared-lib-plugin", "2.3.1"),
+		newPackage("patch", "shared-lib", "2.3.10"),
+		newPackage("exact", "shared-lib", "2.3.1"),
+	}
+
+	pkg, err := selectPackageByNameVersion(packages, "shared-lib", "2.3.1")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if pkg.GetSlugPerm() != "exact" {
+		t.Errorf("expected package exact, got %s", pkg.GetSlugPerm())
+	}
+
+	if _, err := selectPackageByNameVersion(packages, "shared-lib", "1.0.0"); !errors.Is(err, errPackageNotFound) {
+		t.Errorf("expected errPackageNotFound, got %v", err)
+	}
+
+	packages = append(packages, newPackage("duplicate", "shared-lib", "2.3.1"))
+	if _, err := selectPackageByNameVersion(packages, "shared-lib", "2.3.1"); err == nil || errors.Is(err, errPackageNotFound) {
+		t.Errorf("expected an ambiguous package error, got %v", err)
+	}
+}
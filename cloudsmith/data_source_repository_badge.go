@@ -0,0 +1,144 @@
+package cloudsmith
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	badgeTypeDownloads     = "downloads"
+	badgeTypeLatestVersion = "latest_version"
+	badgeTypePackageCount  = "package_count"
+)
+
+// repositoryBadgeURL returns the URL of a badge for a repository. Version
+// badges are rendered by the Cloudsmith badges endpoint, while download and
+// package count badges are shields.io dynamic badges reading the repository
+// from the API, so they're only rendered for public repositories.
+func repositoryBadgeURL(apiHost, namespace, repository, badgeType, packageFormat, packageName string) (string, error) {
+	apiHost = strings.TrimSuffix(apiHost, "/")
+
+	var label, query string
+	switch badgeType {
+	case badgeTypeLatestVersion:
+		if packageFormat == "" || packageName == "" {
+			return "", fmt.Errorf("package_format and package_name must be set for %s badges", badgeTypeLatestVersion)
+		}
+		return fmt.Sprintf(
+			"%s/badges/version/%s/%s/%s/%s/latest/x/?render=true&show_latest=true",
+			apiHost,
+			url.PathEscape(namespace),
+			url.PathEscape(repository),
+			url.PathEscape(packageFormat),
+			url.PathEscape(packageName),
+		), nil
+	case badgeTypeDownloads:
+		label, query = "downloads", "$.num_downloads"
+	case badgeTypePackageCount:
+		label, query = "packages", "$.package_count"
+	default:
+		return "", fmt.Errorf("unsupported badge type %q", badgeType)
+	}
+
+	repoURL := fmt.Sprintf("%s/repos/%s/%s/", apiHost, url.PathEscape(namespace), url.PathEscape(repository))
+	return "https://img.shields.io/badge/dynamic/json?" + url.Values{
+		"label": {label},
+		"query": {query},
+		"url":   {repoURL},
+	}.Encode(), nil
+}
+
+func dataSourceRepositoryBadgeRead(d *schema.ResourceData, m interface{}) error {
+	pc := m.(*providerConfig)
+
+	namespace := requiredString(d, "namespace")
+	repository := requiredString(d, "repository")
+	badgeType := requiredString(d, "badge_type")
+	packageFormat := requiredString(d, "package_format")
+	packageName := requiredString(d, "package_name")
+
+	// read the repository so a badge is never returned for a repository that
+	// doesn't exist or that the provider can't access.
+	req := pc.APIClient.ReposApi.ReposRead(pc.Auth, namespace, repository)
+	repo, _, err := pc.APIClient.ReposApi.ReposReadExecute(req)
+	if err != nil {
+		return fmt.Errorf("error reading repository (%s.%s): %w", namespace, repository, err)
+	}
+
+	// shields.io reads the repository from the API without credentials, so
+	// its badges would fail to render for anything but a public repository.
+	if badgeType != badgeTypeLatestVersion && !strings.EqualFold(repo.GetRepositoryTypeStr(), "Public") {
+		return fmt.Errorf(
+			"%s badges can only be rendered for public repositories, but repository (%s.%s) is %s",
+			badgeType, namespace, repository, repo.GetRepositoryTypeStr(),
+		)
+	}
+
+	apiHost, err := pc.APIClient.GetConfig().Servers.URL(0, nil)
+	if err != nil {
+		return err
+	}
+
+	badgeURL, err := repositoryBadgeURL(apiHost, namespace, repository, badgeType, packageFormat, packageName)
+	if err != nil {
+		return err
+	}
+
+	d.Set("badge_url", badgeURL)
+
+	d.SetId(fmt.Sprintf("%s.%s.%s", namespace, repository, badgeType))
+
+	return nil
+}
+
+func dataSourceRepositoryBadge() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceRepositoryBadgeRead,
+
+		Schema: map[string]*schema.Schema{
+			"badge_type": {
+				Type:        schema.TypeString,
+				Description: "The type of badge, one of `downloads`, `latest_version` or `package_count`.",
+				Required:    true,
+				ValidateFunc: validation.StringInSlice([]string{
+					badgeTypeDownloads,
+					badgeTypeLatestVersion,
+					badgeTypePackageCount,
+				}, false),
+			},
+			"badge_url": {
+				Type:        schema.TypeString,
+				Description: "The shields.io compatible URL of the badge.",
+				Computed:    true,
+			},
+			"namespace": {
+				Type:         schema.TypeString,
+				Description:  "Namespace to which the repository belongs.",
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"package_format": {
+				Type:         schema.TypeString,
+				Description:  "The format of the package, e.g. `python`. Required for `latest_version` badges.",
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"package_name": {
+				Type:         schema.TypeString,
+				Description:  "The name of the package. Required for `latest_version` badges.",
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"repository": {
+				Type:         schema.TypeString,
+				Description:  "The repository for which to return a badge.",
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+		},
+	}
+}
@@ -0,0 +1,158 @@
+//nolint:testpackage
+package cloudsmith
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestRepositoryBadgeURL(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		badgeType string
+		format    string
+		pkgName   string
+		want      string
+		wantErr   bool
+	}{
+		{
+			name:      "latest version",
+			badgeType: badgeTypeLatestVersion,
+			format:    "python",
+			pkgName:   "my-package",
+			want:      "https://api.cloudsmith.io/v1/badges/version/ns/repo/python/my-package/latest/x/?render=true&show_latest=true",
+		},
+		{
+			name:      "latest version without package",
+			badgeType: badgeTypeLatestVersion,
+			wantErr:   true,
+		},
+		{
+			name:      "downloads",
+			badgeType: badgeTypeDownloads,
+			want: "https://img.shields.io/badge/dynamic/json?label=downloads&query=%24.num_downloads" +
+				"&url=https%3A%2F%2Fapi.cloudsmith.io%2Fv1%2Frepos%2Fns%2Frepo%2F",
+		},
+		{
+			name:      "package count",
+			badgeType: badgeTypePackageCount,
+			want: "https://img.shields.io/badge/dynamic/json?label=packages&query=%24.package_count" +
+				"&url=https%3A%2F%2Fapi.cloudsmith.io%2Fv1%2Frepos%2Fns%2Frepo%2F",
+		},
+		{
+			name:      "unsupported",
+			badgeType: "stars",
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := repositoryBadgeURL("https://api.cloudsmith.io/v1/", "ns", "repo", tt.badgeType, tt.format, tt.pkgName)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("repositoryBadgeURL() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("repositoryBadgeURL() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestDataSourceRepositoryBadgeRead verifies that shields.io badges, which
+// read the repository without credentials, are refused for private
+// repositories, while version badges are still returned.
+func TestDataSourceRepositoryBadgeRead(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		repositoryType string
+		badgeType      string
+		wantErr        bool
+	}{
+		{name: "public downloads", repositoryType: "Public", badgeType: badgeTypeDownloads},
+		{name: "private downloads", repositoryType: "Private", badgeType: badgeTypeDownloads, wantErr: true},
+		{name: "private package count", repositoryType: "Private", badgeType: badgeTypePackageCount, wantErr: true},
+		{name: "private latest version", repositoryType: "Private", badgeType: badgeTypeLatestVersion},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				_ = json.NewEncoder(w).Encode(map[string]string{
+					"name":                "repo",
+					"repository_type_str": tt.repositoryType,
+				})
+			}))
+			defer server.Close()
+
+			d := schema.TestResourceDataRaw(t, dataSourceRepositoryBadge().Schema, map[string]interface{}{
+				"namespace":      "ns",
+				"repository":     "repo",
+				"badge_type":     tt.badgeType,
+				"package_format": "python",
+				"package_name":   "my-package",
+			})
+
+			err := dataSourceRepositoryBadgeRead(d, testProviderConfig(server.URL))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("dataSourceRepositoryBadgeRead() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && d.Get("badge_url").(string) == "" {
+				t.Error("expected badge_url to be set")
+			}
+		})
+	}
+}
+
+// TestAccDataSourceRepositoryBadge_basic creates a public repository, then
+// reads a package count badge for it using the data source.
+func TestAccDataSourceRepositoryBadge_basic(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccRepositoryCheckDestroy("cloudsmith_repository.test"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceRepositoryBadgeConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.cloudsmith_repository_badge.test", "badge_type", "package_count"),
+					resource.TestCheckResourceAttrSet("data.cloudsmith_repository_badge.test", "badge_url"),
+				),
+			},
+		},
+	})
+}
+
+var testAccDataSourceRepositoryBadgeConfig = fmt.Sprintf(`
+resource "cloudsmith_repository" "test" {
+	name            = "terraform-acc-test-repository-badge"
+	namespace       = "%s"
+	repository_type = "Public"
+}
+
+data "cloudsmith_repository_badge" "test" {
+	namespace  = cloudsmith_repository.test.namespace
+	repository = cloudsmith_repository.test.slug_perm
+	badge_type = "package_count"
+}
+`, os.Getenv("CLOUDSMITH_NAMESPACE"))
@@ -0,0 +1,182 @@
+package cloudsmith
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/cloudsmith-io/cloudsmith-api-go"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	defaultRepositoryListMaxResults = 1000
+	repositoryListPageSize          = 100
+)
+
+// retrieveRepositoryListPages retrieves the repositories in a namespace page
+// by page until every page has been retrieved, or at least maxResults
+// repositories have been, in which case only the first maxResults are
+// returned.
+func retrieveRepositoryListPages(pc *providerConfig, namespace string, pageSize, maxResults int64) ([]cloudsmith.Repository, error) {
+	repositories := []cloudsmith.Repository{}
+
+	var page, pageTotal int64 = 1, 1
+	for page <= pageTotal && int64(len(repositories)) < maxResults {
+		req := pc.APIClient.ReposApi.ReposNamespaceList(pc.Auth, namespace)
+		req = req.Page(page)
+		req = req.PageSize(pageSize)
+
+		pageData, resp, err := pc.APIClient.ReposApi.ReposNamespaceListExecute(req)
+		if err != nil {
+			return nil, err
+		}
+		pageTotal, err = strconv.ParseInt(resp.Header.Get("X-Pagination-Pagetotal"), 10, 64)
+		if err != nil {
+			return nil, err
+		}
+
+		repositories = append(repositories, pageData...)
+		page++
+	}
+
+	if int64(len(repositories)) > maxResults {
+		repositories = repositories[:maxResults]
+	}
+
+	return repositories, nil
+}
+
+// filterRepositoriesByType returns the repositories of the given type, e.g.
+// `open-source`, or every repository if the type is empty.
+func filterRepositoriesByType(repositories []cloudsmith.Repository, repositoryType string) []cloudsmith.Repository {
+	if repositoryType == "" {
+		return repositories
+	}
+
+	filtered := []cloudsmith.Repository{}
+	for _, repository := range repositories {
+		if strings.EqualFold(repository.GetRepositoryTypeStr(), repositoryType) {
+			filtered = append(filtered, repository)
+		}
+	}
+	return filtered
+}
+
+func flattenRepositories(repositories []cloudsmith.Repository) []interface{} {
+	out := make([]interface{}, len(repositories))
+	for i, repository := range repositories {
+		out[i] = map[string]interface{}{
+			"cdn_url":         repository.GetCdnUrl(),
+			"created_at":      timeToString(repository.GetCreatedAt()),
+			"description":     repository.GetDescription(),
+			"name":            repository.GetName(),
+			"repository_type": repository.GetRepositoryTypeStr(),
+			"slug":            repository.GetSlug(),
+			"slug_perm":       repository.GetSlugPerm(),
+			"storage_region":  repository.GetStorageRegion(),
+		}
+	}
+	return out
+}
+
+func dataSourceRepositoryListRead(d *schema.ResourceData, m interface{}) error {
+	pc := m.(*providerConfig)
+
+	namespace := requiredString(d, "namespace")
+	typeFilter := requiredString(d, "type_filter")
+	maxResults := int64(d.Get("max_results").(int))
+
+	repositories, err := retrieveRepositoryListPages(pc, namespace, repositoryListPageSize, maxResults)
+	if err != nil {
+		return fmt.Errorf("error listing repositories in %s: %w", namespace, err)
+	}
+
+	if err := d.Set("repositories", flattenRepositories(filterRepositoriesByType(repositories, typeFilter))); err != nil {
+		return err
+	}
+
+	d.SetId(fmt.Sprintf("%s_%s", namespace, typeFilter))
+
+	return nil
+}
+
+func dataSourceRepositoryList() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceRepositoryListRead,
+
+		Schema: map[string]*schema.Schema{
+			"max_results": {
+				Type: schema.TypeInt,
+				Description: "The maximum number of repositories to retrieve, to limit the number of API " +
+					"requests made for large organizations. type_filter is applied to the retrieved repositories.",
+				Optional:     true,
+				Default:      defaultRepositoryListMaxResults,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"namespace": {
+				Type:         schema.TypeString,
+				Description:  "The namespace to list repositories in.",
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"repositories": {
+				Type:        schema.TypeList,
+				Description: "The repositories in the namespace.",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"cdn_url": {
+							Type:        schema.TypeString,
+							Description: "Base URL from which packages and other artifacts are downloaded.",
+							Computed:    true,
+						},
+						"created_at": {
+							Type:        schema.TypeString,
+							Description: "ISO 8601 timestamp at which the repository was created.",
+							Computed:    true,
+						},
+						"description": {
+							Type:        schema.TypeString,
+							Description: "A description of the repository's purpose/contents.",
+							Computed:    true,
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Description: "A descriptive name for the repository.",
+							Computed:    true,
+						},
+						"repository_type": {
+							Type:        schema.TypeString,
+							Description: "The repository type changes how it is accessed and billed.",
+							Computed:    true,
+						},
+						"slug": {
+							Type:        schema.TypeString,
+							Description: "The slug identifies the repository in URIs.",
+							Computed:    true,
+						},
+						"slug_perm": {
+							Type: schema.TypeString,
+							Description: "The slug_perm immutably identifies the repository. " +
+								"It will never change once a repository has been created.",
+							Computed: true,
+						},
+						"storage_region": {
+							Type:        schema.TypeString,
+							Description: "The Cloudsmith region in which package files are stored.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"type_filter": {
+				Type:         schema.TypeString,
+				Description:  "If set, only repositories of this type are returned.",
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"public", "private", "open-source"}, false),
+			},
+		},
+	}
+}
@@ -0,0 +1,126 @@
+//nolint:testpackage
+package cloudsmith
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"testing"
+
+	"github.com/cloudsmith-io/cloudsmith-api-go"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// TestRetrieveRepositoryListPages serves a paginated list of repositories and
+// verifies that every page is fetched exactly once, and that no more pages
+// are fetched than needed to retrieve maxResults repositories.
+func TestRetrieveRepositoryListPages(t *testing.T) {
+	t.Parallel()
+
+	const pageSize, pageTotal = 2, 3
+
+	requests := map[int]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		requests[page]++
+
+		repositories := []map[string]string{}
+		for i := 0; i < pageSize; i++ {
+			repositories = append(repositories, map[string]string{
+				"name":                fmt.Sprintf("repository-%d-%d", page, i),
+				"repository_type_str": "Public",
+			})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Pagination-Pagetotal", strconv.Itoa(pageTotal))
+		_ = json.NewEncoder(w).Encode(repositories)
+	}))
+	defer server.Close()
+
+	pc := testProviderConfig(server.URL)
+
+	repositories, err := retrieveRepositoryListPages(pc, "namespace", pageSize, 100)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(repositories) != pageSize*pageTotal {
+		t.Fatalf("expected %d repositories, got %d", pageSize*pageTotal, len(repositories))
+	}
+	for page := 1; page <= pageTotal; page++ {
+		if requests[page] != 1 {
+			t.Errorf("expected page %d to be requested once, got %d", page, requests[page])
+		}
+	}
+
+	repositories, err = retrieveRepositoryListPages(pc, "namespace", pageSize, 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(repositories) != 3 || repositories[2].GetName() != "repository-2-0" {
+		t.Errorf("expected the first 3 repositories, got %d", len(repositories))
+	}
+	if requests[3] != 1 {
+		t.Errorf("expected page 3 not to be requested again, got %d requests", requests[3])
+	}
+}
+
+func TestFilterRepositoriesByType(t *testing.T) {
+	t.Parallel()
+
+	newRepository := func(name, repositoryType string) cloudsmith.Repository {
+		repository := cloudsmith.Repository{Name: name}
+		repository.SetRepositoryTypeStr(repositoryType)
+		return repository
+	}
+	repositories := []cloudsmith.Repository{
+		newRepository("public", "Public"),
+		newRepository("private", "Private"),
+		newRepository("oss", "Open-Source"),
+	}
+
+	if got := filterRepositoriesByType(repositories, ""); len(got) != 3 {
+		t.Errorf("expected every repository without a filter, got %d", len(got))
+	}
+	if got := filterRepositoriesByType(repositories, "open-source"); len(got) != 1 || got[0].GetName() != "oss" {
+		t.Errorf("expected only the open-source repository, got %v", got)
+	}
+}
+
+// TestAccDataSourceRepositoryList_basic creates a private repository and
+// verifies it is listed when filtering by private repositories.
+func TestAccDataSourceRepositoryList_basic(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccRepositoryCheckDestroy("cloudsmith_repository.test"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceRepositoryListConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckTypeSetElemNestedAttrs("data.cloudsmith_repository_list.test", "repositories.*", map[string]string{
+						"name":            "terraform-acc-test-repository-list",
+						"repository_type": "Private",
+					}),
+				),
+			},
+		},
+	})
+}
+
+var testAccDataSourceRepositoryListConfig = fmt.Sprintf(`
+resource "cloudsmith_repository" "test" {
+	name            = "terraform-acc-test-repository-list"
+	namespace       = "%s"
+	repository_type = "Private"
+}
+
+data "cloudsmith_repository_list" "test" {
+	namespace   = cloudsmith_repository.test.namespace
+	type_filter = "private"
+}
+`, os.Getenv("CLOUDSMITH_NAMESPACE"))
@@ -0,0 +1,108 @@
+package cloudsmith
+
+import (
+	"fmt"
+
+	"github.com/cloudsmith-io/cloudsmith-api-go"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// flattenRepositoryPrivilegeGrants converts privileges as returned by the
+// Cloudsmith API into a list of grants, one per user, team or service.
+func flattenRepositoryPrivilegeGrants(privileges []cloudsmith.RepositoryPrivilegeDict) []interface{} {
+	grants := make([]interface{}, 0, len(privileges))
+	for _, privilege := range privileges {
+		var slugPerm string
+		switch {
+		case privilege.HasUser():
+			slugPerm = privilege.GetUser()
+		case privilege.HasTeam():
+			slugPerm = privilege.GetTeam()
+		case privilege.HasService():
+			slugPerm = privilege.GetService()
+		}
+
+		grants = append(grants, map[string]interface{}{
+			"privilege": privilege.GetPrivilege(),
+			"service":   privilege.GetService(),
+			"slug_perm": slugPerm,
+			"team":      privilege.GetTeam(),
+			"user":      privilege.GetUser(),
+		})
+	}
+	return grants
+}
+
+func dataSourceRepositoryPrivilegeGrantRead(d *schema.ResourceData, m interface{}) error {
+	pc := m.(*providerConfig)
+
+	namespace := requiredString(d, "namespace")
+	repository := requiredString(d, "repository")
+
+	privileges, _, err := listRepositoryPrivileges(pc, namespace, repository)
+	if err != nil {
+		return fmt.Errorf("error listing privileges of repository (%s): %w", repository, err)
+	}
+
+	d.Set("grants", flattenRepositoryPrivilegeGrants(privileges))
+
+	d.SetId(fmt.Sprintf("%s/%s", namespace, repository))
+
+	return nil
+}
+
+func dataSourceRepositoryPrivilegeGrant() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceRepositoryPrivilegeGrantRead,
+
+		Schema: map[string]*schema.Schema{
+			"grants": {
+				Type:        schema.TypeList,
+				Description: "The privileges granted on the repository, one per user, team or service.",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"privilege": {
+							Type:        schema.TypeString,
+							Description: "The privilege granted, i.e. Admin, Write or Read.",
+							Computed:    true,
+						},
+						"service": {
+							Type:        schema.TypeString,
+							Description: "The slug of the service the privilege is granted to, if any.",
+							Computed:    true,
+						},
+						"slug_perm": {
+							Type:        schema.TypeString,
+							Description: "The identifier of the user, team or service the privilege is granted to.",
+							Computed:    true,
+						},
+						"team": {
+							Type:        schema.TypeString,
+							Description: "The slug of the team the privilege is granted to, if any.",
+							Computed:    true,
+						},
+						"user": {
+							Type:        schema.TypeString,
+							Description: "The slug of the user the privilege is granted to, if any.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"namespace": {
+				Type:         schema.TypeString,
+				Description:  "Namespace to which the repository belongs.",
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"repository": {
+				Type:         schema.TypeString,
+				Description:  "Repository to list the privileges of.",
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+		},
+	}
+}
@@ -0,0 +1,90 @@
+//nolint:testpackage
+package cloudsmith
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/cloudsmith-io/cloudsmith-api-go"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// TestAccDataSourceRepositoryPrivilegeGrant_basic grants a service a privilege
+// on a repository and verifies the grant is listed by the data source.
+func TestAccDataSourceRepositoryPrivilegeGrant_basic(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccRepositoryCheckDestroy("cloudsmith_repository.test"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceRepositoryPrivilegeGrantConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.cloudsmith_repository_privilege_grant.test", "grants.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("data.cloudsmith_repository_privilege_grant.test", "grants.*", map[string]string{
+						"privilege": "Read",
+						"team":      "",
+						"user":      "",
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestFlattenRepositoryPrivilegeGrants(t *testing.T) {
+	grants := flattenRepositoryPrivilegeGrants([]cloudsmith.RepositoryPrivilegeDict{
+		{Privilege: "Admin", User: cloudsmith.PtrString("jane")},
+		{Privilege: "Write", Team: cloudsmith.PtrString("developers")},
+		{Privilege: "Read", Service: cloudsmith.PtrString("ci")},
+	})
+
+	expected := []interface{}{
+		map[string]interface{}{"privilege": "Admin", "service": "", "slug_perm": "jane", "team": "", "user": "jane"},
+		map[string]interface{}{"privilege": "Write", "service": "", "slug_perm": "developers", "team": "developers", "user": ""},
+		map[string]interface{}{"privilege": "Read", "service": "ci", "slug_perm": "ci", "team": "", "user": ""},
+	}
+	if !reflect.DeepEqual(grants, expected) {
+		t.Errorf("expected %v, got %v", expected, grants)
+	}
+}
+
+var testAccDataSourceRepositoryPrivilegeGrantConfig = fmt.Sprintf(`
+resource "cloudsmith_repository" "test" {
+	name      = "terraform-acc-test-privilege-grant"
+	namespace = "%s"
+}
+
+resource "cloudsmith_service" "test" {
+	name         = "TF Test Service Privilege Grant"
+	organization = cloudsmith_repository.test.namespace
+	role         = "Member"
+}
+
+data "cloudsmith_user_self" "current" {}
+
+resource "cloudsmith_repository_privileges" "test" {
+	organization = cloudsmith_repository.test.namespace
+	repository   = cloudsmith_repository.test.slug
+
+	service {
+		privilege = "Read"
+		slug      = cloudsmith_service.test.slug
+	}
+
+	# Include the authenticated account explicitly to satisfy lockout safeguard.
+	user {
+		privilege = "Admin"
+		slug      = data.cloudsmith_user_self.current.slug
+	}
+}
+
+data "cloudsmith_repository_privilege_grant" "test" {
+	namespace  = cloudsmith_repository_privileges.test.organization
+	repository = cloudsmith_repository_privileges.test.repository
+}
+`, os.Getenv("CLOUDSMITH_NAMESPACE"))
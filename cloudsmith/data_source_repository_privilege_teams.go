@@ -0,0 +1,101 @@
+package cloudsmith
+
+import (
+	"fmt"
+
+	"github.com/cloudsmith-io/cloudsmith-api-go"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// flattenRepositoryPrivilegeTeamList converts the team privileges as returned
+// by the Cloudsmith API into a list, looking up the name of each team in the
+// teams of the organization. Privileges of users and services are skipped.
+func flattenRepositoryPrivilegeTeamList(privileges []cloudsmith.RepositoryPrivilegeDict, teams []cloudsmith.OrganizationTeam) []interface{} {
+	teamNames := make(map[string]string, len(teams))
+	for _, team := range teams {
+		teamNames[team.GetSlug()] = team.GetName()
+	}
+
+	teamPrivileges := make([]interface{}, 0, len(privileges))
+	for _, privilege := range privileges {
+		if !privilege.HasTeam() {
+			continue
+		}
+
+		teamPrivileges = append(teamPrivileges, map[string]interface{}{
+			"privilege": privilege.GetPrivilege(),
+			"team_name": teamNames[privilege.GetTeam()],
+			"team_slug": privilege.GetTeam(),
+		})
+	}
+	return teamPrivileges
+}
+
+func dataSourceRepositoryPrivilegeTeamsRead(d *schema.ResourceData, m interface{}) error {
+	pc := m.(*providerConfig)
+
+	namespace := requiredString(d, "namespace")
+	repository := requiredString(d, "repository")
+
+	privileges, _, err := listRepositoryPrivileges(pc, namespace, repository)
+	if err != nil {
+		return fmt.Errorf("error listing privileges of repository (%s): %w", repository, err)
+	}
+
+	teams, err := retrieveTeamListPages(pc, namespace, -1, -1)
+	if err != nil {
+		return fmt.Errorf("error retrieving teams for organization %s: %w", namespace, err)
+	}
+
+	d.Set("team_privileges", flattenRepositoryPrivilegeTeamList(privileges, teams))
+
+	d.SetId(fmt.Sprintf("%s/%s", namespace, repository))
+
+	return nil
+}
+
+func dataSourceRepositoryPrivilegeTeams() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceRepositoryPrivilegeTeamsRead,
+
+		Schema: map[string]*schema.Schema{
+			"namespace": {
+				Type:         schema.TypeString,
+				Description:  "Namespace to which the repository belongs.",
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"repository": {
+				Type:         schema.TypeString,
+				Description:  "Repository to list the team privileges of.",
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"team_privileges": {
+				Type:        schema.TypeList,
+				Description: "The privileges granted to teams on the repository.",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"privilege": {
+							Type:        schema.TypeString,
+							Description: "The privilege granted, i.e. Admin, Write or Read.",
+							Computed:    true,
+						},
+						"team_name": {
+							Type:        schema.TypeString,
+							Description: "The name of the team.",
+							Computed:    true,
+						},
+						"team_slug": {
+							Type:        schema.TypeString,
+							Description: "The slug of the team.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
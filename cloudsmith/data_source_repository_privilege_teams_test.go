@@ -0,0 +1,91 @@
+//nolint:testpackage
+package cloudsmith
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/cloudsmith-io/cloudsmith-api-go"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// TestAccDataSourceRepositoryPrivilegeTeams_basic grants a team a privilege
+// on a repository and verifies the team and its name are listed by the data
+// source.
+func TestAccDataSourceRepositoryPrivilegeTeams_basic(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccRepositoryCheckDestroy("cloudsmith_repository.test"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceRepositoryPrivilegeTeamsConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.cloudsmith_repository_privilege_teams.test", "team_privileges.#", "1"),
+					resource.TestCheckResourceAttr("data.cloudsmith_repository_privilege_teams.test", "team_privileges.0.privilege", "Write"),
+					resource.TestCheckResourceAttr("data.cloudsmith_repository_privilege_teams.test", "team_privileges.0.team_name", "terraform-acc-test-privilege-teams"),
+					resource.TestCheckResourceAttrPair("data.cloudsmith_repository_privilege_teams.test", "team_privileges.0.team_slug", "cloudsmith_team.test", "slug"),
+				),
+			},
+		},
+	})
+}
+
+func TestFlattenRepositoryPrivilegeTeamList(t *testing.T) {
+	teamPrivileges := flattenRepositoryPrivilegeTeamList([]cloudsmith.RepositoryPrivilegeDict{
+		{Privilege: "Admin", User: cloudsmith.PtrString("jane")},
+		{Privilege: "Write", Team: cloudsmith.PtrString("developers")},
+		{Privilege: "Read", Team: cloudsmith.PtrString("removed")},
+		{Privilege: "Read", Service: cloudsmith.PtrString("ci")},
+	}, []cloudsmith.OrganizationTeam{
+		{Name: "Developers", Slug: cloudsmith.PtrString("developers")},
+		{Name: "Operations", Slug: cloudsmith.PtrString("operations")},
+	})
+
+	expected := []interface{}{
+		map[string]interface{}{"privilege": "Write", "team_name": "Developers", "team_slug": "developers"},
+		map[string]interface{}{"privilege": "Read", "team_name": "", "team_slug": "removed"},
+	}
+	if !reflect.DeepEqual(teamPrivileges, expected) {
+		t.Errorf("expected %v, got %v", expected, teamPrivileges)
+	}
+}
+
+var testAccDataSourceRepositoryPrivilegeTeamsConfig = fmt.Sprintf(`
+resource "cloudsmith_repository" "test" {
+	name      = "terraform-acc-test-privilege-teams"
+	namespace = "%s"
+}
+
+resource "cloudsmith_team" "test" {
+	name         = "terraform-acc-test-privilege-teams"
+	organization = cloudsmith_repository.test.namespace
+}
+
+data "cloudsmith_user_self" "current" {}
+
+resource "cloudsmith_repository_privileges" "test" {
+	organization = cloudsmith_repository.test.namespace
+	repository   = cloudsmith_repository.test.slug
+
+	team {
+		privilege = "Write"
+		slug      = cloudsmith_team.test.slug
+	}
+
+	# Include the authenticated account explicitly to satisfy lockout safeguard.
+	user {
+		privilege = "Admin"
+		slug      = data.cloudsmith_user_self.current.slug
+	}
+}
+
+data "cloudsmith_repository_privilege_teams" "test" {
+	namespace  = cloudsmith_repository_privileges.test.organization
+	repository = cloudsmith_repository_privileges.test.repository
+}
+`, os.Getenv("CLOUDSMITH_NAMESPACE"))
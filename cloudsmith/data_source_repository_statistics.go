@@ -0,0 +1,82 @@
+package cloudsmith
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceRepositoryStatisticsRead(d *schema.ResourceData, m interface{}) error {
+	pc := m.(*providerConfig)
+
+	namespace := requiredString(d, "namespace")
+	repository := requiredString(d, "repository")
+
+	req := pc.APIClient.ReposApi.ReposRead(pc.Auth, namespace, repository)
+	repo, _, err := pc.APIClient.ReposApi.ReposReadExecute(req)
+	if err != nil {
+		return fmt.Errorf("error reading repository (%s.%s): %w", namespace, repository, err)
+	}
+
+	metrics, err := retrievePackageMetrics(pc, namespace, repository, "", time.Time{})
+	if err != nil {
+		return fmt.Errorf("error reading repository (%s.%s) metrics: %w", namespace, repository, err)
+	}
+
+	bandwidth, err := bandwidthBytes(metrics.Bandwidth.Total)
+	if err != nil {
+		return fmt.Errorf("error reading repository (%s.%s) metrics: %w", namespace, repository, err)
+	}
+
+	d.Set("bandwidth_total_bytes", bandwidth)
+	d.Set("downloads_total", repo.GetNumDownloads())
+	d.Set("packages_total", repo.GetPackageCount())
+	d.Set("storage_used_bytes", repo.GetSize())
+
+	d.SetId(fmt.Sprintf("%s.%s", namespace, repository))
+
+	return nil
+}
+
+func dataSourceRepositoryStatistics() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceRepositoryStatisticsRead,
+
+		Schema: map[string]*schema.Schema{
+			"bandwidth_total_bytes": {
+				Type:        schema.TypeInt,
+				Description: "The bandwidth used by downloads from the repository in bytes, converted from the units reported by the API.",
+				Computed:    true,
+			},
+			"downloads_total": {
+				Type:        schema.TypeInt,
+				Description: "The number of times packages in the repository have been downloaded.",
+				Computed:    true,
+			},
+			"namespace": {
+				Type:         schema.TypeString,
+				Description:  "Namespace to which the repository belongs.",
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"packages_total": {
+				Type:        schema.TypeInt,
+				Description: "The number of packages in the repository.",
+				Computed:    true,
+			},
+			"repository": {
+				Type:         schema.TypeString,
+				Description:  "The repository to retrieve the statistics of.",
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"storage_used_bytes": {
+				Type:        schema.TypeInt,
+				Description: "The storage used by the repository in bytes.",
+				Computed:    true,
+			},
+		},
+	}
+}
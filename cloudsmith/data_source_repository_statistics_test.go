@@ -0,0 +1,45 @@
+//nolint:testpackage
+package cloudsmith
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// TestAccDataSourceRepositoryStatistics_basic creates an empty repository and
+// verifies that its statistics are read.
+func TestAccDataSourceRepositoryStatistics_basic(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccRepositoryCheckDestroy("cloudsmith_repository.test"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceRepositoryStatisticsConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.cloudsmith_repository_statistics.test", "packages_total", "0"),
+					resource.TestCheckResourceAttr("data.cloudsmith_repository_statistics.test", "downloads_total", "0"),
+					resource.TestCheckResourceAttrSet("data.cloudsmith_repository_statistics.test", "storage_used_bytes"),
+					resource.TestCheckResourceAttrSet("data.cloudsmith_repository_statistics.test", "bandwidth_total_bytes"),
+				),
+			},
+		},
+	})
+}
+
+var testAccDataSourceRepositoryStatisticsConfig = fmt.Sprintf(`
+resource "cloudsmith_repository" "test" {
+	name      = "terraform-acc-test-repository-statistics"
+	namespace = "%s"
+}
+
+data "cloudsmith_repository_statistics" "test" {
+	namespace  = cloudsmith_repository.test.namespace
+	repository = cloudsmith_repository.test.slug_perm
+}
+`, os.Getenv("CLOUDSMITH_NAMESPACE"))
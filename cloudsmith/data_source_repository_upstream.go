@@ -0,0 +1,185 @@
+package cloudsmith
+
+import (
+	"fmt"
+
+	"github.com/cloudsmith-io/cloudsmith-api-go"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceRepositoryUpstreamRead(d *schema.ResourceData, m interface{}) error {
+	// getUpstream reads the upstream identified by the resource ID, which for
+	// both the resource and the data source is the upstream's slug_perm.
+	d.SetId(requiredString(d, SlugPerm))
+
+	upstream, _, err := getUpstream(d, m)
+	if err != nil {
+		return fmt.Errorf("error reading upstream (%s): %w", d.Id(), err)
+	}
+
+	_ = d.Set(AuthMode, upstream.GetAuthMode())
+	_ = d.Set(AuthUsername, upstream.GetAuthUsername())
+	_ = d.Set(CreatedAt, timeToString(upstream.GetCreatedAt()))
+	_ = d.Set(ExtraHeader1, upstream.GetExtraHeader1())
+	_ = d.Set(ExtraHeader2, upstream.GetExtraHeader2())
+	_ = d.Set(ExtraValue1, upstream.GetExtraValue1())
+	_ = d.Set(ExtraValue2, upstream.GetExtraValue2())
+	_ = d.Set(IsActive, upstream.GetIsActive())
+	_ = d.Set(Mode, upstream.GetMode())
+	_ = d.Set(Name, upstream.GetName())
+	_ = d.Set(Priority, upstream.GetPriority())
+	_ = d.Set(UpdatedAt, timeToString(upstream.GetUpdatedAt()))
+	_ = d.Set(UpstreamUrl, upstream.GetUpstreamUrl())
+	_ = d.Set(VerifySsl, upstream.GetVerifySsl())
+
+	switch u := upstream.(type) {
+	case *cloudsmith.DebUpstream:
+		_ = d.Set(Component, u.GetComponent())
+		_ = d.Set(DistroVersions, flattenStrings(u.GetDistroVersions()))
+		_ = d.Set(IncludeSources, u.GetIncludeSources())
+		_ = d.Set(UpstreamDistribution, u.GetUpstreamDistribution())
+	case *cloudsmith.GenericUpstream:
+		_ = d.Set(UpstreamPrefix, u.GetUpstreamPrefix())
+	case *cloudsmith.RpmUpstream:
+		_ = d.Set(DistroVersion, u.GetDistroVersion())
+		_ = d.Set(IncludeSources, u.GetIncludeSources())
+	}
+
+	return nil
+}
+
+//nolint:funlen
+func dataSourceRepositoryUpstream() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceRepositoryUpstreamRead,
+
+		Schema: map[string]*schema.Schema{
+			AuthMode: {
+				Type:        schema.TypeString,
+				Description: "The authentication mode used when accessing this upstream.",
+				Computed:    true,
+			},
+			AuthUsername: {
+				Type:        schema.TypeString,
+				Description: "Username provided with requests to upstream.",
+				Computed:    true,
+			},
+			Component: {
+				Type:        schema.TypeString,
+				Description: "(deb only) The component fetched from the upstream.",
+				Computed:    true,
+			},
+			CreatedAt: {
+				Type:        schema.TypeString,
+				Description: "ISO 8601 timestamp at which the Upstream was created.",
+				Computed:    true,
+			},
+			DistroVersion: {
+				Type:        schema.TypeString,
+				Description: "(rpm only) The distribution version that packages found on this upstream are associated with.",
+				Computed:    true,
+			},
+			DistroVersions: {
+				Type:        schema.TypeSet,
+				Description: "(deb only) The distribution versions that packages found on this upstream are associated with.",
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			ExtraHeader1: {
+				Type:        schema.TypeString,
+				Description: "The key for extra header #1 sent to upstream.",
+				Computed:    true,
+			},
+			ExtraHeader2: {
+				Type:        schema.TypeString,
+				Description: "The key for extra header #2 sent to upstream.",
+				Computed:    true,
+			},
+			ExtraValue1: {
+				Type:        schema.TypeString,
+				Description: "The value for extra header #1 sent to upstream.",
+				Computed:    true,
+			},
+			ExtraValue2: {
+				Type:        schema.TypeString,
+				Description: "The value for extra header #2 sent to upstream.",
+				Computed:    true,
+			},
+			IncludeSources: {
+				Type:        schema.TypeBool,
+				Description: "(deb/rpm only) When true, source packages are available from this upstream.",
+				Computed:    true,
+			},
+			IsActive: {
+				Type:        schema.TypeBool,
+				Description: "Whether or not this upstream is active and ready for requests.",
+				Computed:    true,
+			},
+			Mode: {
+				Type:        schema.TypeString,
+				Description: "The mode that this upstream operates in.",
+				Computed:    true,
+			},
+			Name: {
+				Type:        schema.TypeString,
+				Description: "A descriptive name for this upstream source.",
+				Computed:    true,
+			},
+			Namespace: {
+				Type:         schema.TypeString,
+				Description:  "The Organization to which the Upstream belongs.",
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			Priority: {
+				Type:        schema.TypeInt,
+				Description: "The order in which this upstream is selected for resolving requests.",
+				Computed:    true,
+			},
+			Repository: {
+				Type:         schema.TypeString,
+				Description:  "The Repository to which the Upstream belongs.",
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			SlugPerm: {
+				Type:         schema.TypeString,
+				Description:  "The unique identifier for this Upstream.",
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			UpdatedAt: {
+				Type:        schema.TypeString,
+				Description: "ISO 8601 timestamp at which the Upstream was updated.",
+				Computed:    true,
+			},
+			UpstreamDistribution: {
+				Type:        schema.TypeString,
+				Description: "(deb only) The distribution fetched from the upstream.",
+				Computed:    true,
+			},
+			UpstreamPrefix: {
+				Type:        schema.TypeString,
+				Description: "(generic only) The prefix used to distinguish this upstream source within the repository.",
+				Computed:    true,
+			},
+			UpstreamType: {
+				Type:         schema.TypeString,
+				Description:  "The type of Upstream (docker, nuget, python, ...)",
+				Required:     true,
+				ValidateFunc: validation.StringInSlice(upstreamTypes, false),
+			},
+			UpstreamUrl: {
+				Type:        schema.TypeString,
+				Description: "The URL for this upstream source.",
+				Computed:    true,
+			},
+			VerifySsl: {
+				Type:        schema.TypeBool,
+				Description: "If enabled, SSL certificates are verified when requests are made to this upstream.",
+				Computed:    true,
+			},
+		},
+	}
+}
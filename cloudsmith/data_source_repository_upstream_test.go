@@ -0,0 +1,59 @@
+//nolint:testpackage
+package cloudsmith
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// TestAccDataSourceRepositoryUpstream_basic creates a python upstream, then
+// reads it back using the data source and verifies that its configuration
+// matches the resource.
+func TestAccDataSourceRepositoryUpstream_basic(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccRepositoryCheckDestroy("cloudsmith_repository.test"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceRepositoryUpstreamConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.cloudsmith_repository_upstream.test", "name", "TF Test Upstream Data"),
+					resource.TestCheckResourceAttr("data.cloudsmith_repository_upstream.test", "upstream_url", "https://pypi.org"),
+					resource.TestCheckResourceAttr("data.cloudsmith_repository_upstream.test", "mode", "Proxy Only"),
+					resource.TestCheckResourceAttrPair("data.cloudsmith_repository_upstream.test", "priority", "cloudsmith_repository_upstream.test", "priority"),
+					resource.TestCheckResourceAttrPair("data.cloudsmith_repository_upstream.test", "verify_ssl", "cloudsmith_repository_upstream.test", "verify_ssl"),
+					resource.TestCheckResourceAttrSet("data.cloudsmith_repository_upstream.test", "created_at"),
+				),
+			},
+		},
+	})
+}
+
+var testAccDataSourceRepositoryUpstreamConfig = fmt.Sprintf(`
+resource "cloudsmith_repository" "test" {
+	name      = "terraform-acc-test-upstream-data"
+	namespace = "%s"
+}
+
+resource "cloudsmith_repository_upstream" "test" {
+	name          = "TF Test Upstream Data"
+	namespace     = cloudsmith_repository.test.namespace
+	repository    = cloudsmith_repository.test.slug
+	mode          = "Proxy Only"
+	upstream_type = "python"
+	upstream_url  = "https://pypi.org"
+}
+
+data "cloudsmith_repository_upstream" "test" {
+	namespace     = cloudsmith_repository_upstream.test.namespace
+	repository    = cloudsmith_repository_upstream.test.repository
+	upstream_type = cloudsmith_repository_upstream.test.upstream_type
+	slug_perm     = cloudsmith_repository_upstream.test.slug_perm
+}
+`, os.Getenv("CLOUDSMITH_NAMESPACE"))
@@ -0,0 +1,93 @@
+package cloudsmith
+
+import (
+	"fmt"
+
+	"github.com/cloudsmith-io/cloudsmith-api-go"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// findServiceByName returns the service with the given name, returning an
+// error if there is no such service or the name is ambiguous.
+func findServiceByName(services []cloudsmith.Service, name string) (*cloudsmith.Service, error) {
+	var found *cloudsmith.Service
+	for i := range services {
+		if services[i].GetName() != name {
+			continue
+		}
+		if found != nil {
+			return nil, fmt.Errorf("more than one service is named %q", name)
+		}
+		found = &services[i]
+	}
+
+	if found == nil {
+		return nil, fmt.Errorf("no service is named %q", name)
+	}
+
+	return found, nil
+}
+
+func dataSourceServiceAccountRead(d *schema.ResourceData, m interface{}) error {
+	pc := m.(*providerConfig)
+
+	namespace := requiredString(d, "namespace")
+	name := requiredString(d, "name")
+
+	services, err := retrieveServiceListPages(pc, namespace, -1, -1, nil, nil)
+	if err != nil {
+		return fmt.Errorf("error listing services in %s: %w", namespace, err)
+	}
+
+	service, err := findServiceByName(services, name)
+	if err != nil {
+		return err
+	}
+
+	d.Set("description", service.GetDescription())
+	d.Set("role", service.GetRole())
+	d.Set("slug", service.GetSlug())
+
+	d.SetId(fmt.Sprintf("%s.%s", namespace, service.GetSlug()))
+
+	return nil
+}
+
+func dataSourceServiceAccount() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceServiceAccountRead,
+
+		// the API key of a service is deliberately not exposed, as it's only
+		// returned in full when the service is created.
+		Schema: map[string]*schema.Schema{
+			"description": {
+				Type:        schema.TypeString,
+				Description: "The description of the service.",
+				Computed:    true,
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Description:  "The name of the service to look up.",
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"namespace": {
+				Type:         schema.TypeString,
+				Description:  "Organization to which the service belongs.",
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"role": {
+				Type:        schema.TypeString,
+				Description: "The role of the service in the organization.",
+				Computed:    true,
+			},
+			"slug": {
+				Type:        schema.TypeString,
+				Description: "The slug that identifies the service. The API key of the service is not exposed.",
+				Computed:    true,
+			},
+		},
+	}
+}
@@ -0,0 +1,77 @@
+//nolint:testpackage
+package cloudsmith
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/cloudsmith-io/cloudsmith-api-go"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestFindServiceByName(t *testing.T) {
+	t.Parallel()
+
+	newService := func(name, slug string) cloudsmith.Service {
+		service := cloudsmith.Service{Name: name}
+		service.SetSlug(slug)
+		return service
+	}
+	services := []cloudsmith.Service{
+		newService("ci", "ci"),
+		newService("deploy", "deploy"),
+		newService("shared", "shared-1"),
+		newService("shared", "shared-2"),
+	}
+
+	service, err := findServiceByName(services, "deploy")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if service.GetSlug() != "deploy" {
+		t.Errorf("expected deploy, got %s", service.GetSlug())
+	}
+
+	if _, err := findServiceByName(services, "shared"); err == nil {
+		t.Error("expected an error for an ambiguous name")
+	}
+	if _, err := findServiceByName(services, "missing"); err == nil {
+		t.Error("expected an error for a missing service")
+	}
+}
+
+// TestAccDataSourceServiceAccount_basic creates a service, then looks it up by
+// name using the data source.
+func TestAccDataSourceServiceAccount_basic(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceServiceAccountConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.cloudsmith_service_account.test", "role", "Member"),
+					resource.TestCheckResourceAttr("data.cloudsmith_service_account.test", "description", "TF Test Service Account"),
+					resource.TestCheckResourceAttrPair("data.cloudsmith_service_account.test", "slug", "cloudsmith_service.test", "slug"),
+				),
+			},
+		},
+	})
+}
+
+var testAccDataSourceServiceAccountConfig = fmt.Sprintf(`
+resource "cloudsmith_service" "test" {
+	name         = "terraform-acc-test-service-account"
+	description  = "TF Test Service Account"
+	organization = "%s"
+	role         = "Member"
+}
+
+data "cloudsmith_service_account" "test" {
+	namespace = cloudsmith_service.test.organization
+	name      = cloudsmith_service.test.name
+}
+`, os.Getenv("CLOUDSMITH_NAMESPACE"))
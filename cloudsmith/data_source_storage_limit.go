@@ -0,0 +1,97 @@
+package cloudsmith
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceStorageLimitRead(d *schema.ResourceData, m interface{}) error {
+	pc := m.(*providerConfig)
+
+	namespace := requiredString(d, "namespace")
+
+	req := pc.APIClient.QuotaApi.QuotaRead(pc.Auth, namespace)
+	quota, _, err := pc.APIClient.QuotaApi.QuotaReadExecute(req)
+	if err != nil {
+		return err
+	}
+
+	usage := quota.GetUsage()
+	raw := usage.GetRaw()
+	storage := raw.GetStorage()
+	bandwidth := raw.GetBandwidth()
+
+	d.SetId(namespace)
+	d.Set("bandwidth_limit", bandwidth.GetConfigured())
+	d.Set("bandwidth_percentage_used", bandwidth.GetPercentageUsed())
+	d.Set("bandwidth_plan_limit", bandwidth.GetPlanLimit())
+	d.Set("bandwidth_used", bandwidth.GetUsed())
+	d.Set("limit", storage.GetConfigured())
+	d.Set("peak", storage.GetPeak())
+	d.Set("percentage_used", storage.GetPercentageUsed())
+	d.Set("plan_limit", storage.GetPlanLimit())
+	d.Set("used", storage.GetUsed())
+
+	return nil
+}
+
+//nolint:funlen
+func dataSourceStorageLimit() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceStorageLimitRead,
+
+		Schema: map[string]*schema.Schema{
+			"bandwidth_limit": {
+				Type:        schema.TypeInt,
+				Description: "The configured bandwidth limit in bytes.",
+				Computed:    true,
+			},
+			"bandwidth_percentage_used": {
+				Type:        schema.TypeFloat,
+				Description: "The percentage of the bandwidth limit used.",
+				Computed:    true,
+			},
+			"bandwidth_plan_limit": {
+				Type:        schema.TypeInt,
+				Description: "The bandwidth limit in bytes included in the plan.",
+				Computed:    true,
+			},
+			"bandwidth_used": {
+				Type:        schema.TypeInt,
+				Description: "The bandwidth used in bytes.",
+				Computed:    true,
+			},
+			"limit": {
+				Type:        schema.TypeInt,
+				Description: "The configured storage limit in bytes.",
+				Computed:    true,
+			},
+			"namespace": {
+				Type:         schema.TypeString,
+				Description:  "The namespace to retrieve storage limits for.",
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"peak": {
+				Type:        schema.TypeInt,
+				Description: "The peak storage used in bytes.",
+				Computed:    true,
+			},
+			"percentage_used": {
+				Type:        schema.TypeFloat,
+				Description: "The percentage of the storage limit used.",
+				Computed:    true,
+			},
+			"plan_limit": {
+				Type:        schema.TypeInt,
+				Description: "The storage limit in bytes included in the plan.",
+				Computed:    true,
+			},
+			"used": {
+				Type:        schema.TypeInt,
+				Description: "The storage used in bytes.",
+				Computed:    true,
+			},
+		},
+	}
+}
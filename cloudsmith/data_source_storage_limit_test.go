@@ -0,0 +1,39 @@
+//nolint:testpackage
+package cloudsmith
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// TestAccStorageLimit_data reads the storage and bandwidth usage of the
+// configured namespace and verifies that the expected fields are set.
+func TestAccStorageLimit_data(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccStorageLimitData,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.cloudsmith_storage_limit.test", "id", os.Getenv("CLOUDSMITH_NAMESPACE")),
+					resource.TestCheckResourceAttrSet("data.cloudsmith_storage_limit.test", "used"),
+					resource.TestCheckResourceAttrSet("data.cloudsmith_storage_limit.test", "plan_limit"),
+					resource.TestCheckResourceAttrSet("data.cloudsmith_storage_limit.test", "bandwidth_used"),
+					resource.TestCheckResourceAttrSet("data.cloudsmith_storage_limit.test", "bandwidth_plan_limit"),
+				),
+			},
+		},
+	})
+}
+
+var testAccStorageLimitData = fmt.Sprintf(`
+data "cloudsmith_storage_limit" "test" {
+	namespace = "%s"
+}
+`, os.Getenv("CLOUDSMITH_NAMESPACE"))
@@ -0,0 +1,87 @@
+package cloudsmith
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// dataSourceTeamRead looks up a team within an organization by its slug.
+func dataSourceTeamRead(d *schema.ResourceData, m interface{}) error {
+	pc := m.(*providerConfig)
+
+	organization := requiredString(d, "organization")
+	slug := requiredString(d, "slug")
+
+	req := pc.APIClient.OrgsApi.OrgsTeamsRead(pc.Auth, organization, slug)
+	team, _, err := pc.APIClient.OrgsApi.OrgsTeamsReadExecute(req)
+	if err != nil {
+		return fmt.Errorf("error retrieving team %s/%s: %w", organization, slug, err)
+	}
+
+	// the team itself doesn't include its members, so they're counted from
+	// the team's membership.
+	mreq := pc.APIClient.OrgsApi.OrgsTeamsMembersList(pc.Auth, organization, slug)
+	members, _, err := pc.APIClient.OrgsApi.OrgsTeamsMembersListExecute(mreq)
+	if err != nil {
+		return fmt.Errorf("error retrieving team members for %s/%s: %w", organization, slug, err)
+	}
+
+	d.Set("description", team.GetDescription())
+	d.Set("member_count", len(members.GetMembers()))
+	d.Set("name", team.GetName())
+	d.Set("slug_perm", team.GetSlugPerm())
+	d.Set("visibility", team.GetVisibility())
+
+	d.SetId(fmt.Sprintf("%s/%s", organization, slug))
+
+	return nil
+}
+
+// dataSourceTeam defines the schema for looking up a single team.
+func dataSourceTeam() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceTeamRead,
+
+		Schema: map[string]*schema.Schema{
+			"description": {
+				Type:        schema.TypeString,
+				Description: "A description of the team's purpose.",
+				Computed:    true,
+			},
+			"member_count": {
+				Type:        schema.TypeInt,
+				Description: "The number of members of the team.",
+				Computed:    true,
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Description: "The name of the team.",
+				Computed:    true,
+			},
+			"organization": {
+				Type:         schema.TypeString,
+				Description:  "Organization to which the team belongs.",
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"slug": {
+				Type:         schema.TypeString,
+				Description:  "The slug of the team.",
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"slug_perm": {
+				Type:        schema.TypeString,
+				Description: "The slug_perm that immutably identifies the team.",
+				Computed:    true,
+			},
+			"visibility": {
+				Type:        schema.TypeString,
+				Description: "Controls if the team is visible or hidden from non-members.",
+				Computed:    true,
+			},
+		},
+	}
+}
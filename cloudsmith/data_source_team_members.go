@@ -2,8 +2,6 @@ package cloudsmith
 
 import (
 	"fmt"
-	"strconv"
-	"time"
 
 	"github.com/cloudsmith-io/cloudsmith-api-go"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -31,8 +29,7 @@ func dataSourceTeamMembersRead(d *schema.ResourceData, m interface{}) error {
 		return fmt.Errorf("error setting members: %w", err)
 	}
 
-	// Ephemeral ID for the data source
-	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
+	d.SetId(fmt.Sprintf("%s/%s", organization, teamName))
 	return nil
 }
 
@@ -20,6 +20,10 @@ func TestAccDataSourceTeamMembers_basic(t *testing.T) {
 				Config: testAccDataSourceTeamMembersConfig(),
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr("data.cloudsmith_team_members.test", "team_name", "terraform-acc-test-team-members"),
+					resource.TestCheckResourceAttr(
+						"data.cloudsmith_team_members.test", "id",
+						os.Getenv("CLOUDSMITH_NAMESPACE")+"/terraform-acc-test-team-members",
+					),
 					resource.TestCheckResourceAttrSet("data.cloudsmith_team_members.test", "members.0.user"),
 					resource.TestCheckResourceAttrSet("data.cloudsmith_team_members.test", "members.0.role"),
 				),
@@ -0,0 +1,108 @@
+//nolint:testpackage
+package cloudsmith
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// TestAccDataSourceTeam_basic creates a team and verifies the data source
+// looks it up by its slug.
+func TestAccDataSourceTeam_basic(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceTeamConfig(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.cloudsmith_team.test", "name", "terraform-acc-test-team-data"),
+					resource.TestCheckResourceAttr("data.cloudsmith_team.test", "description", "Acceptance test team data"),
+					resource.TestCheckResourceAttr("data.cloudsmith_team.test", "visibility", "Visible"),
+					resource.TestCheckResourceAttrPair("data.cloudsmith_team.test", "slug_perm", "cloudsmith_team.test", "slug_perm"),
+					resource.TestCheckResourceAttrSet("data.cloudsmith_team.test", "member_count"),
+				),
+			},
+		},
+	})
+}
+
+// TestDataSourceTeamRead serves a team and its members and verifies the team
+// attributes and member count are set.
+func TestDataSourceTeamRead(t *testing.T) {
+	t.Parallel()
+
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	mux.HandleFunc("/orgs/my-org/teams/developers/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"name":        "Developers",
+			"description": "All developers",
+			"slug":        "developers",
+			"slug_perm":   "AbCdEf123",
+			"visibility":  "Visible",
+		})
+	})
+	mux.HandleFunc("/orgs/my-org/teams/developers/members", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"members": []map[string]string{
+				{"role": "Manager", "user": "jane"},
+				{"role": "Member", "user": "john"},
+			},
+		})
+	})
+
+	pc := testProviderConfig(server.URL)
+
+	d := schema.TestResourceDataRaw(t, dataSourceTeam().Schema, map[string]interface{}{
+		"organization": "my-org",
+		"slug":         "developers",
+	})
+	if err := dataSourceTeamRead(d, pc); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	for key, expected := range map[string]interface{}{
+		"description":  "All developers",
+		"member_count": 2,
+		"name":         "Developers",
+		"slug_perm":    "AbCdEf123",
+		"visibility":   "Visible",
+	} {
+		if actual := d.Get(key); actual != expected {
+			t.Errorf("expected %s to be %v, got %v", key, expected, actual)
+		}
+	}
+	if d.Id() != "my-org/developers" {
+		t.Errorf("unexpected id %s", d.Id())
+	}
+}
+
+func testAccDataSourceTeamConfig() string {
+	return fmt.Sprintf(`
+resource "cloudsmith_team" "test" {
+  name         = "terraform-acc-test-team-data"
+  organization = "%s"
+  description  = "Acceptance test team data"
+  visibility   = "Visible"
+}
+
+data "cloudsmith_team" "test" {
+  organization = cloudsmith_team.test.organization
+  slug         = cloudsmith_team.test.slug
+}
+`, os.Getenv("CLOUDSMITH_NAMESPACE"))
+}
@@ -0,0 +1,127 @@
+package cloudsmith
+
+import (
+	"fmt"
+
+	"github.com/cloudsmith-io/cloudsmith-api-go"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// webhookListPageSize is the number of webhooks fetched per page when listing
+// the webhooks of a repository.
+const webhookListPageSize = 100
+
+// listWebhooks retrieves every webhook of a repository.
+func listWebhooks(pc *providerConfig, namespace, repository string) ([]cloudsmith.RepositoryWebhook, error) {
+	var webhooks []cloudsmith.RepositoryWebhook
+	for page := int64(1); ; page++ {
+		req := pc.APIClient.WebhooksApi.WebhooksList(pc.Auth, namespace, repository)
+		req = req.Page(page)
+		req = req.PageSize(webhookListPageSize)
+		webhooksPage, _, err := pc.APIClient.WebhooksApi.WebhooksListExecute(req)
+		if err != nil {
+			return nil, err
+		}
+
+		webhooks = append(webhooks, webhooksPage...)
+		if len(webhooksPage) < webhookListPageSize {
+			return webhooks, nil
+		}
+	}
+}
+
+// selectWebhookByURL returns the only webhook with the given target URL. The
+// API can't filter webhooks by URL, so they're filtered here.
+func selectWebhookByURL(webhooks []cloudsmith.RepositoryWebhook, webhookURL string) (*cloudsmith.RepositoryWebhook, error) {
+	var selected *cloudsmith.RepositoryWebhook
+	for i := range webhooks {
+		if webhooks[i].GetTargetUrl() != webhookURL {
+			continue
+		}
+		if selected != nil {
+			return nil, fmt.Errorf("more than one webhook has URL %s", webhookURL)
+		}
+		selected = &webhooks[i]
+	}
+
+	if selected == nil {
+		return nil, fmt.Errorf("no webhook has URL %s", webhookURL)
+	}
+	return selected, nil
+}
+
+func dataSourceWebhookRead(d *schema.ResourceData, m interface{}) error {
+	pc := m.(*providerConfig)
+
+	namespace := requiredString(d, "namespace")
+	repository := requiredString(d, "repository")
+	webhookURL := requiredString(d, "webhook_url")
+
+	webhooks, err := listWebhooks(pc, namespace, repository)
+	if err != nil {
+		return fmt.Errorf("error listing webhooks of repository (%s): %w", repository, err)
+	}
+
+	webhook, err := selectWebhookByURL(webhooks, webhookURL)
+	if err != nil {
+		return fmt.Errorf("error selecting webhook in %s/%s: %w", namespace, repository, err)
+	}
+
+	d.Set("is_active", webhook.GetIsActive())
+	d.Set("package_events", flattenEvents(webhook.GetEvents()))
+	d.Set("request_body_format", flattenRequestBodyFormat(webhook.GetRequestBodyFormat()))
+	d.Set("slug_perm", webhook.GetSlugPerm())
+
+	d.SetId(fmt.Sprintf("%s.%s.%s", namespace, repository, webhook.GetSlugPerm()))
+
+	return nil
+}
+
+func dataSourceWebhook() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceWebhookRead,
+
+		Schema: map[string]*schema.Schema{
+			"is_active": {
+				Type:        schema.TypeBool,
+				Description: "If enabled, the webhook will trigger on subscribed events and send payloads to the configured target URL.",
+				Computed:    true,
+			},
+			"namespace": {
+				Type:         schema.TypeString,
+				Description:  "Namespace to which the webhook belongs.",
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"package_events": {
+				Type:        schema.TypeSet,
+				Description: "List of events for which the webhook is fired.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Computed:    true,
+			},
+			"repository": {
+				Type:         schema.TypeString,
+				Description:  "Repository to which the webhook belongs.",
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"request_body_format": {
+				Type:        schema.TypeString,
+				Description: "The format of the payloads for webhook requests.",
+				Computed:    true,
+			},
+			"slug_perm": {
+				Type:        schema.TypeString,
+				Description: "The slug_perm of the webhook.",
+				Computed:    true,
+			},
+			"webhook_url": {
+				Type:         schema.TypeString,
+				Description:  "The target URL of the webhook to look up.",
+				Required:     true,
+				ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+			},
+		},
+	}
+}
@@ -0,0 +1,86 @@
+//nolint:testpackage
+package cloudsmith
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/cloudsmith-io/cloudsmith-api-go"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// TestAccDataSourceWebhook_basic creates a webhook and verifies the data
+// source looks it up by its URL.
+func TestAccDataSourceWebhook_basic(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccWebhookCheckDestroy("cloudsmith_webhook.test"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceWebhookConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.cloudsmith_webhook.test", "slug_perm", "cloudsmith_webhook.test", "slug_perm"),
+					resource.TestCheckResourceAttr("data.cloudsmith_webhook.test", "is_active", "true"),
+					resource.TestCheckResourceAttr("data.cloudsmith_webhook.test", "package_events.#", "2"),
+					resource.TestCheckResourceAttr("data.cloudsmith_webhook.test", "request_body_format", "JSON Object"),
+				),
+			},
+		},
+	})
+}
+
+func TestSelectWebhookByURL(t *testing.T) {
+	t.Parallel()
+
+	newWebhook := func(slugPerm, targetURL string) cloudsmith.RepositoryWebhook {
+		webhook := cloudsmith.RepositoryWebhook{TargetUrl: targetURL}
+		webhook.SetSlugPerm(slugPerm)
+		return webhook
+	}
+
+	webhooks := []cloudsmith.RepositoryWebhook{
+		newWebhook("slack", "https://hooks.slack.com/services/abc"),
+		newWebhook("ci", "https://ci.example.com/hook"),
+		newWebhook("ci-copy", "https://ci.example.com/hook"),
+	}
+
+	webhook, err := selectWebhookByURL(webhooks, "https://hooks.slack.com/services/abc")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if webhook.GetSlugPerm() != "slack" {
+		t.Errorf("expected webhook slack, got %s", webhook.GetSlugPerm())
+	}
+
+	if _, err := selectWebhookByURL(webhooks, "https://ci.example.com/hook"); err == nil {
+		t.Error("expected an error for a URL matching more than one webhook")
+	}
+	if _, err := selectWebhookByURL(webhooks, "https://example.com/missing"); err == nil {
+		t.Error("expected an error for a URL matching no webhook")
+	}
+}
+
+var testAccDataSourceWebhookConfig = fmt.Sprintf(`
+resource "cloudsmith_repository" "test" {
+	name      = "terraform-acc-test-webhook-data"
+	namespace = "%s"
+}
+
+resource "cloudsmith_webhook" "test" {
+    namespace  = "${cloudsmith_repository.test.namespace}"
+    repository = "${cloudsmith_repository.test.slug_perm}"
+
+	events     = ["package.created", "package.deleted"]
+	target_url = "https://example.com/terraform-acc-test-webhook-data"
+}
+
+data "cloudsmith_webhook" "test" {
+    namespace   = cloudsmith_webhook.test.namespace
+    repository  = cloudsmith_webhook.test.repository
+    webhook_url = cloudsmith_webhook.test.target_url
+}
+`, os.Getenv("CLOUDSMITH_NAMESPACE"))
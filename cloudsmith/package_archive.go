@@ -0,0 +1,207 @@
+package cloudsmith
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/bzip2"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ulikunitz/xz"
+)
+
+// archiveExtractors maps the filename suffixes of supported archives to the
+// function extracting them.
+var archiveExtractors = []struct {
+	suffix  string
+	extract func(archivePath, extractDir string) ([]string, error)
+}{
+	{".zip", extractZip},
+	{".tar.gz", extractTarGzip},
+	{".tgz", extractTarGzip},
+	{".tar.bz2", extractTarBzip2},
+	{".tar.xz", extractTarXz},
+	{".txz", extractTarXz},
+	{".tar", extractTar},
+}
+
+// extractArchive extracts an archive into extractDir, choosing the archive
+// format from the filename of the archive. The paths of the extracted files
+// are returned relative to extractDir, sorted and using forward slashes.
+func extractArchive(archivePath, extractDir string) ([]string, error) {
+	name := strings.ToLower(filepath.Base(archivePath))
+	for _, extractor := range archiveExtractors {
+		if !strings.HasSuffix(name, extractor.suffix) {
+			continue
+		}
+
+		if err := os.MkdirAll(extractDir, 0o755); err != nil {
+			return nil, err
+		}
+		files, err := extractor.extract(archivePath, extractDir)
+		if err != nil {
+			return nil, fmt.Errorf("error extracting %s: %w", archivePath, err)
+		}
+		sort.Strings(files)
+		return files, nil
+	}
+
+	return nil, fmt.Errorf("unsupported archive %s, must be one of .zip, .tar, .tar.gz, .tgz, .tar.bz2, .tar.xz or .txz", archivePath)
+}
+
+// archiveEntryPath returns the path an archive entry is extracted to,
+// rejecting entries which would be written outside extractDir. An entry for
+// the root of the archive, e.g. "./", resolves to extractDir itself.
+func archiveEntryPath(extractDir, name string) (string, error) {
+	root := filepath.Clean(extractDir)
+	entryPath := filepath.Join(extractDir, filepath.FromSlash(name))
+	if entryPath != root && !strings.HasPrefix(entryPath, root+string(os.PathSeparator)) {
+		return "", fmt.Errorf("archive entry %s is outside the extract directory", name)
+	}
+	return entryPath, nil
+}
+
+// writeArchiveEntry writes the content of a file in an archive to entryPath.
+func writeArchiveEntry(entryPath string, r io.Reader, mode os.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(entryPath), 0o755); err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(entryPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, mode.Perm()|0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func extractZip(archivePath, extractDir string) ([]string, error) {
+	r, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	files := []string{}
+	for _, entry := range r.File {
+		entryPath, err := archiveEntryPath(extractDir, entry.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		if entry.FileInfo().IsDir() {
+			if err := os.MkdirAll(entryPath, 0o755); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		// only regular files are extracted, so that a symlink in an archive
+		// can't point outside the extract directory.
+		if !entry.Mode().IsRegular() {
+			continue
+		}
+
+		rc, err := entry.Open()
+		if err != nil {
+			return nil, err
+		}
+		err = writeArchiveEntry(entryPath, rc, entry.Mode())
+		rc.Close()
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, filepath.ToSlash(filepath.Clean(filepath.FromSlash(entry.Name))))
+	}
+	return files, nil
+}
+
+func extractTarGzip(archivePath, extractDir string) ([]string, error) {
+	f, err := os.Open(archivePath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, err
+	}
+	defer gz.Close()
+
+	return extractTarReader(gz, extractDir)
+}
+
+func extractTarBzip2(archivePath, extractDir string) ([]string, error) {
+	f, err := os.Open(archivePath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return extractTarReader(bzip2.NewReader(f), extractDir)
+}
+
+func extractTarXz(archivePath, extractDir string) ([]string, error) {
+	f, err := os.Open(archivePath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	xzr, err := xz.NewReader(f)
+	if err != nil {
+		return nil, err
+	}
+
+	return extractTarReader(xzr, extractDir)
+}
+
+func extractTar(archivePath, extractDir string) ([]string, error) {
+	f, err := os.Open(archivePath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return extractTarReader(f, extractDir)
+}
+
+func extractTarReader(r io.Reader, extractDir string) ([]string, error) {
+	tr := tar.NewReader(r)
+
+	files := []string{}
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return files, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		entryPath, err := archiveEntryPath(extractDir, header.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(entryPath, 0o755); err != nil {
+				return nil, err
+			}
+		case tar.TypeReg:
+			if err := writeArchiveEntry(entryPath, tr, header.FileInfo().Mode()); err != nil {
+				return nil, err
+			}
+			files = append(files, filepath.ToSlash(filepath.Clean(filepath.FromSlash(header.Name))))
+		}
+	}
+}
@@ -0,0 +1,198 @@
+//nolint:testpackage
+package cloudsmith
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// testArchiveFiles are the files in each of the test archives.
+var testArchiveFiles = map[string]string{
+	"hello.txt":      "Hello world",
+	"docs/readme.md": "Read me",
+}
+
+// testArchiveTarBzip2 is a .tar.bz2 archive of testArchiveFiles, as the
+// standard library can't write bzip2.
+const testArchiveTarBzip2 = "QlpoOTFBWSZTWSIQM4QAAJL/gMuAAIBAAe+AAEAQgG5GnsAYCCAAchKkeoBoANANNGQSKp+ppB6mnqaNDagyDTTtnvIY4YAVkkhFKZF5uCzDgTNghDAanlN58i0FaMEVrAjHjLF4OLyRGqms6WaQ2qDC9zmkEMN2+9ospG7cIRPsHXfPIeC4FejBSg/F3JFOFCQIhAzhAA=="
+
+// testArchiveTarXz is a .tar.xz archive of testArchiveFiles.
+const testArchiveTarXz = "/Td6WFoAAATm1rRGBMCSAYBQIQEcAAAAAAAAAL+oRuHgJ/8Ail0ANBlJ7o3wusj/m//yDGmvEetjVIkd9yu856OR6Mvb51Mfrt4I8DccJwRkkNNgS2MvfHk2BGSrUYLrZcmoibcg32HMoMGa1tqM3uZ4tlEQkJiMqv2jEUknz6IQB1xLMNacwgL1m0WDtBjPojNgd4WU1CDI+jXGzw4AjA0JlsZ7pONQfRN4z6iT9qYAAAAA2gQZemTZBPEAAa4BgFAAAHgVxlyxxGf7AgAAAAAEWVo="
+
+func writeTestBase64(t *testing.T, path, encoded string) {
+	t.Helper()
+
+	content, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, content, 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func writeTestZip(t *testing.T, path string, files map[string]string) {
+	t.Helper()
+
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for name, content := range files {
+		f, err := w.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := f.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func writeTestTarGzip(t *testing.T, path string, files map[string]string) {
+	t.Helper()
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	w := tar.NewWriter(gz)
+	for name, content := range files {
+		if err := w.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(content))}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestExtractArchive extracts an archive of each supported format and
+// verifies the extracted files and their content.
+func TestExtractArchive(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		filename string
+		write    func(t *testing.T, path string)
+	}{
+		{"package.zip", func(t *testing.T, path string) { writeTestZip(t, path, testArchiveFiles) }},
+		{"package.tar.gz", func(t *testing.T, path string) { writeTestTarGzip(t, path, testArchiveFiles) }},
+		{"package.TGZ", func(t *testing.T, path string) { writeTestTarGzip(t, path, testArchiveFiles) }},
+		{"package.tar.bz2", func(t *testing.T, path string) { writeTestBase64(t, path, testArchiveTarBzip2) }},
+		{"package.tar.xz", func(t *testing.T, path string) { writeTestBase64(t, path, testArchiveTarXz) }},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.filename, func(t *testing.T) {
+			t.Parallel()
+
+			archivePath := filepath.Join(t.TempDir(), tc.filename)
+			tc.write(t, archivePath)
+			extractDir := filepath.Join(t.TempDir(), "extracted")
+
+			files, err := extractArchive(archivePath, extractDir)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if expected := []string{"docs/readme.md", "hello.txt"}; !reflect.DeepEqual(files, expected) {
+				t.Errorf("expected files %v, got %v", expected, files)
+			}
+			for name, content := range testArchiveFiles {
+				if err := checkFileContent(filepath.Join(extractDir, filepath.FromSlash(name)), content); err != nil {
+					t.Error(err)
+				}
+			}
+		})
+	}
+}
+
+// TestExtractArchive_rootEntry verifies that an archive with an entry for its
+// root directory, as written by `tar -C dir -cf archive.tar .`, is extracted.
+func TestExtractArchive_rootEntry(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	w := tar.NewWriter(&buf)
+	if err := w.WriteHeader(&tar.Header{Name: "./", Typeflag: tar.TypeDir, Mode: 0o755}); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.WriteHeader(&tar.Header{Name: "./a.txt", Typeflag: tar.TypeReg, Mode: 0o644, Size: 1}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Write([]byte("a")); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	archivePath := filepath.Join(t.TempDir(), "package.tar")
+	if err := os.WriteFile(archivePath, buf.Bytes(), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	extractDir := filepath.Join(t.TempDir(), "extracted")
+
+	files, err := extractArchive(archivePath, extractDir)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if expected := []string{"a.txt"}; !reflect.DeepEqual(files, expected) {
+		t.Errorf("expected files %v, got %v", expected, files)
+	}
+	if err := checkFileContent(filepath.Join(extractDir, "a.txt"), "a"); err != nil {
+		t.Error(err)
+	}
+}
+
+// TestExtractArchive_outsideExtractDir verifies that an archive entry which
+// would be written outside the extract directory is rejected.
+func TestExtractArchive_outsideExtractDir(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	archivePath := filepath.Join(dir, "package.zip")
+	writeTestZip(t, archivePath, map[string]string{"../escaped.txt": "escaped"})
+
+	_, err := extractArchive(archivePath, filepath.Join(dir, "extracted"))
+	if err == nil || !strings.Contains(err.Error(), "outside the extract directory") {
+		t.Errorf("expected an error for an entry outside the extract directory, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "escaped.txt")); !os.IsNotExist(err) {
+		t.Errorf("expected the entry not to be extracted")
+	}
+}
+
+func TestExtractArchive_unsupported(t *testing.T) {
+	t.Parallel()
+
+	archivePath := filepath.Join(t.TempDir(), "package.rar")
+	if err := os.WriteFile(archivePath, []byte("content"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := extractArchive(archivePath, t.TempDir()); err == nil || !strings.Contains(err.Error(), "unsupported archive") {
+		t.Errorf("expected an unsupported archive error, got %v", err)
+	}
+}
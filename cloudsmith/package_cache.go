@@ -0,0 +1,92 @@
+package cloudsmith
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+)
+
+// sha256Regexp matches a hex encoded SHA256 checksum, which is used to name
+// entries in the package cache.
+var sha256Regexp = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+// packageCachePath returns the path of the cache entry for a package with the
+// given SHA256 checksum, or an empty string if the checksum isn't valid.
+func packageCachePath(cacheDir, sha256 string) string {
+	if !sha256Regexp.MatchString(sha256) {
+		return ""
+	}
+	return filepath.Join(cacheDir, sha256)
+}
+
+// restoreCachedPackage copies the cache entry for a package to outputPath,
+// returning false if there's no entry or it's older than ttl. A ttl of zero
+// means entries never expire.
+func restoreCachedPackage(cacheDir string, ttl time.Duration, sha256, outputPath string, now time.Time) (bool, error) {
+	cachePath := packageCachePath(cacheDir, sha256)
+	if cachePath == "" {
+		return false, nil
+	}
+
+	info, err := os.Stat(cachePath)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if ttl > 0 && now.Sub(info.ModTime()) > ttl {
+		return false, nil
+	}
+
+	if err := copyFileAtomic(cachePath, outputPath); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// storeCachedPackage copies a downloaded package into the cache, replacing any
+// existing entry so that its age is reset.
+func storeCachedPackage(cacheDir, sha256, packagePath string) error {
+	cachePath := packageCachePath(cacheDir, sha256)
+	if cachePath == "" {
+		return nil
+	}
+
+	if err := os.MkdirAll(cacheDir, 0o755); err != nil {
+		return err
+	}
+	return copyFileAtomic(packagePath, cachePath)
+}
+
+// copyFileAtomic copies src to dst through a temporary file alongside dst, so
+// that a partial copy is never left at dst.
+func copyFileAtomic(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	tempFile, err := os.CreateTemp(filepath.Dir(dst), "."+filepath.Base(dst)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		tempFile.Close()
+		os.Remove(tempFile.Name())
+	}()
+
+	if _, err := io.Copy(tempFile, in); err != nil {
+		return err
+	}
+	if err := tempFile.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tempFile.Name(), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tempFile.Name(), dst)
+}
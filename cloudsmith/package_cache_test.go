@@ -0,0 +1,53 @@
+//nolint:testpackage
+package cloudsmith
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestPackageCache(t *testing.T) {
+	t.Parallel()
+
+	const sha256 = "64ec88ca00b268e5ba1a35678a1b5316d212f4f366b2477232534a8aeca37f3c"
+
+	cacheDir := filepath.Join(t.TempDir(), "cache")
+	downloadDir := t.TempDir()
+
+	packagePath := filepath.Join(downloadDir, "hello.txt")
+	if err := os.WriteFile(packagePath, []byte("Hello world"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	outputPath := filepath.Join(downloadDir, "restored.txt")
+	if ok, err := restoreCachedPackage(cacheDir, time.Hour, sha256, outputPath, time.Now()); err != nil || ok {
+		t.Fatalf("expected a cache miss, got %t, %v", ok, err)
+	}
+
+	if err := storeCachedPackage(cacheDir, sha256, packagePath); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if ok, err := restoreCachedPackage(cacheDir, time.Hour, sha256, outputPath, time.Now()); err != nil || !ok {
+		t.Fatalf("expected a cache hit, got %t, %v", ok, err)
+	}
+	if err := checkFileContent(outputPath, "Hello world"); err != nil {
+		t.Error(err)
+	}
+
+	// entries older than the ttl are ignored, unless entries never expire.
+	later := time.Now().Add(2 * time.Hour)
+	if ok, err := restoreCachedPackage(cacheDir, time.Hour, sha256, outputPath, later); err != nil || ok {
+		t.Errorf("expected an expired cache entry to be a miss, got %t, %v", ok, err)
+	}
+	if ok, err := restoreCachedPackage(cacheDir, 0, sha256, outputPath, later); err != nil || !ok {
+		t.Errorf("expected a cache hit without a ttl, got %t, %v", ok, err)
+	}
+
+	// checksums which aren't valid are never used as paths.
+	if ok, err := restoreCachedPackage(cacheDir, 0, "../hello.txt", outputPath, time.Now()); err != nil || ok {
+		t.Errorf("expected an invalid checksum to be a miss, got %t, %v", ok, err)
+	}
+}
@@ -4,10 +4,13 @@ package cloudsmith
 import (
 	"context"
 	"fmt"
+	"os"
 	"runtime"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 // Provider returns a terraform.ResourceProvider.
@@ -15,9 +18,10 @@ func Provider() *schema.Provider {
 	p := &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"api_key": {
-				Type:        schema.TypeString,
-				Description: "The API key for authenticating with the Cloudsmith API.",
-				Required:    true,
+				Type: schema.TypeString,
+				Description: "The API key for authenticating with the Cloudsmith API. If not set, it's read " +
+					"from the CLOUDSMITH_API_KEY environment variable, or from ~/.cloudsmith/credentials.",
+				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("CLOUDSMITH_API_KEY", nil),
 				Sensitive:   true,
 			},
@@ -27,33 +31,145 @@ func Provider() *schema.Provider {
 				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("CLOUDSMITH_API_HOST", "https://api.cloudsmith.io/v1"),
 			},
+			"cache_dir": {
+				Type: schema.TypeString,
+				Description: "A directory in which downloaded packages are cached, so a package read by " +
+					"several cloudsmith_package data sources is only downloaded once.",
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"cache_ttl": {
+				Type: schema.TypeInt,
+				Description: "The time in seconds after which a cached package is downloaded again. If 0, " +
+					"cached packages don't expire.",
+				Optional:     true,
+				Default:      86400,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
 			"headers": {
 				Type:        schema.TypeMap,
 				Elem:        &schema.Schema{Type: schema.TypeString},
 				Description: "Additional HTTP headers to include in API requests",
 				Optional:    true,
 			},
+			"max_retries": {
+				Type:         schema.TypeInt,
+				Description:  "The maximum number of times a failed package download will be retried.",
+				Optional:     true,
+				Default:      3,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"retry_wait_min": {
+				Type:         schema.TypeInt,
+				Description:  "The minimum time in seconds to wait between package download retries.",
+				Optional:     true,
+				Default:      1,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"retry_wait_max": {
+				Type:         schema.TypeInt,
+				Description:  "The maximum time in seconds to wait between package download retries.",
+				Optional:     true,
+				Default:      30,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"proxy_url": {
+				Type:         schema.TypeString,
+				Description:  "The URL of a proxy to use when downloading packages. API requests are not proxied.",
+				Optional:     true,
+				ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+			},
+			"rate_limit_calls": {
+				Type: schema.TypeInt,
+				Description: "The maximum number of API requests to make every rate_limit_period. Requests " +
+					"beyond the limit are delayed rather than rejected. If 0, requests are not limited.",
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"rate_limit_period": {
+				Type:         schema.TypeInt,
+				Description:  "The period in seconds over which rate_limit_calls applies.",
+				Optional:     true,
+				Default:      1,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"request_timeout": {
+				Type: schema.TypeInt,
+				Description: "The time in seconds to wait for a connection to the Cloudsmith API or CDN, and for it to " +
+					"start responding. Doesn't limit how long package uploads and downloads take once under way.",
+				Optional:     true,
+				Default:      120,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"show_download_progress": {
+				Type:        schema.TypeBool,
+				Description: "If true, package download progress is logged at DEBUG level.",
+				Optional:    true,
+				Default:     false,
+			},
+			"tls_ca_cert_file": {
+				Type:         schema.TypeString,
+				Description:  "Path to a PEM encoded CA certificate to trust, in addition to the system roots, when connecting to the API.",
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("CLOUDSMITH_TLS_CA_CERT_FILE", nil),
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"tls_client_cert_file": {
+				Type:         schema.TypeString,
+				Description:  "Path to a PEM encoded client certificate to present when connecting to the API with mutual TLS.",
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("CLOUDSMITH_TLS_CLIENT_CERT_FILE", nil),
+				ValidateFunc: validation.StringIsNotEmpty,
+				RequiredWith: []string{"tls_client_key_file"},
+			},
+			"tls_client_key_file": {
+				Type:         schema.TypeString,
+				Description:  "Path to the PEM encoded private key of the client certificate.",
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("CLOUDSMITH_TLS_CLIENT_KEY_FILE", nil),
+				ValidateFunc: validation.StringIsNotEmpty,
+				RequiredWith: []string{"tls_client_cert_file"},
+			},
 		},
 		DataSourcesMap: map[string]*schema.Resource{
-			"cloudsmith_namespace":             dataSourceNamespace(),
-			"cloudsmith_oidc":                  dataSourceOidc(),
-			"cloudsmith_organization":          dataSourceOrganization(),
-			"cloudsmith_package":               dataSourcePackage(),
-			"cloudsmith_package_list":          dataSourcePackageList(),
-			"cloudsmith_repository":            dataSourceRepository(),
-			"cloudsmith_repository_privileges": dataSourceRepositoryPrivileges(),
-			"cloudsmith_package_deny_policy":   dataSourcePackageDenyPolicy(),
-			"cloudsmith_entitlement_list":      dataSourceEntitlementList(),
-			"cloudsmith_list_org_members":      dataSourceOrganizationMembersList(),
-			"cloudsmith_org_member_details":    dataSourceMemberDetails(),
-			"cloudsmith_user_self":             dataSourceUserSelf(),
-			"cloudsmith_team_list":             dataSourceTeamList(),
-			"cloudsmith_team_members":          dataSourceTeamMembers(),
-			"cloudsmith_service_list":          dataSourceServiceList(),
-			"cloudsmith_service_details":       dataSourceServiceDetails(),
+			"cloudsmith_namespace":                  dataSourceNamespace(),
+			"cloudsmith_namespace_list":             dataSourceNamespaceList(),
+			"cloudsmith_oidc":                       dataSourceOidc(),
+			"cloudsmith_oidc_token":                 dataSourceOidcToken(),
+			"cloudsmith_organization":               dataSourceOrganization(),
+			"cloudsmith_package":                    dataSourcePackage(),
+			"cloudsmith_package_list":               dataSourcePackageList(),
+			"cloudsmith_package_tag_search":         dataSourcePackageTagSearch(),
+			"cloudsmith_package_dependencies":       dataSourcePackageDependencies(),
+			"cloudsmith_package_statistics":         dataSourcePackageStatistics(),
+			"cloudsmith_repository":                 dataSourceRepository(),
+			"cloudsmith_repository_list":            dataSourceRepositoryList(),
+			"cloudsmith_repository_upstream":        dataSourceRepositoryUpstream(),
+			"cloudsmith_repository_privileges":      dataSourceRepositoryPrivileges(),
+			"cloudsmith_repository_privilege_grant": dataSourceRepositoryPrivilegeGrant(),
+			"cloudsmith_repository_privilege_teams": dataSourceRepositoryPrivilegeTeams(),
+			"cloudsmith_repository_badge":           dataSourceRepositoryBadge(),
+			"cloudsmith_repository_statistics":      dataSourceRepositoryStatistics(),
+			"cloudsmith_package_deny_policy":        dataSourcePackageDenyPolicy(),
+			"cloudsmith_entitlement":                dataSourceEntitlement(),
+			"cloudsmith_entitlement_list":           dataSourceEntitlementList(),
+			"cloudsmith_list_org_members":           dataSourceOrganizationMembersList(),
+			"cloudsmith_org_member_details":         dataSourceMemberDetails(),
+			"cloudsmith_user_self":                  dataSourceUserSelf(),
+			"cloudsmith_team":                       dataSourceTeam(),
+			"cloudsmith_team_list":                  dataSourceTeamList(),
+			"cloudsmith_team_members":               dataSourceTeamMembers(),
+			"cloudsmith_service_list":               dataSourceServiceList(),
+			"cloudsmith_service_details":            dataSourceServiceDetails(),
+			"cloudsmith_service_account":            dataSourceServiceAccount(),
+			"cloudsmith_storage_limit":              dataSourceStorageLimit(),
+			"cloudsmith_organization_quota":         dataSourceOrganizationQuota(),
+			"cloudsmith_webhook":                    dataSourceWebhook(),
 		},
 		ResourcesMap: map[string]*schema.Resource{
 			"cloudsmith_entitlement":               resourceEntitlement(),
+			"cloudsmith_entitlement_limit_policy":  resourceEntitlementLimitPolicy(),
 			"cloudsmith_license_policy":            resourceLicensePolicy(),
 			"cloudsmith_repository":                resourceRepository(),
 			"cloudsmith_repository_geo_ip_rules":   resourceRepositoryGeoIpRules(),
@@ -70,6 +186,29 @@ func Provider() *schema.Provider {
 			"cloudsmith_saml_auth":                 resourceSAMLAuth(),
 			"cloudsmith_repository_retention_rule": resourceRepoRetentionRule(),
 			"cloudsmith_entitlement_control":       resourceEntitlementControl(),
+			"cloudsmith_entitlement_refresh":       resourceEntitlementRefresh(),
+			"cloudsmith_package_upload":            resourcePackageUpload(),
+			"cloudsmith_token":                     resourceToken(),
+			"cloudsmith_repository_privilege":      resourceRepositoryPrivilege(),
+			"cloudsmith_organization_member":       resourceOrganizationMember(),
+			"cloudsmith_package_copy":              resourcePackageCopy(),
+			"cloudsmith_package_move":              resourcePackageMove(),
+			"cloudsmith_package_promote":           resourcePackagePromote(),
+			"cloudsmith_package_quarantine":        resourcePackageQuarantine(),
+			"cloudsmith_package_resync":            resourcePackageResync(),
+			"cloudsmith_package_tag":               resourcePackageTag(),
+			"cloudsmith_package_metadata":          resourcePackageMetadata(),
+			"cloudsmith_deb_package":               resourcePackageDeb(),
+			"cloudsmith_rpm_package":               resourcePackageRpm(),
+			"cloudsmith_python_package":            resourcePackagePython(),
+			"cloudsmith_raw_package":               resourcePackageRaw(),
+			"cloudsmith_docker_package":            resourcePackageDocker(),
+			"cloudsmith_npm_package":               resourcePackageNpm(),
+			"cloudsmith_nuget_package":             resourcePackageNuget(),
+			"cloudsmith_helm_package":              resourcePackageHelm(),
+			"cloudsmith_maven_package":             resourcePackageMaven(),
+			"cloudsmith_cargo_package":             resourcePackageCargo(),
+			"cloudsmith_go_module":                 resourcePackageGo(),
 		},
 	}
 
@@ -83,10 +222,60 @@ func Provider() *schema.Provider {
 
 		apiHost := requiredString(d, "api_host")
 		apiKey := requiredString(d, "api_key")
+		if apiKey == "" {
+			// fall back to the credentials file shared with the Cloudsmith CLI,
+			// using the profile named by CLOUDSMITH_PROFILE if set.
+			profile := os.Getenv("CLOUDSMITH_PROFILE")
+			if profile == "" {
+				profile = defaultCredentialsProfile
+			}
+
+			var err error
+			if apiKey, err = credentialsFileAPIKey(defaultCredentialsFile(), profile); err != nil {
+				return nil, diag.FromErr(err)
+			}
+		}
 		userAgent := fmt.Sprintf("(%s %s) Terraform/%s", runtime.GOOS, runtime.GOARCH, terraformVersion)
 		headers := d.Get("headers").(map[string]interface{})
+		requestTimeout := time.Duration(d.Get("request_timeout").(int)) * time.Second
+
+		tlsConfig, err := newTLSConfig(
+			requiredString(d, "tls_client_cert_file"),
+			requiredString(d, "tls_client_key_file"),
+			requiredString(d, "tls_ca_cert_file"),
+		)
+		if err != nil {
+			return nil, diag.Diagnostics{{
+				Severity: diag.Error,
+				Summary:  "Invalid TLS configuration",
+				Detail:   err.Error(),
+			}}
+		}
+
+		limiter := newRateLimiter(
+			d.Get("rate_limit_calls").(int),
+			time.Duration(d.Get("rate_limit_period").(int))*time.Second,
+		)
+
+		pc, diags := newProviderConfig(apiHost, apiKey, headers, userAgent, requestTimeout, tlsConfig, limiter)
+		if diags.HasError() {
+			return nil, diags
+		}
+
+		pc.MaxRetries = d.Get("max_retries").(int)
+		pc.RetryWaitMin = time.Duration(d.Get("retry_wait_min").(int)) * time.Second
+		pc.RetryWaitMax = time.Duration(d.Get("retry_wait_max").(int)) * time.Second
+		pc.ShowDownloadProgress = d.Get("show_download_progress").(bool)
+		pc.CacheDir = requiredString(d, "cache_dir")
+		pc.CacheTTL = time.Duration(d.Get("cache_ttl").(int)) * time.Second
+
+		if proxyURL := requiredString(d, "proxy_url"); proxyURL != "" {
+			if err := pc.SetDownloadProxy(proxyURL); err != nil {
+				return nil, diag.FromErr(err)
+			}
+		}
 
-		return newProviderConfig(apiHost, apiKey, headers, userAgent)
+		return pc, diags
 	}
 
 	return p
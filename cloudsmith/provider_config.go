@@ -2,13 +2,20 @@ package cloudsmith
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
+	"net/url"
+	"os"
+	"time"
 
 	"github.com/cloudsmith-io/cloudsmith-api-go"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/logging"
+	"golang.org/x/time/rate"
 )
 
 var errMissingCredentials = errors.New("credentials required for Cloudsmith provider")
@@ -19,18 +26,115 @@ type providerConfig struct {
 
 	// initialised Cloudsmith API client
 	APIClient *cloudsmith.APIClient
+
+	// retry behaviour for package downloads
+	MaxRetries   int
+	RetryWaitMin time.Duration
+	RetryWaitMax time.Duration
+
+	// RequestTimeout bounds how long to wait for a connection to be made and
+	// for the response headers, but not how long a response body takes, so
+	// that large package uploads and downloads aren't cut short
+	RequestTimeout time.Duration
+
+	// ShowDownloadProgress enables progress logging for package downloads
+	ShowDownloadProgress bool
+
+	// CacheDir, if set, is where downloaded packages are cached by checksum
+	// so they're only downloaded once, until CacheTTL has passed
+	CacheDir string
+	CacheTTL time.Duration
+
+	// downloadClient, if set, is used for package downloads in place of the
+	// API client's HTTP client, e.g. to route them through a proxy
+	downloadClient *http.Client
+
+	// headers, tlsConfig and limiter configure the transport of the API
+	// client, and are reused for any separate download client
+	headers   map[string]interface{}
+	tlsConfig *tls.Config
+	limiter   *rate.Limiter
 }
 
-func newProviderConfig(apiHost string, apiKey string, headers map[string]interface{}, userAgent string) (*providerConfig, diag.Diagnostics) {
-	if apiKey == "" {
-		return nil, diag.FromErr(errMissingCredentials)
+// newTLSConfig builds the TLS configuration used to connect to the API from
+// the given PEM files, returning nil if none are set. A client certificate
+// and key enable mutual TLS, while a CA certificate is trusted in addition to
+// the system roots.
+func newTLSConfig(certFile, keyFile, caFile string) (*tls.Config, error) {
+	if certFile == "" && keyFile == "" && caFile == "" {
+		return nil, nil
+	}
+
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if certFile != "" || keyFile != "" {
+		if certFile == "" || keyFile == "" {
+			return nil, errors.New("tls_client_cert_file and tls_client_key_file must be set together")
+		}
+
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("error loading TLS client certificate %s and key %s: %w", certFile, keyFile, err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	if caFile != "" {
+		pem, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("error reading TLS CA certificate %s: %w", caFile, err)
+		}
+
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("error parsing TLS CA certificate %s: no PEM encoded certificates found", caFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	return tlsConfig, nil
+}
+
+// newHTTPTransport returns the transport used to make requests, which adds the
+// configured headers to every request and applies rate limiting, on top of a
+// base transport using tlsConfig and proxy when they are set. requestTimeout
+// bounds connecting and waiting for response headers, rather than the whole
+// request, as reading the body of a large package can take much longer.
+func newHTTPTransport(headers map[string]interface{}, tlsConfig *tls.Config, limiter *rate.Limiter, requestTimeout time.Duration, proxy func(*http.Request) (*url.URL, error)) http.RoundTripper {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if requestTimeout > 0 {
+		dialer := &net.Dialer{Timeout: requestTimeout, KeepAlive: 30 * time.Second}
+		transport.DialContext = dialer.DialContext
+		transport.TLSHandshakeTimeout = requestTimeout
+		transport.ResponseHeaderTimeout = requestTimeout
+	}
+	if tlsConfig != nil {
+		transport.TLSClientConfig = tlsConfig
+	}
+	if proxy != nil {
+		transport.Proxy = proxy
 	}
 
-	httpClient := http.DefaultClient
-	httpClient.Transport = logging.NewSubsystemLoggingHTTPTransport("Cloudsmith", &headerTransport{
+	return logging.NewSubsystemLoggingHTTPTransport("Cloudsmith", &headerTransport{
 		headers: headers,
-		rt:      http.DefaultTransport,
+		rt: &rateLimitTransport{
+			limiter: limiter,
+			rt:      transport,
+		},
 	})
+}
+
+func newProviderConfig(apiHost string, apiKey string, headers map[string]interface{}, userAgent string, requestTimeout time.Duration, tlsConfig *tls.Config, limiter *rate.Limiter) (*providerConfig, diag.Diagnostics) {
+	if apiKey == "" {
+		return nil, diag.FromErr(errMissingCredentials)
+	}
+
+	httpClient := &http.Client{
+		Transport: newHTTPTransport(headers, tlsConfig, limiter, requestTimeout, nil),
+	}
 
 	config := cloudsmith.NewConfiguration()
 	config.Debug = logging.IsDebugOrHigher()
@@ -56,7 +160,37 @@ func newProviderConfig(apiHost string, apiKey string, headers map[string]interfa
 		return nil, diag.FromErr(errors.New("invalid API credentials"))
 	}
 
-	return &providerConfig{Auth: auth, APIClient: apiClient}, nil
+	return &providerConfig{
+		Auth:           auth,
+		APIClient:      apiClient,
+		RequestTimeout: requestTimeout,
+		headers:        headers,
+		tlsConfig:      tlsConfig,
+		limiter:        limiter,
+	}, nil
+}
+
+// SetDownloadProxy routes package downloads through the proxy at proxyURL,
+// leaving requests made by the API client unchanged. Downloads still use the
+// same headers, TLS configuration and rate limiting as the API client.
+func (pc *providerConfig) SetDownloadProxy(proxyURL string) error {
+	parsed, err := url.Parse(proxyURL)
+	if err != nil {
+		return fmt.Errorf("invalid proxy URL %q: %w", proxyURL, err)
+	}
+
+	pc.downloadClient = &http.Client{
+		Transport: newHTTPTransport(pc.headers, pc.tlsConfig, pc.limiter, pc.RequestTimeout, http.ProxyURL(parsed)),
+	}
+	return nil
+}
+
+// DownloadClient returns the HTTP client to use for package downloads.
+func (pc *providerConfig) DownloadClient() *http.Client {
+	if pc.downloadClient != nil {
+		return pc.downloadClient
+	}
+	return pc.APIClient.GetConfig().HTTPClient
 }
 
 func (pc *providerConfig) GetAPIKey() string {
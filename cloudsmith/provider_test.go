@@ -2,13 +2,25 @@
 package cloudsmith
 
 import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
 	"fmt"
+	"math/big"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"regexp"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/cloudsmith-io/cloudsmith-api-go"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -40,6 +52,25 @@ func testAccPreCheck(t *testing.T) {
 		t.Fatal("CLOUDSMITH_NAMESPACE must be set for acceptance tests")
 	}
 }
+
+// testProviderConfig returns a provider configuration which sends API
+// requests to the test server at serverURL.
+func testProviderConfig(serverURL string) *providerConfig {
+	config := cloudsmith.NewConfiguration()
+	config.HTTPClient = &http.Client{}
+	config.Servers = cloudsmith.ServerConfigurations{{URL: serverURL}}
+
+	auth := context.WithValue(
+		context.Background(),
+		cloudsmith.ContextAPIKeys,
+		map[string]cloudsmith.APIKey{
+			"apikey": {Key: "test-api-key"},
+		},
+	)
+
+	return &providerConfig{Auth: auth, APIClient: cloudsmith.NewAPIClient(config)}
+}
+
 func TestAccProvider_UserSelfValidation(t *testing.T) {
 	// Create mock server
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -96,3 +127,91 @@ func TestAccProvider_UserSelfValidation(t *testing.T) {
 var selfConfig string = `
 data "cloudsmith_user_self" "this" {
 }`
+
+// writeTestCertificate writes a self-signed certificate and its private key
+// to dir as PEM files, returning their paths.
+func writeTestCertificate(t *testing.T, dir string) (string, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "terraform-provider-cloudsmith"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		IsCA:         true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certFile := filepath.Join(dir, "cert.pem")
+	keyFile := filepath.Join(dir, "key.pem")
+	if err := os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	return certFile, keyFile
+}
+
+func TestNewTLSConfig(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := writeTestCertificate(t, dir)
+
+	invalidFile := filepath.Join(dir, "invalid.pem")
+	if err := os.WriteFile(invalidFile, []byte("not a certificate"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tlsConfig, err := newTLSConfig("", "", "")
+	if err != nil || tlsConfig != nil {
+		t.Fatalf("expected no TLS config when no files are set, got %v, %v", tlsConfig, err)
+	}
+
+	tlsConfig, err = newTLSConfig(certFile, keyFile, certFile)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(tlsConfig.Certificates) != 1 {
+		t.Errorf("expected 1 client certificate, got %d", len(tlsConfig.Certificates))
+	}
+	if tlsConfig.RootCAs == nil {
+		t.Error("expected CA certificate to be added to the root CAs")
+	}
+
+	tests := []struct {
+		name     string
+		certFile string
+		keyFile  string
+		caFile   string
+		expected string
+	}{
+		{"MissingKey", certFile, "", "", "must be set together"},
+		{"InvalidKey", certFile, invalidFile, "", "error loading TLS client certificate"},
+		{"NonexistentCert", filepath.Join(dir, "missing.pem"), keyFile, "", "error loading TLS client certificate"},
+		{"InvalidCA", "", "", invalidFile, "no PEM encoded certificates found"},
+		{"NonexistentCA", "", "", filepath.Join(dir, "missing.pem"), "error reading TLS CA certificate"},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := newTLSConfig(tc.certFile, tc.keyFile, tc.caFile)
+			if err == nil || !strings.Contains(err.Error(), tc.expected) {
+				t.Errorf("expected error containing %q, got %v", tc.expected, err)
+			}
+		})
+	}
+}
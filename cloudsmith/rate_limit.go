@@ -0,0 +1,89 @@
+package cloudsmith
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// maxRateLimitRetries is the number of times a request rejected by the API
+// with 429 Too Many Requests is retried.
+const maxRateLimitRetries = 3
+
+// defaultRetryAfter is how long to wait before retrying a rejected request
+// when the API doesn't say.
+const defaultRetryAfter = 5 * time.Second
+
+// rateLimitTransport delays API requests to stay within a limiter, if one is
+// set, and retries requests rejected with 429 Too Many Requests once the time
+// given by the Retry-After header has passed.
+type rateLimitTransport struct {
+	limiter *rate.Limiter
+	rt      http.RoundTripper
+}
+
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		if t.limiter != nil {
+			if err := t.limiter.Wait(req.Context()); err != nil {
+				return nil, err
+			}
+		}
+
+		resp, err := t.rt.RoundTrip(req)
+		if err != nil || resp.StatusCode != http.StatusTooManyRequests || attempt >= maxRateLimitRetries {
+			return resp, err
+		}
+
+		// a request body can only be sent again if it can be recreated. The
+		// logging transport replaces a missing body with http.NoBody, which
+		// needs no recreating.
+		if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+			return resp, nil
+		}
+
+		wait := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+		resp.Body.Close()
+
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(wait):
+		}
+
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+	}
+}
+
+// parseRetryAfter returns how long to wait as given by a Retry-After header,
+// which is either a number of seconds or an HTTP date.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		if wait := date.Sub(now); wait > 0 {
+			return wait
+		}
+		return 0
+	}
+	return defaultRetryAfter
+}
+
+// newRateLimiter returns a limiter allowing calls requests per period, or nil
+// if calls is zero.
+func newRateLimiter(calls int, period time.Duration) *rate.Limiter {
+	if calls <= 0 {
+		return nil
+	}
+	return rate.NewLimiter(rate.Every(period/time.Duration(calls)), calls)
+}
@@ -0,0 +1,136 @@
+//nolint:testpackage
+package cloudsmith
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2023, 1, 2, 15, 4, 5, 0, time.UTC)
+
+	for _, tc := range []struct {
+		value    string
+		expected time.Duration
+	}{
+		{"3", 3 * time.Second},
+		{"0", 0},
+		{now.Add(10 * time.Second).Format(http.TimeFormat), 10 * time.Second},
+		{now.Add(-time.Minute).Format(http.TimeFormat), 0},
+		{"", defaultRetryAfter},
+		{"soon", defaultRetryAfter},
+	} {
+		if got := parseRetryAfter(tc.value, now); got != tc.expected {
+			t.Errorf("parseRetryAfter(%q): expected %s, got %s", tc.value, tc.expected, got)
+		}
+	}
+}
+
+// TestRateLimitTransport_retryAfter verifies that a request rejected with 429
+// Too Many Requests is sent again, with its body, until it succeeds.
+func TestRateLimitTransport_retryAfter(t *testing.T) {
+	t.Parallel()
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != "payload" {
+			t.Errorf("request %d: expected body %q, got %q", requests, "payload", body)
+		}
+
+		if requests < 3 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: &rateLimitTransport{rt: http.DefaultTransport}}
+
+	resp, err := client.Post(server.URL, "text/plain", strings.NewReader("payload"))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected status %d, got %d", http.StatusOK, resp.StatusCode)
+	}
+	if requests != 3 {
+		t.Errorf("expected 3 requests, got %d", requests)
+	}
+}
+
+// TestRateLimitTransport_maxRetries verifies that the response is returned
+// once a request has been rejected more than maxRateLimitRetries times.
+func TestRateLimitTransport_maxRetries(t *testing.T) {
+	t.Parallel()
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Retry-After", "0")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: &rateLimitTransport{rt: http.DefaultTransport}}
+
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("expected status %d, got %d", http.StatusTooManyRequests, resp.StatusCode)
+	}
+	if requests != maxRateLimitRetries+1 {
+		t.Errorf("expected %d requests, got %d", maxRateLimitRetries+1, requests)
+	}
+}
+
+// TestRateLimitTransport_limiter verifies that requests beyond the limit are
+// delayed rather than rejected.
+func TestRateLimitTransport_limiter(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	const calls, period = 2, 200 * time.Millisecond
+	client := &http.Client{Transport: &rateLimitTransport{
+		limiter: newRateLimiter(calls, period),
+		rt:      http.DefaultTransport,
+	}}
+
+	start := time.Now()
+	for i := 0; i < calls+1; i++ {
+		resp, err := client.Get(server.URL)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		resp.Body.Close()
+	}
+
+	// the first calls requests are allowed immediately, after which one
+	// request is allowed every period/calls.
+	if elapsed := time.Since(start); elapsed < period/calls {
+		t.Errorf("expected requests beyond the limit to be delayed, took %s", elapsed)
+	}
+	if newRateLimiter(0, period) != nil {
+		t.Error("expected no limiter when calls is 0")
+	}
+}
@@ -11,6 +11,18 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
+var bandwidthUnits = []string{
+	"Byte",
+	"Kilobyte",
+	"Megabyte",
+	"Gigabyte",
+	"Terabyte",
+	"Petabyte",
+	"Exabyte",
+	"Zettabyte",
+	"Yottabyte",
+}
+
 func importEntitlement(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
 	idParts := strings.Split(d.Id(), ".")
 	if len(idParts) != 3 {
@@ -35,12 +47,14 @@ func resourceEntitlementCreate(d *schema.ResourceData, m interface{}) error {
 	req = req.Data(cloudsmith.RepositoryTokenRequest{
 		AccessPrivateBroadcasts: optionalBool(d, "access_private_broadcasts"),
 		IsActive:                optionalBool(d, "is_active"),
+		LimitBandwidth:          nullableInt64(d, "limit_bandwidth"),
+		LimitBandwidthUnit:      nullableString(d, "limit_bandwidth_unit"),
 		LimitDateRangeFrom:      nullableTime(d, "limit_date_range_from"),
 		LimitDateRangeTo:        nullableTime(d, "limit_date_range_to"),
 		LimitNumClients:         nullableInt64(d, "limit_num_clients"),
 		LimitNumDownloads:       nullableInt64(d, "limit_num_downloads"),
 		LimitPackageQuery:       nullableString(d, "limit_package_query"),
-		LimitPathQuery:          nullableString(d, "limitPathQuery"),
+		LimitPathQuery:          nullableString(d, "limit_path_query"),
 		Name:                    requiredString(d, "name"),
 		Token:                   optionalString(d, "token"),
 	})
@@ -91,6 +105,8 @@ func resourceEntitlementRead(d *schema.ResourceData, m interface{}) error {
 
 	d.Set("access_private_broadcasts", entitlement.GetAccessPrivateBroadcasts())
 	d.Set("is_active", entitlement.GetIsActive())
+	d.Set("limit_bandwidth", entitlement.GetLimitBandwidth())
+	d.Set("limit_bandwidth_unit", entitlement.GetLimitBandwidthUnit())
 	d.Set("limit_date_range_from", timeToString(entitlement.GetLimitDateRangeFrom()))
 	d.Set("limit_date_range_to", timeToString(entitlement.GetLimitDateRangeTo()))
 	d.Set("limit_num_clients", entitlement.GetLimitNumClients())
@@ -120,6 +136,8 @@ func resourceEntitlementUpdate(d *schema.ResourceData, m interface{}) error {
 	req = req.Data(cloudsmith.RepositoryTokenRequestPatch{
 		AccessPrivateBroadcasts: optionalBool(d, "access_private_broadcasts"),
 		IsActive:                optionalBool(d, "is_active"),
+		LimitBandwidth:          nullableInt64(d, "limit_bandwidth"),
+		LimitBandwidthUnit:      nullableString(d, "limit_bandwidth_unit"),
 		LimitDateRangeFrom:      nullableTime(d, "limit_date_range_from"),
 		LimitDateRangeTo:        nullableTime(d, "limit_date_range_to"),
 		LimitNumClients:         nullableInt64(d, "limit_num_clients"),
@@ -205,6 +223,22 @@ func resourceEntitlement() *schema.Resource {
 				Optional:    true,
 				Computed:    true,
 			},
+			"limit_bandwidth": {
+				Type: schema.TypeInt,
+				Description: "The maximum download bandwidth allowed for the token. Values are expressed " +
+					"as the selected unit of bandwidth. Please note that since downloads are calculated " +
+					"asynchronously (after the download happens), the limit may not be imposed immediately " +
+					"but at a later point.",
+				Optional: true,
+				Computed: true,
+			},
+			"limit_bandwidth_unit": {
+				Type:         schema.TypeString,
+				Description:  "Unit of bandwidth for the maximum download bandwidth.",
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice(bandwidthUnits, false),
+			},
 			"limit_date_range_from": {
 				Type:         schema.TypeString,
 				Description:  "The starting date/time the token is allowed to be used from.",
@@ -0,0 +1,208 @@
+package cloudsmith
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/cloudsmith-io/cloudsmith-api-go"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func importEntitlementLimitPolicy(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	idParts := strings.Split(d.Id(), ".")
+	if len(idParts) != 3 {
+		return nil, fmt.Errorf(
+			"invalid import ID, must be of the form <organization_slug>.<repository_slug>.<entitlement_slug_perm>, got: %s", d.Id(),
+		)
+	}
+
+	d.Set("namespace", idParts[0])
+	d.Set("repository", idParts[1])
+	d.Set("entitlement_slug_perm", idParts[2])
+	return []*schema.ResourceData{d}, nil
+}
+
+// updateEntitlementLimits sets the limits of an entitlement. Limits which are
+// not set in the given request are removed.
+func updateEntitlementLimits(pc *providerConfig, namespace, repository, identifier string, limits cloudsmith.RepositoryTokenRequestPatch) (*http.Response, error) {
+	req := pc.APIClient.EntitlementsApi.EntitlementsPartialUpdate(pc.Auth, namespace, repository, identifier)
+	req = req.Data(limits)
+	_, resp, err := pc.APIClient.EntitlementsApi.EntitlementsPartialUpdateExecute(req)
+	if err != nil {
+		return resp, fmt.Errorf("error updating entitlement (%s) limits: %w", identifier, err)
+	}
+	return resp, nil
+}
+
+func resourceEntitlementLimitPolicyCreateUpdate(d *schema.ResourceData, m interface{}) error {
+	pc := m.(*providerConfig)
+
+	namespace := requiredString(d, "namespace")
+	repository := requiredString(d, "repository")
+	identifier := requiredString(d, "entitlement_slug_perm")
+
+	_, err := updateEntitlementLimits(pc, namespace, repository, identifier, cloudsmith.RepositoryTokenRequestPatch{
+		LimitBandwidth:     nullableInt64(d, "limit_bandwidth"),
+		LimitBandwidthUnit: nullableString(d, "limit_bandwidth_unit"),
+		LimitDateRangeFrom: nullableTime(d, "limit_date_range_from"),
+		LimitDateRangeTo:   nullableTime(d, "limit_date_range_to"),
+		LimitNumClients:    nullableInt64(d, "limit_num_clients"),
+		LimitNumDownloads:  nullableInt64(d, "limit_num_downloads"),
+		LimitPackageQuery:  nullableString(d, "limit_package_query"),
+	})
+	if err != nil {
+		return err
+	}
+
+	d.SetId(fmt.Sprintf("%s.%s.%s", namespace, repository, identifier))
+
+	return resourceEntitlementLimitPolicyRead(d, m)
+}
+
+func resourceEntitlementLimitPolicyRead(d *schema.ResourceData, m interface{}) error {
+	pc := m.(*providerConfig)
+
+	namespace := requiredString(d, "namespace")
+	repository := requiredString(d, "repository")
+	identifier := requiredString(d, "entitlement_slug_perm")
+
+	req := pc.APIClient.EntitlementsApi.EntitlementsRead(pc.Auth, namespace, repository, identifier)
+	entitlement, resp, err := pc.APIClient.EntitlementsApi.EntitlementsReadExecute(req)
+	if err != nil {
+		if is404(resp) {
+			d.SetId("")
+			return nil
+		}
+
+		return err
+	}
+
+	d.Set("limit_bandwidth", entitlement.GetLimitBandwidth())
+	d.Set("limit_bandwidth_unit", entitlement.GetLimitBandwidthUnit())
+	d.Set("limit_date_range_from", timeToString(entitlement.GetLimitDateRangeFrom()))
+	d.Set("limit_date_range_to", timeToString(entitlement.GetLimitDateRangeTo()))
+	d.Set("limit_num_clients", entitlement.GetLimitNumClients())
+	d.Set("limit_num_downloads", entitlement.GetLimitNumDownloads())
+	d.Set("limit_package_query", entitlement.GetLimitPackageQuery())
+
+	// namespace, repository and entitlement_slug_perm are not returned from
+	// the entitlement read endpoint, so we can use the values stored in
+	// resource state. We rely on ForceNew to ensure if any changes a new
+	// resource is created.
+	d.Set("namespace", namespace)
+	d.Set("repository", repository)
+	d.Set("entitlement_slug_perm", identifier)
+
+	return nil
+}
+
+// resourceEntitlementLimitPolicyDelete removes every limit from the
+// entitlement, leaving the entitlement itself in place.
+func resourceEntitlementLimitPolicyDelete(d *schema.ResourceData, m interface{}) error {
+	pc := m.(*providerConfig)
+
+	namespace := requiredString(d, "namespace")
+	repository := requiredString(d, "repository")
+	identifier := requiredString(d, "entitlement_slug_perm")
+
+	resp, err := updateEntitlementLimits(pc, namespace, repository, identifier, cloudsmith.RepositoryTokenRequestPatch{
+		LimitBandwidth:     *cloudsmith.NewNullableInt64(nil),
+		LimitBandwidthUnit: *cloudsmith.NewNullableString(nil),
+		LimitDateRangeFrom: *cloudsmith.NewNullableTime(nil),
+		LimitDateRangeTo:   *cloudsmith.NewNullableTime(nil),
+		LimitNumClients:    *cloudsmith.NewNullableInt64(nil),
+		LimitNumDownloads:  *cloudsmith.NewNullableInt64(nil),
+		LimitPackageQuery:  *cloudsmith.NewNullableString(nil),
+	})
+	if err != nil && !is404(resp) {
+		return err
+	}
+
+	return nil
+}
+
+//nolint:funlen
+func resourceEntitlementLimitPolicy() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceEntitlementLimitPolicyCreateUpdate,
+		Read:   resourceEntitlementLimitPolicyRead,
+		Update: resourceEntitlementLimitPolicyCreateUpdate,
+		Delete: resourceEntitlementLimitPolicyDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: importEntitlementLimitPolicy,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"entitlement_slug_perm": {
+				Type:         schema.TypeString,
+				Description:  "The slug_perm of the entitlement to limit.",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"limit_bandwidth": {
+				Type: schema.TypeInt,
+				Description: "The maximum download bandwidth allowed for the token, expressed in " +
+					"limit_bandwidth_unit.",
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+				RequiredWith: []string{"limit_bandwidth_unit"},
+			},
+			"limit_bandwidth_unit": {
+				Type:         schema.TypeString,
+				Description:  "Unit of bandwidth for the maximum download bandwidth.",
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice(bandwidthUnits, false),
+				RequiredWith: []string{"limit_bandwidth"},
+			},
+			"limit_date_range_from": {
+				Type:         schema.TypeString,
+				Description:  "The starting date/time the token is allowed to be used from.",
+				Optional:     true,
+				ValidateFunc: validation.IsRFC3339Time,
+			},
+			"limit_date_range_to": {
+				Type:         schema.TypeString,
+				Description:  "The ending date/time the token is allowed to be used until.",
+				Optional:     true,
+				ValidateFunc: validation.IsRFC3339Time,
+			},
+			"limit_num_clients": {
+				Type:         schema.TypeInt,
+				Description:  "The maximum number of unique clients allowed for the token.",
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"limit_num_downloads": {
+				Type:         schema.TypeInt,
+				Description:  "The maximum number of downloads allowed for the token.",
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"limit_package_query": {
+				Type:         schema.TypeString,
+				Description:  "The package-based search query to apply to restrict downloads to.",
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"namespace": {
+				Type:         schema.TypeString,
+				Description:  "Namespace to which the entitlement belongs.",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"repository": {
+				Type:         schema.TypeString,
+				Description:  "Repository to which the entitlement belongs.",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+		},
+	}
+}
@@ -0,0 +1,141 @@
+//nolint:testpackage
+package cloudsmith
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// TestAccEntitlementLimitPolicy_basic spins up a repository with an
+// entitlement, applies a limit policy to the entitlement and verifies the
+// limits are set, updates the limits, then imports the policy before tearing
+// down the resources and verifying deletion.
+func TestAccEntitlementLimitPolicy_basic(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccEntitlementCheckDestroy("cloudsmith_entitlement.test"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccEntitlementLimitPolicyConfigBasic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccEntitlementLimitPolicyCheckLimit("cloudsmith_entitlement_limit_policy.test", 100),
+					resource.TestCheckResourceAttr("cloudsmith_entitlement_limit_policy.test", "limit_num_downloads", "100"),
+					resource.TestCheckResourceAttr("cloudsmith_entitlement_limit_policy.test", "limit_package_query", "name:foo"),
+				),
+			},
+			{
+				Config: testAccEntitlementLimitPolicyConfigBasicUpdate,
+				Check: resource.ComposeTestCheckFunc(
+					testAccEntitlementLimitPolicyCheckLimit("cloudsmith_entitlement_limit_policy.test", 200),
+					resource.TestCheckResourceAttr("cloudsmith_entitlement_limit_policy.test", "limit_num_clients", "5"),
+					resource.TestCheckResourceAttr("cloudsmith_entitlement_limit_policy.test", "limit_num_downloads", "200"),
+					resource.TestCheckResourceAttr("cloudsmith_entitlement_limit_policy.test", "limit_package_query", ""),
+				),
+			},
+			{
+				ResourceName: "cloudsmith_entitlement_limit_policy.test",
+				ImportState:  true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					resourceState := s.RootModule().Resources["cloudsmith_entitlement_limit_policy.test"]
+					return fmt.Sprintf(
+						"%s.%s.%s",
+						resourceState.Primary.Attributes["namespace"],
+						resourceState.Primary.Attributes["repository"],
+						resourceState.Primary.Attributes["entitlement_slug_perm"],
+					), nil
+				},
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+//nolint:goerr113
+func testAccEntitlementLimitPolicyCheckLimit(resourceName string, limitNumDownloads int64) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		resourceState, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", resourceName)
+		}
+
+		if resourceState.Primary.ID == "" {
+			return fmt.Errorf("resource id not set")
+		}
+
+		pc := testAccProvider.Meta().(*providerConfig)
+
+		namespace := os.Getenv("CLOUDSMITH_NAMESPACE")
+		repository := resourceState.Primary.Attributes["repository"]
+		entitlement := resourceState.Primary.Attributes["entitlement_slug_perm"]
+
+		req := pc.APIClient.EntitlementsApi.EntitlementsRead(pc.Auth, namespace, repository, entitlement)
+		token, resp, err := pc.APIClient.EntitlementsApi.EntitlementsReadExecute(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+
+		if token.GetLimitNumDownloads() != limitNumDownloads {
+			return fmt.Errorf("expected limit_num_downloads %d, got %d", limitNumDownloads, token.GetLimitNumDownloads())
+		}
+
+		return nil
+	}
+}
+
+var testAccEntitlementLimitPolicyConfigBasic = fmt.Sprintf(`
+resource "cloudsmith_repository" "test" {
+	name      = "terraform-acc-test-ent-limit"
+	namespace = "%s"
+}
+
+resource "cloudsmith_entitlement" "test" {
+    name       = "Test Entitlement"
+    namespace  = "${cloudsmith_repository.test.namespace}"
+    repository = "${cloudsmith_repository.test.slug_perm}"
+
+    lifecycle {
+        ignore_changes = [limit_package_query]
+    }
+}
+
+resource "cloudsmith_entitlement_limit_policy" "test" {
+    namespace             = "${cloudsmith_repository.test.namespace}"
+    repository            = "${cloudsmith_repository.test.slug_perm}"
+    entitlement_slug_perm = "${cloudsmith_entitlement.test.slug_perm}"
+    limit_num_downloads   = 100
+    limit_package_query   = "name:foo"
+}
+`, os.Getenv("CLOUDSMITH_NAMESPACE"))
+
+var testAccEntitlementLimitPolicyConfigBasicUpdate = fmt.Sprintf(`
+resource "cloudsmith_repository" "test" {
+	name      = "terraform-acc-test-ent-limit"
+	namespace = "%s"
+}
+
+resource "cloudsmith_entitlement" "test" {
+    name       = "Test Entitlement"
+    namespace  = "${cloudsmith_repository.test.namespace}"
+    repository = "${cloudsmith_repository.test.slug_perm}"
+
+    lifecycle {
+        ignore_changes = [limit_package_query]
+    }
+}
+
+resource "cloudsmith_entitlement_limit_policy" "test" {
+    namespace             = "${cloudsmith_repository.test.namespace}"
+    repository            = "${cloudsmith_repository.test.slug_perm}"
+    entitlement_slug_perm = "${cloudsmith_entitlement.test.slug_perm}"
+    limit_num_clients     = 5
+    limit_num_downloads   = 200
+}
+`, os.Getenv("CLOUDSMITH_NAMESPACE"))
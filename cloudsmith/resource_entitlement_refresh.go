@@ -0,0 +1,120 @@
+package cloudsmith
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cloudsmith-io/cloudsmith-api-go"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceEntitlementRefreshCreate(d *schema.ResourceData, m interface{}) error {
+	pc := m.(*providerConfig)
+
+	namespace := requiredString(d, "namespace")
+	repository := requiredString(d, "repository")
+	identifier := requiredString(d, "identifier")
+
+	req := pc.APIClient.EntitlementsApi.EntitlementsRefresh(pc.Auth, namespace, repository, identifier)
+	req = req.Data(cloudsmith.RepositoryTokenRefreshRequest{})
+	req = req.ShowTokens(true)
+
+	entitlement, _, err := pc.APIClient.EntitlementsApi.EntitlementsRefreshExecute(req)
+	if err != nil {
+		return fmt.Errorf("error refreshing entitlement (%s): %w", identifier, err)
+	}
+
+	d.SetId(entitlement.GetSlugPerm())
+	d.Set("refreshed_at", timeToString(time.Now().UTC()))
+
+	return resourceEntitlementRefreshRead(d, m)
+}
+
+func resourceEntitlementRefreshRead(d *schema.ResourceData, m interface{}) error {
+	pc := m.(*providerConfig)
+
+	namespace := requiredString(d, "namespace")
+	repository := requiredString(d, "repository")
+
+	req := pc.APIClient.EntitlementsApi.EntitlementsRead(pc.Auth, namespace, repository, d.Id())
+	req = req.ShowTokens(true)
+
+	entitlement, resp, err := pc.APIClient.EntitlementsApi.EntitlementsReadExecute(req)
+	if err != nil {
+		if is404(resp) {
+			d.SetId("")
+			return nil
+		}
+
+		return err
+	}
+
+	d.Set("token", entitlement.GetToken())
+
+	// namespace, repository, identifier and keepers are not returned from the
+	// entitlement read endpoint, so we can use the values stored in resource
+	// state. We rely on ForceNew to ensure if any changes the token is
+	// refreshed again.
+	d.Set("namespace", namespace)
+	d.Set("repository", repository)
+	d.Set("identifier", requiredString(d, "identifier"))
+
+	return nil
+}
+
+// resourceEntitlementRefreshDelete only removes the resource from state, as a
+// refresh can't be undone; the entitlement keeps its current token.
+func resourceEntitlementRefreshDelete(d *schema.ResourceData, m interface{}) error {
+	return nil
+}
+
+func resourceEntitlementRefresh() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceEntitlementRefreshCreate,
+		Read:   resourceEntitlementRefreshRead,
+		Delete: resourceEntitlementRefreshDelete,
+
+		Schema: map[string]*schema.Schema{
+			"identifier": {
+				Type:         schema.TypeString,
+				Description:  "The slug_perm of the entitlement whose token is refreshed.",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"keepers": {
+				Type:        schema.TypeMap,
+				Description: "Arbitrary values which, when changed, cause the token to be refreshed again.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"namespace": {
+				Type:         schema.TypeString,
+				Description:  "Namespace to which the entitlement belongs.",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"refreshed_at": {
+				Type:        schema.TypeString,
+				Description: "ISO 8601 timestamp at which the token was refreshed.",
+				Computed:    true,
+			},
+			"repository": {
+				Type:         schema.TypeString,
+				Description:  "Repository to which the entitlement belongs.",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"token": {
+				Type:        schema.TypeString,
+				Description: "The refreshed token.",
+				Computed:    true,
+				Sensitive:   true,
+			},
+		},
+	}
+}
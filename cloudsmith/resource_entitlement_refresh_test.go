@@ -0,0 +1,92 @@
+//nolint:testpackage
+package cloudsmith
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// TestAccEntitlementRefresh_basic spins up a repository and an entitlement,
+// refreshes the entitlement's token and verifies it changed, then changes a
+// keeper and verifies the token is refreshed again.
+func TestAccEntitlementRefresh_basic(t *testing.T) {
+	t.Parallel()
+
+	var tokens []string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccEntitlementCheckDestroy("cloudsmith_entitlement.test"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccEntitlementRefreshConfig("1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("cloudsmith_entitlement_refresh.test", "refreshed_at"),
+					testAccEntitlementRefreshCheckRotated("cloudsmith_entitlement_refresh.test", &tokens),
+				),
+			},
+			{
+				Config: testAccEntitlementRefreshConfig("2"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccEntitlementRefreshCheckRotated("cloudsmith_entitlement_refresh.test", &tokens),
+				),
+			},
+		},
+	})
+}
+
+// testAccEntitlementRefreshCheckRotated records the token of the refresh
+// resource and verifies it differs from the previously recorded token.
+//
+//nolint:goerr113
+func testAccEntitlementRefreshCheckRotated(resourceName string, tokens *[]string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		resourceState, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", resourceName)
+		}
+
+		token := resourceState.Primary.Attributes["token"]
+		if token == "" {
+			return fmt.Errorf("token not set")
+		}
+		for _, previous := range *tokens {
+			if token == previous {
+				return fmt.Errorf("expected token to have been refreshed")
+			}
+		}
+
+		*tokens = append(*tokens, token)
+		return nil
+	}
+}
+
+func testAccEntitlementRefreshConfig(rotation string) string {
+	return fmt.Sprintf(`
+resource "cloudsmith_repository" "test" {
+	name      = "terraform-acc-test-entitlement-refresh"
+	namespace = "%s"
+}
+
+resource "cloudsmith_entitlement" "test" {
+	name       = "Test Entitlement Refresh"
+	namespace  = cloudsmith_repository.test.namespace
+	repository = cloudsmith_repository.test.slug_perm
+}
+
+resource "cloudsmith_entitlement_refresh" "test" {
+	namespace  = cloudsmith_repository.test.namespace
+	repository = cloudsmith_repository.test.slug_perm
+	identifier = cloudsmith_entitlement.test.slug_perm
+
+	keepers = {
+		rotation = "%s"
+	}
+}
+`, os.Getenv("CLOUDSMITH_NAMESPACE"), rotation)
+}
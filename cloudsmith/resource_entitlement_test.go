@@ -38,6 +38,8 @@ func TestAccEntitlement_basic(t *testing.T) {
 					testAccEntitlementCheckExists("cloudsmith_entitlement.test"),
 					resource.TestCheckResourceAttr("cloudsmith_entitlement.test", "name", "Test Entitlement Update"),
 					resource.TestCheckResourceAttr("cloudsmith_entitlement.test", "limit_num_downloads", "100"),
+					resource.TestCheckResourceAttr("cloudsmith_entitlement.test", "limit_bandwidth", "5"),
+					resource.TestCheckResourceAttr("cloudsmith_entitlement.test", "limit_bandwidth_unit", "Gigabyte"),
 					resource.TestCheckResourceAttr("cloudsmith_entitlement.test", "access_private_broadcasts", "true"),
 				),
 			},
@@ -151,6 +153,8 @@ resource "cloudsmith_entitlement" "test" {
 	name                       = "Test Entitlement Update"
     access_private_broadcasts  = true
     limit_num_downloads        = 100
+    limit_bandwidth            = 5
+    limit_bandwidth_unit       = "Gigabyte"
     namespace                  = "${cloudsmith_repository.test.namespace}"
     repository                 = "${cloudsmith_repository.test.slug_perm}"
 }
@@ -0,0 +1,186 @@
+package cloudsmith
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/cloudsmith-io/cloudsmith-api-go"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var organizationMemberRoles = []string{
+	"Member",
+	"Manager",
+	"Owner",
+}
+
+func importOrganizationMember(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	idParts := strings.Split(d.Id(), ".")
+	if len(idParts) != 2 {
+		return nil, fmt.Errorf(
+			"invalid import ID, must be of the form <organization_slug>.<user_slug>, got: %s", d.Id(),
+		)
+	}
+
+	d.Set("organization", idParts[0])
+	d.Set("user", idParts[1])
+	return []*schema.ResourceData{d}, nil
+}
+
+// updateOrganizationMemberRole sets the role of an existing organization member.
+func updateOrganizationMemberRole(pc *providerConfig, organization, user, role string) error {
+	req := pc.APIClient.OrgsApi.OrgsMembersUpdateRole(pc.Auth, organization, user)
+	req = req.Data(cloudsmith.OrganizationMembershipRoleUpdateRequestPatch{
+		Role: cloudsmith.PtrString(role),
+	})
+	if _, _, err := pc.APIClient.OrgsApi.OrgsMembersUpdateRoleExecute(req); err != nil {
+		return fmt.Errorf("error updating role of member (%s): %w", user, err)
+	}
+	return nil
+}
+
+func resourceOrganizationMemberCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	pc := m.(*providerConfig)
+
+	organization := requiredString(d, "organization")
+	user := requiredString(d, "user")
+
+	// the Cloudsmith API has no way of adding a user to an organization
+	// directly, as users must accept an invitation to join. So we take over
+	// management of an existing membership instead.
+	req := pc.APIClient.OrgsApi.OrgsMembersRead(pc.Auth, organization, user)
+	member, resp, err := pc.APIClient.OrgsApi.OrgsMembersReadExecute(req)
+	if err != nil {
+		if is404(resp) {
+			return diag.Errorf(
+				"user %s is not a member of organization %s; users must be invited to and join the organization before their membership can be managed",
+				user, organization,
+			)
+		}
+		return diag.FromErr(err)
+	}
+
+	if role := requiredString(d, "role"); member.GetRole() != role {
+		if err := updateOrganizationMemberRole(pc, organization, user, role); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	d.SetId(fmt.Sprintf("%s.%s", organization, user))
+
+	return resourceOrganizationMemberRead(ctx, d, m)
+}
+
+func resourceOrganizationMemberRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	pc := m.(*providerConfig)
+
+	organization := requiredString(d, "organization")
+	user := requiredString(d, "user")
+
+	req := pc.APIClient.OrgsApi.OrgsMembersRead(pc.Auth, organization, user)
+	member, resp, err := pc.APIClient.OrgsApi.OrgsMembersReadExecute(req)
+	if err != nil {
+		if is404(resp) {
+			tflog.Warn(ctx, "Organization member not found, removing from state", map[string]interface{}{
+				"organization": organization,
+				"user":         user,
+			})
+			d.SetId("")
+			return nil
+		}
+
+		return diag.FromErr(err)
+	}
+
+	d.Set("email", member.GetEmail())
+	d.Set("has_two_factor", member.GetHasTwoFactor())
+	d.Set("role", member.GetRole())
+	d.Set("user_id", member.GetUserId())
+
+	// organization and user are not returned in a form we can rely on from
+	// the member read endpoint, so we can use the values stored in resource
+	// state. We rely on ForceNew to ensure if either changes a new resource is
+	// created.
+	d.Set("organization", organization)
+	d.Set("user", user)
+
+	return nil
+}
+
+func resourceOrganizationMemberUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	pc := m.(*providerConfig)
+
+	organization := requiredString(d, "organization")
+	user := requiredString(d, "user")
+
+	if d.HasChange("role") {
+		if err := updateOrganizationMemberRole(pc, organization, user, requiredString(d, "role")); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceOrganizationMemberRead(ctx, d, m)
+}
+
+// resourceOrganizationMemberDelete only removes the membership from state. As
+// Create takes over an existing membership rather than adding the user to the
+// organization, destroying the resource leaves the user in the organization.
+func resourceOrganizationMemberDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return nil
+}
+
+func resourceOrganizationMember() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceOrganizationMemberCreate,
+		ReadContext:   resourceOrganizationMemberRead,
+		UpdateContext: resourceOrganizationMemberUpdate,
+		DeleteContext: resourceOrganizationMemberDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: importOrganizationMember,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"email": {
+				Type:        schema.TypeString,
+				Description: "The email address of the member.",
+				Computed:    true,
+			},
+			"has_two_factor": {
+				Type:        schema.TypeBool,
+				Description: "Whether the member has two-factor authentication enabled.",
+				Computed:    true,
+			},
+			"organization": {
+				Type:         schema.TypeString,
+				Description:  "Organization to which the member belongs.",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"role": {
+				Type:         schema.TypeString,
+				Description:  "The role of the member within the organization.",
+				Optional:     true,
+				Default:      "Member",
+				ValidateFunc: validation.StringInSlice(organizationMemberRoles, false),
+			},
+			"user": {
+				Type:         schema.TypeString,
+				Description:  "The slug of the user.",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"user_id": {
+				Type:        schema.TypeString,
+				Description: "The unique identifier of the user.",
+				Computed:    true,
+			},
+		},
+	}
+}
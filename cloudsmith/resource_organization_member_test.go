@@ -0,0 +1,78 @@
+//nolint:testpackage
+package cloudsmith
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// TestAccOrganizationMember_basic takes over management of an existing
+// organization member, changes their role and verifies it has been set
+// correctly before importing the membership, then verifies the user is still
+// a member once the resource is destroyed. Since the test changes the role of
+// the member, it only runs when a disposable member is provided via
+// CLOUDSMITH_TEST_ORG_MEMBER.
+func TestAccOrganizationMember_basic(t *testing.T) {
+	t.Parallel()
+
+	member := os.Getenv("CLOUDSMITH_TEST_ORG_MEMBER")
+	if member == "" {
+		t.Skip("CLOUDSMITH_TEST_ORG_MEMBER must be set to run organization member tests")
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccOrganizationMemberCheckRetained(member),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccOrganizationMemberConfig(member, "Member"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("cloudsmith_organization_member.test", "role", "Member"),
+					resource.TestCheckResourceAttrSet("cloudsmith_organization_member.test", "user_id"),
+					resource.TestCheckResourceAttrSet("cloudsmith_organization_member.test", "has_two_factor"),
+				),
+			},
+			{
+				Config: testAccOrganizationMemberConfig(member, "Manager"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("cloudsmith_organization_member.test", "role", "Manager"),
+				),
+			},
+			{
+				ResourceName:      "cloudsmith_organization_member.test",
+				ImportState:       true,
+				ImportStateId:     fmt.Sprintf("%s.%s", os.Getenv("CLOUDSMITH_NAMESPACE"), member),
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+// testAccOrganizationMemberCheckRetained verifies that destroying the resource
+// leaves the user in the organization.
+func testAccOrganizationMemberCheckRetained(member string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		pc := testAccProvider.Meta().(*providerConfig)
+
+		req := pc.APIClient.OrgsApi.OrgsMembersRead(pc.Auth, os.Getenv("CLOUDSMITH_NAMESPACE"), member)
+		if _, _, err := pc.APIClient.OrgsApi.OrgsMembersReadExecute(req); err != nil {
+			return fmt.Errorf("expected %s to remain a member of the organization: %w", member, err)
+		}
+		return nil
+	}
+}
+
+func testAccOrganizationMemberConfig(member, role string) string {
+	return fmt.Sprintf(`
+resource "cloudsmith_organization_member" "test" {
+	organization = "%s"
+	user         = "%s"
+	role         = "%s"
+}
+`, os.Getenv("CLOUDSMITH_NAMESPACE"), member, role)
+}
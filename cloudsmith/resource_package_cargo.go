@@ -0,0 +1,136 @@
+package cloudsmith
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// cargoManifest holds the fields of the [package] table of a Cargo.toml that
+// are exposed by the cargo package resource.
+type cargoManifest struct {
+	Name        string
+	Version     string
+	Edition     string
+	RustVersion string
+}
+
+// parseCargoManifest reads the string values of the [package] table of a
+// Cargo.toml. The manifest in a .crate is normalised by `cargo package`, so
+// each value is a plain `key = "value"` line.
+func parseCargoManifest(content []byte) cargoManifest {
+	fields := map[string]string{}
+
+	inPackage := false
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "[") {
+			inPackage = line == "[package]"
+			continue
+		}
+		if !inPackage || line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		key, value, found := strings.Cut(line, "=")
+		if !found {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		if !strings.HasPrefix(value, `"`) {
+			continue
+		}
+		if end := strings.Index(value[1:], `"`); end >= 0 {
+			fields[strings.TrimSpace(key)] = value[1 : end+1]
+		}
+	}
+
+	return cargoManifest{
+		Name:        fields["name"],
+		Version:     fields["version"],
+		Edition:     fields["edition"],
+		RustVersion: fields["rust-version"],
+	}
+}
+
+// readCargoManifest reads the Cargo.toml from a .crate, as created by `cargo
+// package`, returning an error if the crate is not valid.
+func readCargoManifest(filePath string) (cargoManifest, error) {
+	// cargo package places the crate contents in a single top-level directory
+	// named after the crate and its version.
+	content, err := readTarGzEntry(filePath, func(name string) bool {
+		parts := strings.Split(strings.TrimPrefix(name, "./"), "/")
+		return len(parts) == 2 && parts[1] == "Cargo.toml"
+	})
+	if err != nil {
+		return cargoManifest{}, fmt.Errorf("invalid crate %s: error reading Cargo.toml: %w", filePath, err)
+	}
+
+	manifest := parseCargoManifest(content)
+	if manifest.Name == "" || manifest.Version == "" {
+		return manifest, fmt.Errorf("invalid crate %s: Cargo.toml must contain a package name and version", filePath)
+	}
+
+	return manifest, nil
+}
+
+func resourcePackageCargoCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	pc := m.(*providerConfig)
+
+	manifest, err := readCargoManifest(requiredString(d, "package_file"))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := createUploadedPackage(ctx, d, pc, "cargo", requiredString(d, "package_file")); err != nil {
+		return diag.FromErr(err)
+	}
+
+	// The package API doesn't return the edition or minimum Rust version of a
+	// crate, so they're taken from the Cargo.toml that was uploaded.
+	d.Set("edition", manifest.Edition)
+	d.Set("rust_version", manifest.RustVersion)
+
+	return resourcePackageCargoRead(ctx, d, m)
+}
+
+func resourcePackageCargoRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	pc := m.(*providerConfig)
+
+	_, err := readUploadedPackage(d, pc)
+	return diag.FromErr(err)
+}
+
+// resourcePackageCargoUpdate only handles changes to sync_timeout, as every
+// other argument forces a new package to be uploaded.
+func resourcePackageCargoUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return resourcePackageCargoRead(ctx, d, m)
+}
+
+func resourcePackageCargo() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcePackageCargoCreate,
+		ReadContext:   resourcePackageCargoRead,
+		UpdateContext: resourcePackageCargoUpdate,
+		DeleteContext: resourcePackageUploadDelete,
+
+		Schema: packageResourceSchema([]string{".crate"}, map[string]*schema.Schema{
+			"edition": {
+				Type:        schema.TypeString,
+				Description: "The Rust edition of the crate, e.g. `2021`, as read from its Cargo.toml.",
+				Computed:    true,
+			},
+			"rust_version": {
+				Type:        schema.TypeString,
+				Description: "The minimum supported Rust version of the crate, as read from its Cargo.toml.",
+				Computed:    true,
+			},
+		}),
+	}
+}
@@ -0,0 +1,106 @@
+//nolint:testpackage
+package cloudsmith
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+const testCargoToml = `# THIS FILE IS AUTOMATICALLY GENERATED BY CARGO
+[package]
+edition = "2021"
+rust-version = "1.70"
+name = "terraform-acc-test-cargo"
+version = "1.0.0"
+description = "Terraform acceptance test crate"
+license = "MIT"
+
+[dependencies.serde]
+version = "1.0"
+`
+
+// TestAccPackageCargo_basic spins up a repository, uploads a minimal crate
+// built by the test and verifies the attributes read from its Cargo.toml,
+// before tearing down the resources and verifying deletion.
+func TestAccPackageCargo_basic(t *testing.T) {
+	t.Parallel()
+
+	packageFile := filepath.Join(t.TempDir(), "terraform-acc-test-cargo-1.0.0.crate")
+	content := testTarGz(t, map[string]string{"terraform-acc-test-cargo-1.0.0/Cargo.toml": testCargoToml})
+	if err := os.WriteFile(packageFile, content, 0o600); err != nil {
+		t.Fatalf("unable to write package file: %s", err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccPackageUploadCheckDestroy("cloudsmith_cargo_package.test"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPackageCargoConfig(packageFile),
+				Check: resource.ComposeTestCheckFunc(
+					testAccPackageUploadCheckExists("cloudsmith_cargo_package.test"),
+					resource.TestCheckResourceAttr("cloudsmith_cargo_package.test", "name", "terraform-acc-test-cargo"),
+					resource.TestCheckResourceAttr("cloudsmith_cargo_package.test", "version", "1.0.0"),
+					resource.TestCheckResourceAttr("cloudsmith_cargo_package.test", "edition", "2021"),
+					resource.TestCheckResourceAttr("cloudsmith_cargo_package.test", "rust_version", "1.70"),
+					resource.TestCheckResourceAttrSet("cloudsmith_cargo_package.test", "cdn_url"),
+				),
+			},
+		},
+	})
+}
+
+func TestReadCargoManifest(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	tests := []struct {
+		name    string
+		content []byte
+		err     string
+	}{
+		{name: "valid", content: testTarGz(t, map[string]string{"crate-1.0.0/Cargo.toml": testCargoToml})},
+		{name: "no-package", content: testTarGz(t, map[string]string{"crate-1.0.0/Cargo.toml": "[dependencies]\nname = \"dep\"\n"}), err: "must contain a package name and version"},
+		{name: "missing", content: testTarGz(t, map[string]string{"crate-1.0.0/src/lib.rs": ""}), err: "file not found in archive"},
+	}
+
+	for _, tt := range tests {
+		packageFile := filepath.Join(dir, tt.name+".crate")
+		if err := os.WriteFile(packageFile, tt.content, 0o600); err != nil {
+			t.Fatalf("unable to write package file: %s", err)
+		}
+
+		manifest, err := readCargoManifest(packageFile)
+		if tt.err == "" {
+			expected := cargoManifest{Name: "terraform-acc-test-cargo", Version: "1.0.0", Edition: "2021", RustVersion: "1.70"}
+			if err != nil {
+				t.Errorf("%s: unexpected error: %s", tt.name, err)
+			} else if manifest != expected {
+				t.Errorf("%s: unexpected manifest %+v", tt.name, manifest)
+			}
+		} else if err == nil || !strings.Contains(err.Error(), tt.err) {
+			t.Errorf("%s: expected error containing %q, got %v", tt.name, tt.err, err)
+		}
+	}
+}
+
+func testAccPackageCargoConfig(packageFile string) string {
+	return fmt.Sprintf(`
+resource "cloudsmith_repository" "test" {
+	name      = "terraform-acc-test-cargo-package"
+	namespace = "%s"
+}
+
+resource "cloudsmith_cargo_package" "test" {
+	namespace    = "${cloudsmith_repository.test.namespace}"
+	repository   = "${cloudsmith_repository.test.slug_perm}"
+	package_file = "%s"
+}
+`, os.Getenv("CLOUDSMITH_NAMESPACE"), packageFile)
+}
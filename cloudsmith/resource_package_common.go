@@ -0,0 +1,379 @@
+package cloudsmith
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/cloudsmith-io/cloudsmith-api-go"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var defaultPackageSyncTimeout = time.Minute * 10
+
+// uploadPackageFile uploads a local file to Cloudsmith so that it can be used
+// to create a package, returning the identifier of the uploaded file.
+func uploadPackageFile(ctx context.Context, pc *providerConfig, namespace, repository, filePath string) (string, error) {
+	return uploadPackageFileAs(ctx, pc, namespace, repository, filePath, filepath.Base(filePath))
+}
+
+// uploadPackageFileAs uploads a local file in the same way as
+// uploadPackageFile, but under the given filename rather than its own, for
+// formats where the filename carries meaning.
+func uploadPackageFileAs(ctx context.Context, pc *providerConfig, namespace, repository, filePath, filename string) (string, error) {
+	checksums, err := calculateChecksums(filePath, false)
+	if err != nil {
+		return "", fmt.Errorf("error calculating checksums for %s: %w", filePath, err)
+	}
+
+	initReq := pc.APIClient.FilesApi.FilesCreate(pc.Auth, namespace, repository)
+	initReq = initReq.Data(cloudsmith.PackageFileUploadRequest{
+		Filename:       filename,
+		Method:         cloudsmith.PtrString("put"),
+		Sha256Checksum: cloudsmith.PtrString(checksums.SHA256),
+	})
+	upload, _, err := pc.APIClient.FilesApi.FilesCreateExecute(initReq)
+	if err != nil {
+		return "", fmt.Errorf("error initializing upload of %s: %w", filePath, err)
+	}
+
+	file, err := os.Open(filePath)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, upload.GetUploadUrl(), file)
+	if err != nil {
+		return "", err
+	}
+	req.ContentLength = info.Size()
+	req.SetBasicAuth("token", pc.GetAPIKey())
+	for k, v := range upload.GetUploadHeaders() {
+		req.Header.Set(k, fmt.Sprint(v))
+	}
+
+	resp, err := pc.APIClient.GetConfig().HTTPClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("error uploading %s: %w", filePath, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("error uploading %s: status code: %d", filePath, resp.StatusCode)
+	}
+
+	return upload.GetIdentifier(), nil
+}
+
+// waitForPackageSync polls the status of a package until it has finished
+// synchronising, returning an error with the reason given by the API if the
+// synchronisation fails.
+func waitForPackageSync(ctx context.Context, pc *providerConfig, namespace, repository, slugPerm string, timeout time.Duration) error {
+	err := resource.RetryContext(ctx, timeout, func() *resource.RetryError {
+		req := pc.APIClient.PackagesApi.PackagesStatus(pc.Auth, namespace, repository, slugPerm)
+		status, resp, err := pc.APIClient.PackagesApi.PackagesStatusExecute(req)
+		if err != nil {
+			// the package may not be visible yet due to cross-region database
+			// replication, so keep waiting.
+			if is404(resp) {
+				return resource.RetryableError(fmt.Errorf("package not found"))
+			}
+			return resource.NonRetryableError(err)
+		}
+
+		if status.GetIsSyncFailed() {
+			return resource.NonRetryableError(fmt.Errorf("package sync failed: %s", status.GetStatusReason()))
+		}
+		if !status.GetIsSyncCompleted() {
+			return resource.RetryableError(fmt.Errorf("package sync %s (%d%%)", status.GetStatusStr(), status.GetSyncProgress()))
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("error waiting for package (%s) to sync: %w", slugPerm, err)
+	}
+
+	return nil
+}
+
+// createUploadedPackage uploads the local file at filePath as a package of the
+// given format, sets the resource ID to the slug_perm of the new package and
+// waits for it to finish synchronising.
+func createUploadedPackage(ctx context.Context, d *schema.ResourceData, pc *providerConfig, format, filePath string) error {
+	namespace := requiredString(d, "namespace")
+	repository := requiredString(d, "repository")
+
+	fileID, err := uploadPackageFile(ctx, pc, namespace, repository, filePath)
+	if err != nil {
+		return err
+	}
+
+	slugPerm, err := packageUploaders[format](pc, d, namespace, repository, fileID)
+	if err != nil {
+		return fmt.Errorf("error creating %s package: %w", format, err)
+	}
+
+	d.SetId(slugPerm)
+
+	timeout := time.Duration(d.Get("sync_timeout").(int)) * time.Second
+	return waitForPackageSync(ctx, pc, namespace, repository, d.Id(), timeout)
+}
+
+// readUploadedPackage reads the package identified by the resource ID and
+// sets the attributes common to all uploaded packages. A nil package is
+// returned (and the ID cleared) if the package no longer exists.
+func readUploadedPackage(d *schema.ResourceData, pc *providerConfig) (*cloudsmith.Package, error) {
+	namespace := requiredString(d, "namespace")
+	repository := requiredString(d, "repository")
+
+	req := pc.APIClient.PackagesApi.PackagesRead(pc.Auth, namespace, repository, d.Id())
+	pkg, resp, err := pc.APIClient.PackagesApi.PackagesReadExecute(req)
+	if err != nil {
+		if is404(resp) {
+			d.SetId("")
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	d.Set("cdn_url", pkg.GetCdnUrl())
+	d.Set("checksum_sha256", pkg.GetChecksumSha256())
+	d.Set("filename", pkg.GetFilename())
+	d.Set("name", pkg.GetName())
+	d.Set("slug", pkg.GetSlug())
+	d.Set("slug_perm", pkg.GetSlugPerm())
+	d.Set("version", pkg.GetVersion())
+
+	// namespace and repository are not returned from the package read
+	// endpoint, so we can use the values stored in resource state. We rely on
+	// ForceNew to ensure if either changes a new resource is created.
+	d.Set("namespace", namespace)
+	d.Set("repository", repository)
+
+	return pkg, nil
+}
+
+// exactPackageQuery returns a package search query term matching packages whose
+// field is exactly value. The value is anchored, as otherwise the term also
+// matches packages whose field merely contains it, and escaped, as names and
+// versions often contain characters such as `.` and `+` which the search
+// would otherwise treat as a pattern.
+func exactPackageQuery(field, value string) string {
+	return fmt.Sprintf("%s:^%s$", field, regexp.QuoteMeta(value))
+}
+
+// findDuplicatePackage searches a repository for an existing package with the
+// same filename and content as the local file at filePath, returning its
+// slug_perm or an empty string if no such package exists.
+func findDuplicatePackage(pc *providerConfig, namespace, repository, filePath string) (string, error) {
+	checksums, err := calculateChecksums(filePath, false)
+	if err != nil {
+		return "", fmt.Errorf("error calculating checksums for %s: %w", filePath, err)
+	}
+
+	query := fmt.Sprintf("filename:%s", filepath.Base(filePath))
+	packages, err := retrievePackageListPages(pc, namespace, repository, query, -1, -1)
+	if err != nil {
+		return "", err
+	}
+
+	for _, pkg := range packages {
+		if pkg.GetChecksumSha256() == checksums.SHA256 {
+			return pkg.GetSlugPerm(), nil
+		}
+	}
+
+	return "", nil
+}
+
+// errArchiveEntryNotFound is returned when reading an entry from a package
+// archive if no entry matches.
+var errArchiveEntryNotFound = errors.New("file not found in archive")
+
+// readTarGzEntry returns the content of the first entry in the gzipped
+// tarball at filePath whose name satisfies match, allowing package files to be
+// checked for the manifest their format requires before they are uploaded.
+func readTarGzEntry(filePath string, match func(name string) bool) ([]byte, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	gr, err := gzip.NewReader(file)
+	if err != nil {
+		return nil, err
+	}
+	defer gr.Close()
+
+	tr := tar.NewReader(gr)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil, errArchiveEntryNotFound
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if header.Typeflag == tar.TypeReg && match(header.Name) {
+			return io.ReadAll(tr)
+		}
+	}
+}
+
+// readZipEntry returns the content of the first entry in the zip archive at
+// filePath whose name satisfies match.
+func readZipEntry(filePath string, match func(name string) bool) ([]byte, error) {
+	zr, err := zip.OpenReader(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+
+	for _, file := range zr.File {
+		if file.FileInfo().IsDir() || !match(file.Name) {
+			continue
+		}
+
+		rc, err := file.Open()
+		if err != nil {
+			return nil, err
+		}
+		defer rc.Close()
+
+		return io.ReadAll(rc)
+	}
+
+	return nil, errArchiveEntryNotFound
+}
+
+// packageFileValidateFunc returns a validation function ensuring a path ends in
+// one of the given file extensions.
+func packageFileValidateFunc(extensions ...string) schema.SchemaValidateFunc {
+	quoted := make([]string, len(extensions))
+	for i, extension := range extensions {
+		quoted[i] = regexp.QuoteMeta(extension)
+	}
+
+	return validation.StringMatch(
+		regexp.MustCompile(fmt.Sprintf(`(%s)$`, strings.Join(quoted, "|"))),
+		fmt.Sprintf("must be a path to a %s file", strings.Join(extensions, " or ")),
+	)
+}
+
+// packageResourceSchema returns the schema shared by the format-specific
+// package resources, merged with any format-specific attributes. A nil
+// format-specific attribute removes the shared attribute of the same name. If
+// any extensions are given, the package file must have one of them.
+//
+//nolint:funlen
+func packageResourceSchema(extensions []string, formatSchema map[string]*schema.Schema) map[string]*schema.Schema {
+	s := map[string]*schema.Schema{
+		"cdn_url": {
+			Type:        schema.TypeString,
+			Description: "The URL from which the package can be downloaded.",
+			Computed:    true,
+		},
+		"checksum_sha256": {
+			Type:        schema.TypeString,
+			Description: "SHA256 hash of the package.",
+			Computed:    true,
+		},
+		"filename": {
+			Type:        schema.TypeString,
+			Description: "The filename of the package.",
+			Computed:    true,
+		},
+		"name": {
+			Type:        schema.TypeString,
+			Description: "The name of the package, as read from the package file.",
+			Computed:    true,
+		},
+		"namespace": {
+			Type:         schema.TypeString,
+			Description:  "Namespace to which the package will be uploaded.",
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+		"package_file": {
+			Type:         schema.TypeString,
+			Description:  "Path to the local file to upload.",
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+		"republish": {
+			Type: schema.TypeBool,
+			Description: "If true, the uploaded package will overwrite any others with the same " +
+				"attributes (e.g. same version).",
+			Optional: true,
+			ForceNew: true,
+		},
+		"repository": {
+			Type:         schema.TypeString,
+			Description:  "Repository to which the package will be uploaded.",
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+		"slug": {
+			Type:        schema.TypeString,
+			Description: "The slug identifies the package in URIs.",
+			Computed:    true,
+		},
+		"slug_perm": {
+			Type: schema.TypeString,
+			Description: "The slug_perm immutably identifies the package. " +
+				"It will never change once a package has been created.",
+			Computed: true,
+		},
+		"sync_timeout": {
+			Type:         schema.TypeInt,
+			Description:  "The time in seconds to wait for the package to finish synchronising.",
+			Optional:     true,
+			Default:      int(defaultPackageSyncTimeout.Seconds()),
+			ValidateFunc: validation.IntAtLeast(1),
+		},
+		"version": {
+			Type:        schema.TypeString,
+			Description: "The version of the package, as read from the package file.",
+			Computed:    true,
+		},
+	}
+
+	if len(extensions) > 0 {
+		s["package_file"].Description = fmt.Sprintf("Path to the local %s file to upload.", strings.Join(extensions, " or "))
+		s["package_file"].ValidateFunc = packageFileValidateFunc(extensions...)
+	}
+
+	for key, value := range formatSchema {
+		if value == nil {
+			delete(s, key)
+			continue
+		}
+		s[key] = value
+	}
+
+	return s
+}
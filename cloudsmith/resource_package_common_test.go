@@ -0,0 +1,98 @@
+//nolint:testpackage
+package cloudsmith
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestWaitForPackageSync serves a sequence of package statuses and verifies
+// that waiting continues until the package has finished synchronising, and
+// that the reason for a failed synchronisation is returned.
+func TestWaitForPackageSync(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		statuses []map[string]interface{}
+		expected string
+	}{
+		{
+			name: "Completed",
+			statuses: []map[string]interface{}{
+				nil,
+				{"is_sync_in_progress": true, "status_str": "In Progress", "sync_progress": 50},
+				{"is_sync_completed": true, "status_str": "Completed", "sync_progress": 100},
+			},
+		},
+		{
+			name: "Failed",
+			statuses: []map[string]interface{}{
+				{"is_sync_failed": true, "status_str": "Failed", "status_reason": "invalid package metadata"},
+			},
+			expected: "package sync failed: invalid package metadata",
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			requests := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				status := tc.statuses[requests]
+				requests++
+
+				w.Header().Set("Content-Type", "application/json")
+				if status == nil {
+					w.WriteHeader(http.StatusNotFound)
+					_, _ = w.Write([]byte(`{"detail": "Not found."}`))
+					return
+				}
+				_ = json.NewEncoder(w).Encode(status)
+			}))
+			defer server.Close()
+
+			pc := testProviderConfig(server.URL)
+
+			err := waitForPackageSync(context.Background(), pc, "namespace", "repository", "slug-perm", time.Minute)
+			if tc.expected == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+			} else if err == nil || !strings.Contains(err.Error(), tc.expected) {
+				t.Fatalf("expected error containing %q, got %v", tc.expected, err)
+			}
+			if requests != len(tc.statuses) {
+				t.Errorf("expected %d requests, got %d", len(tc.statuses), requests)
+			}
+		})
+	}
+}
+
+// TestWaitForPackageSync_cancelled checks that waiting stops when the context
+// is cancelled.
+func TestWaitForPackageSync_cancelled(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"is_sync_in_progress": true}`))
+	}))
+	defer server.Close()
+
+	pc := testProviderConfig(server.URL)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	if err := waitForPackageSync(ctx, pc, "namespace", "repository", "slug-perm", time.Minute); err == nil {
+		t.Fatal("expected an error when the context is cancelled")
+	}
+}
@@ -0,0 +1,154 @@
+package cloudsmith
+
+import (
+	"context"
+
+	"github.com/cloudsmith-io/cloudsmith-api-go"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourcePackageCopyCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	pc := m.(*providerConfig)
+
+	namespace := requiredString(d, "namespace")
+	sourceRepository := requiredString(d, "source_repository")
+	destinationRepository := requiredString(d, "destination_repository")
+	identifier := requiredString(d, "identifier")
+
+	req := pc.APIClient.PackagesApi.PackagesCopy(pc.Auth, namespace, sourceRepository, identifier)
+	req = req.Data(cloudsmith.PackageCopyRequest{
+		Destination: destinationRepository,
+		Republish:   optionalBool(d, "republish"),
+	})
+
+	pkg, _, err := pc.APIClient.PackagesApi.PackagesCopyExecute(req)
+	if err != nil {
+		return diag.Errorf("error copying package (%s) to %s: %s", identifier, destinationRepository, err)
+	}
+
+	d.SetId(pkg.GetSlugPerm())
+
+	if err := waitForPackageSync(ctx, pc, namespace, destinationRepository, d.Id(), defaultPackageSyncTimeout); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourcePackageCopyRead(ctx, d, m)
+}
+
+func resourcePackageCopyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	pc := m.(*providerConfig)
+
+	namespace := requiredString(d, "namespace")
+	destinationRepository := requiredString(d, "destination_repository")
+
+	// the copy is identified by its own slug_perm in the destination
+	// repository, so if it has been removed we plan to copy it again.
+	req := pc.APIClient.PackagesApi.PackagesRead(pc.Auth, namespace, destinationRepository, d.Id())
+	pkg, resp, err := pc.APIClient.PackagesApi.PackagesReadExecute(req)
+	if err != nil {
+		if is404(resp) {
+			d.SetId("")
+			return nil
+		}
+
+		return diag.FromErr(err)
+	}
+
+	d.Set("destination", pkg.GetSlugPerm())
+
+	// namespace, repositories and identifier are not returned from the
+	// package read endpoint, so we can use the values stored in resource
+	// state. We rely on ForceNew to ensure if any changes a new resource is
+	// created.
+	d.Set("namespace", namespace)
+	d.Set("destination_repository", destinationRepository)
+	d.Set("source_repository", requiredString(d, "source_repository"))
+	d.Set("identifier", requiredString(d, "identifier"))
+
+	return nil
+}
+
+// resourcePackageCopyUpdate only handles changes to delete_on_destroy, as
+// every other argument forces a new copy to be made.
+func resourcePackageCopyUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return resourcePackageCopyRead(ctx, d, m)
+}
+
+func resourcePackageCopyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	pc := m.(*providerConfig)
+
+	if !requiredBool(d, "delete_on_destroy") {
+		return nil
+	}
+
+	namespace := requiredString(d, "namespace")
+	destinationRepository := requiredString(d, "destination_repository")
+
+	req := pc.APIClient.PackagesApi.PackagesDelete(pc.Auth, namespace, destinationRepository, d.Id())
+	resp, err := pc.APIClient.PackagesApi.PackagesDeleteExecute(req)
+	if err != nil && !is404(resp) {
+		return diag.Errorf("error deleting package copy (%s): %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func resourcePackageCopy() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcePackageCopyCreate,
+		ReadContext:   resourcePackageCopyRead,
+		UpdateContext: resourcePackageCopyUpdate,
+		DeleteContext: resourcePackageCopyDelete,
+
+		Schema: map[string]*schema.Schema{
+			"delete_on_destroy": {
+				Type:        schema.TypeBool,
+				Description: "If true, the copied package is deleted from the destination repository on destroy.",
+				Optional:    true,
+				Default:     false,
+			},
+			"destination": {
+				Type:        schema.TypeString,
+				Description: "The slug_perm of the copied package in the destination repository.",
+				Computed:    true,
+			},
+			"destination_repository": {
+				Type:         schema.TypeString,
+				Description:  "Repository to which the package is copied.",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"identifier": {
+				Type:         schema.TypeString,
+				Description:  "The slug_perm of the package to copy.",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"namespace": {
+				Type:         schema.TypeString,
+				Description:  "Namespace to which both repositories belong.",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"republish": {
+				Type: schema.TypeBool,
+				Description: "If true, the copied package will overwrite any others with the same " +
+					"attributes (e.g. same version) in the destination repository.",
+				Optional: true,
+				ForceNew: true,
+			},
+			"source_repository": {
+				Type:         schema.TypeString,
+				Description:  "Repository containing the package to copy.",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+		},
+	}
+}
@@ -0,0 +1,102 @@
+//nolint:testpackage
+package cloudsmith
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// TestAccPackageCopy_basic spins up a source and destination repository,
+// uploads a raw package to the source and copies it to the destination,
+// verifying the copy exists before tearing down the resources and verifying
+// deletion.
+func TestAccPackageCopy_basic(t *testing.T) {
+	t.Parallel()
+
+	packageFile := filepath.Join(t.TempDir(), "terraform-acc-test-package-copy.txt")
+	if err := os.WriteFile(packageFile, []byte("terraform-acc-test-package-copy"), 0o600); err != nil {
+		t.Fatalf("unable to write package file: %s", err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccRepositoryCheckDestroy("cloudsmith_repository.destination"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPackageCopyConfig(packageFile),
+				Check: resource.ComposeTestCheckFunc(
+					testAccPackageCopyCheckExists("cloudsmith_package_copy.test"),
+					resource.TestCheckResourceAttrSet("cloudsmith_package_copy.test", "destination"),
+					resource.TestCheckResourceAttrPair(
+						"cloudsmith_package_copy.test", "destination_repository",
+						"cloudsmith_repository.destination", "slug_perm",
+					),
+				),
+			},
+		},
+	})
+}
+
+//nolint:goerr113
+func testAccPackageCopyCheckExists(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		resourceState, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", resourceName)
+		}
+
+		if resourceState.Primary.ID == "" {
+			return fmt.Errorf("resource id not set")
+		}
+
+		pc := testAccProvider.Meta().(*providerConfig)
+
+		namespace := os.Getenv("CLOUDSMITH_NAMESPACE")
+		repository := resourceState.Primary.Attributes["destination_repository"]
+		pkg := resourceState.Primary.ID
+
+		req := pc.APIClient.PackagesApi.PackagesRead(pc.Auth, namespace, repository, pkg)
+		_, resp, err := pc.APIClient.PackagesApi.PackagesReadExecute(req)
+		if err != nil {
+			return fmt.Errorf("unable to verify package copy existence: %w", err)
+		}
+		defer resp.Body.Close()
+
+		return nil
+	}
+}
+
+func testAccPackageCopyConfig(packageFile string) string {
+	return fmt.Sprintf(`
+resource "cloudsmith_repository" "source" {
+	name      = "terraform-acc-test-package-copy-src"
+	namespace = "%[1]s"
+}
+
+resource "cloudsmith_repository" "destination" {
+	name      = "terraform-acc-test-package-copy-dst"
+	namespace = "%[1]s"
+}
+
+resource "cloudsmith_package_upload" "test" {
+	namespace      = cloudsmith_repository.source.namespace
+	repository     = cloudsmith_repository.source.slug_perm
+	package_format = "raw"
+	package_file   = "%[2]s"
+}
+
+resource "cloudsmith_package_copy" "test" {
+	namespace              = cloudsmith_repository.source.namespace
+	source_repository      = cloudsmith_repository.source.slug_perm
+	destination_repository = cloudsmith_repository.destination.slug_perm
+	identifier             = cloudsmith_package_upload.test.slug_perm
+	delete_on_destroy      = true
+}
+`, os.Getenv("CLOUDSMITH_NAMESPACE"), packageFile)
+}
@@ -0,0 +1,76 @@
+package cloudsmith
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourcePackageDebCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	pc := m.(*providerConfig)
+
+	if err := createUploadedPackage(ctx, d, pc, "deb", requiredString(d, "package_file")); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourcePackageDebRead(ctx, d, m)
+}
+
+func resourcePackageDebRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	pc := m.(*providerConfig)
+
+	pkg, err := readUploadedPackage(d, pc)
+	if err != nil || pkg == nil {
+		return diag.FromErr(err)
+	}
+
+	// Debian packages are built for exactly one architecture, which is read
+	// from the control file of the package during synchronisation.
+	architecture := ""
+	if architectures := pkg.GetArchitectures(); len(architectures) > 0 {
+		architecture = architectures[0].GetName()
+	}
+	d.Set("architecture", architecture)
+
+	return nil
+}
+
+// resourcePackageDebUpdate only handles changes to sync_timeout, as every
+// other argument forces a new package to be uploaded.
+func resourcePackageDebUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return resourcePackageDebRead(ctx, d, m)
+}
+
+func resourcePackageDeb() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcePackageDebCreate,
+		ReadContext:   resourcePackageDebRead,
+		UpdateContext: resourcePackageDebUpdate,
+		DeleteContext: resourcePackageUploadDelete,
+
+		Schema: packageResourceSchema([]string{".deb"}, map[string]*schema.Schema{
+			"architecture": {
+				Type:        schema.TypeString,
+				Description: "The architecture the package was built for, e.g. `amd64`.",
+				Computed:    true,
+			},
+			"component": {
+				Type:         schema.TypeString,
+				Description:  "The component (channel) for the package, e.g. `main`.",
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^[-_.\w]+$`), "must be a valid component name"),
+			},
+			"distribution": {
+				Type:         schema.TypeString,
+				Description:  "The distribution to store the package for, e.g. `ubuntu/focal`.",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+		}),
+	}
+}
@@ -0,0 +1,137 @@
+//nolint:testpackage
+package cloudsmith
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// TestAccPackageDeb_basic spins up a repository, uploads a minimal Debian
+// package built by the test and verifies the attributes read from its control
+// file. Then it changes the package version, which forces a new upload, before
+// tearing down the resources and verifying deletion.
+func TestAccPackageDeb_basic(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	initialFile := writeTestDebPackage(t, dir, "terraform-acc-test-deb", "1.0.0")
+	updatedFile := writeTestDebPackage(t, dir, "terraform-acc-test-deb", "1.0.1")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccPackageUploadCheckDestroy("cloudsmith_deb_package.test"),
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccPackageDebConfig(filepath.Join(dir, "package.tar.gz")),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("must be a path to a .deb file"),
+			},
+			{
+				Config: testAccPackageDebConfig(initialFile),
+				Check: resource.ComposeTestCheckFunc(
+					testAccPackageUploadCheckExists("cloudsmith_deb_package.test"),
+					resource.TestCheckResourceAttr("cloudsmith_deb_package.test", "name", "terraform-acc-test-deb"),
+					resource.TestCheckResourceAttr("cloudsmith_deb_package.test", "version", "1.0.0"),
+					resource.TestCheckResourceAttr("cloudsmith_deb_package.test", "architecture", "all"),
+					resource.TestCheckResourceAttrSet("cloudsmith_deb_package.test", "checksum_sha256"),
+					resource.TestCheckResourceAttrSet("cloudsmith_deb_package.test", "slug_perm"),
+				),
+			},
+			{
+				Config: testAccPackageDebConfig(updatedFile),
+				Check: resource.ComposeTestCheckFunc(
+					testAccPackageUploadCheckExists("cloudsmith_deb_package.test"),
+					resource.TestCheckResourceAttr("cloudsmith_deb_package.test", "version", "1.0.1"),
+				),
+			},
+		},
+	})
+}
+
+// writeTestDebPackage writes a minimal, architecture-independent Debian
+// package with the given name and version to dir, returning its path.
+func writeTestDebPackage(t *testing.T, dir, name, version string) string {
+	t.Helper()
+
+	control := fmt.Sprintf(
+		"Package: %s\nVersion: %s\nArchitecture: all\nMaintainer: Terraform <terraform@example.com>\nDescription: Terraform acceptance test package\n",
+		name, version,
+	)
+
+	members := []struct {
+		name string
+		data []byte
+	}{
+		{name: "debian-binary", data: []byte("2.0\n")},
+		{name: "control.tar.gz", data: testTarGz(t, map[string]string{"./control": control})},
+		{name: "data.tar.gz", data: testTarGz(t, map[string]string{})},
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("!<arch>\n")
+	for _, member := range members {
+		fmt.Fprintf(&buf, "%-16s%-12d%-6d%-6d%-8s%-10d`\n", member.name, 0, 0, 0, "100644", len(member.data))
+		buf.Write(member.data)
+		if len(member.data)%2 != 0 {
+			buf.WriteByte('\n')
+		}
+	}
+
+	path := filepath.Join(dir, fmt.Sprintf("%s_%s_all.deb", name, version))
+	if err := os.WriteFile(path, buf.Bytes(), 0o600); err != nil {
+		t.Fatalf("unable to write package file: %s", err)
+	}
+
+	return path
+}
+
+// testTarGz returns a gzipped tarball containing the given files.
+func testTarGz(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gw)
+	for name, content := range files {
+		header := &tar.Header{Name: name, Mode: 0o644, Size: int64(len(content))}
+		if err := tw.WriteHeader(header); err != nil {
+			t.Fatalf("unable to write tar header: %s", err)
+		}
+		if _, err := tw.Write([]byte(content)); err != nil {
+			t.Fatalf("unable to write tar content: %s", err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatalf("unable to close tar writer: %s", err)
+	}
+	if err := gw.Close(); err != nil {
+		t.Fatalf("unable to close gzip writer: %s", err)
+	}
+
+	return buf.Bytes()
+}
+
+func testAccPackageDebConfig(packageFile string) string {
+	return fmt.Sprintf(`
+resource "cloudsmith_repository" "test" {
+	name      = "terraform-acc-test-deb-package"
+	namespace = "%s"
+}
+
+resource "cloudsmith_deb_package" "test" {
+	namespace    = "${cloudsmith_repository.test.namespace}"
+	repository   = "${cloudsmith_repository.test.slug_perm}"
+	distribution = "any-distro/any-version"
+	package_file = "%s"
+}
+`, os.Getenv("CLOUDSMITH_NAMESPACE"), packageFile)
+}
@@ -0,0 +1,191 @@
+package cloudsmith
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	defaultDockerHost     = "unix:///var/run/docker.sock"
+	defaultDockerImageTag = "latest"
+)
+
+// dockerHost returns the address of the Docker daemon, honouring the same
+// DOCKER_HOST environment variable as the Docker CLI.
+func dockerHost() string {
+	if host := os.Getenv("DOCKER_HOST"); host != "" {
+		return host
+	}
+	return defaultDockerHost
+}
+
+// exportDockerImage saves an image from the Docker daemon at host to a tarball
+// at dest, equivalent to running `docker save`.
+func exportDockerImage(ctx context.Context, host, image, dest string) error {
+	u, err := url.Parse(host)
+	if err != nil {
+		return fmt.Errorf("invalid docker host %s: %w", host, err)
+	}
+
+	client := &http.Client{}
+	baseURL := "http://" + u.Host
+	switch u.Scheme {
+	case "unix":
+		socket := u.Path
+		client.Transport = &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				var dialer net.Dialer
+				return dialer.DialContext(ctx, "unix", socket)
+			},
+		}
+		baseURL = "http://docker"
+	case "tcp", "http":
+	default:
+		return fmt.Errorf("unsupported docker host %s: scheme must be one of unix, tcp or http", host)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL+"/images/get?"+url.Values{"names": {image}}.Encode(), nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("error exporting image %s from docker daemon: %w", image, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("error exporting image %s from docker daemon: status code: %d: %s", image, resp.StatusCode, message)
+	}
+
+	file, err := os.Create(dest)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if _, err := io.Copy(file, resp.Body); err != nil {
+		return fmt.Errorf("error exporting image %s from docker daemon: %w", image, err)
+	}
+
+	return file.Close()
+}
+
+func resourcePackageDockerCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	pc := m.(*providerConfig)
+
+	packageFile := requiredString(d, "package_file")
+	if requiredBool(d, "from_docker_daemon") {
+		dir, err := os.MkdirTemp("", "terraform-provider-cloudsmith-")
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		defer os.RemoveAll(dir)
+
+		tag := requiredString(d, "image_tag")
+		if tag == "" {
+			tag = defaultDockerImageTag
+			d.Set("image_tag", tag)
+		}
+
+		packageFile = filepath.Join(dir, "image.tar")
+		image := fmt.Sprintf("%s:%s", requiredString(d, "image_name"), tag)
+		if err := exportDockerImage(ctx, dockerHost(), image, packageFile); err != nil {
+			return diag.FromErr(err)
+		}
+	} else if packageFile == "" {
+		return diag.Errorf("package_file must be set unless from_docker_daemon is true")
+	}
+
+	if err := createUploadedPackage(ctx, d, pc, "docker", packageFile); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if diags := resourcePackageDockerRead(ctx, d, m); diags.HasError() {
+		return diags
+	}
+
+	// When uploading a tarball the image name and tag are read from the
+	// package, but they're never overwritten on read so that configured values
+	// don't cause the package to be replaced.
+	if requiredString(d, "image_name") == "" {
+		d.Set("image_name", requiredString(d, "name"))
+	}
+	if requiredString(d, "image_tag") == "" {
+		d.Set("image_tag", requiredString(d, "version"))
+	}
+
+	return nil
+}
+
+func resourcePackageDockerRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	pc := m.(*providerConfig)
+
+	_, err := readUploadedPackage(d, pc)
+	return diag.FromErr(err)
+}
+
+// resourcePackageDockerUpdate only handles changes to sync_timeout, as every
+// other argument forces a new package to be uploaded.
+func resourcePackageDockerUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return resourcePackageDockerRead(ctx, d, m)
+}
+
+//nolint:funlen
+func resourcePackageDocker() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcePackageDockerCreate,
+		ReadContext:   resourcePackageDockerRead,
+		UpdateContext: resourcePackageDockerUpdate,
+		DeleteContext: resourcePackageUploadDelete,
+
+		Schema: packageResourceSchema(nil, map[string]*schema.Schema{
+			"from_docker_daemon": {
+				Type: schema.TypeBool,
+				Description: "If true, the image identified by image_name and image_tag is exported from " +
+					"the local Docker daemon and uploaded, instead of package_file.",
+				Optional:     true,
+				ForceNew:     true,
+				RequiredWith: []string{"image_name"},
+			},
+			"image_name": {
+				Type: schema.TypeString,
+				Description: "The name of the image. Required when from_docker_daemon is true, otherwise " +
+					"it is read from the package file if not set.",
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"image_tag": {
+				Type: schema.TypeString,
+				Description: "The tag of the image. When from_docker_daemon is true this defaults to " +
+					"`latest`, otherwise it is read from the package file if not set.",
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"package_file": {
+				Type:          schema.TypeString,
+				Description:   "Path to the local .tar file, as exported by `docker save`, to upload.",
+				Optional:      true,
+				ForceNew:      true,
+				ValidateFunc:  packageFileValidateFunc(".tar"),
+				ConflictsWith: []string{"from_docker_daemon"},
+			},
+		}),
+	}
+}
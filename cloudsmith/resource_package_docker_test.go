@@ -0,0 +1,108 @@
+//nolint:testpackage
+package cloudsmith
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// TestAccPackageDocker_basic verifies that a Docker package can't be
+// configured with both a package file and the Docker daemon as its source.
+// Uploading an image requires a valid image tarball, which is covered by the
+// daemon export tests below rather than an acceptance test.
+func TestAccPackageDocker_basic(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "cloudsmith_docker_package" "test" {
+	namespace          = "%s"
+	repository         = "terraform-acc-test-docker-package"
+	package_file       = "image.tar"
+	from_docker_daemon = true
+	image_name         = "alpine"
+}
+`, os.Getenv("CLOUDSMITH_NAMESPACE")),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`"package_file": conflicts with from_docker_daemon`),
+			},
+		},
+	})
+}
+
+// TestExportDockerImage serves the image export endpoint of the Docker Engine
+// API over both TCP and a unix socket and verifies the image is written to the
+// destination file, and that daemon errors are surfaced.
+func TestExportDockerImage(t *testing.T) {
+	t.Parallel()
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/images/get" {
+			http.NotFound(w, r)
+			return
+		}
+		if image := r.URL.Query().Get("names"); image != "example/image:1.0" {
+			http.Error(w, fmt.Sprintf(`{"message":"No such image: %s"}`, image), http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte("image-tarball"))
+	})
+
+	tcpServer := httptest.NewServer(handler)
+	defer tcpServer.Close()
+
+	// Unix socket paths are limited in length, so the socket is created in a
+	// short temporary directory rather than t.TempDir().
+	socketDir, err := os.MkdirTemp("", "docker")
+	if err != nil {
+		t.Fatalf("unable to create socket directory: %s", err)
+	}
+	defer os.RemoveAll(socketDir)
+	socket := filepath.Join(socketDir, "docker.sock")
+	listener, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Fatalf("unable to listen on unix socket: %s", err)
+	}
+	unixServer := httptest.NewUnstartedServer(handler)
+	unixServer.Listener = listener
+	unixServer.Start()
+	defer unixServer.Close()
+
+	hosts := []string{
+		strings.Replace(tcpServer.URL, "http://", "tcp://", 1),
+		"unix://" + socket,
+	}
+
+	for _, host := range hosts {
+		dest := filepath.Join(t.TempDir(), "image.tar")
+		if err := exportDockerImage(context.Background(), host, "example/image:1.0", dest); err != nil {
+			t.Fatalf("%s: unexpected error: %s", host, err)
+		}
+		if err := checkFileContent(dest, "image-tarball"); err != nil {
+			t.Fatalf("%s: %s", host, err)
+		}
+
+		err := exportDockerImage(context.Background(), host, "example/missing:1.0", dest)
+		if err == nil || !strings.Contains(err.Error(), "No such image") {
+			t.Fatalf("%s: expected missing image error, got %v", host, err)
+		}
+	}
+
+	if err := exportDockerImage(context.Background(), "ssh://docker", "example/image:1.0", ""); err == nil {
+		t.Fatal("expected unsupported docker host error")
+	}
+}
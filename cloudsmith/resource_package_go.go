@@ -0,0 +1,170 @@
+package cloudsmith
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// goModuleVersionRegexp matches the semantic versions used by Go modules.
+var goModuleVersionRegexp = regexp.MustCompile(`^v\d+\.\d+\.\d+(-[0-9A-Za-z.-]+)?(\+[0-9A-Za-z.-]+)?$`)
+
+// escapeGoModulePath escapes a module path for use in a module proxy URL,
+// replacing each upper case letter with an exclamation mark followed by the
+// lower case letter, as case-insensitive file systems can't otherwise tell
+// module paths apart.
+func escapeGoModulePath(modulePath string) string {
+	var b strings.Builder
+	for _, r := range modulePath {
+		if unicode.IsUpper(r) {
+			b.WriteByte('!')
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// goModFileModulePath returns the module path declared by the module
+// directive of a go.mod file.
+func goModFileModulePath(content []byte) string {
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) >= 2 && fields[0] == "module" {
+			return strings.Trim(fields[1], `"`+"`")
+		}
+	}
+	return ""
+}
+
+// validateGoModule checks that a module zip, as served by a module proxy,
+// contains the go.mod of the given module at the expected path, and that it
+// matches modFile if one is given.
+func validateGoModule(zipFile, modFile, modulePath, version string) error {
+	expectedPath := fmt.Sprintf("%s@%s/go.mod", modulePath, version)
+	goMod, err := readZipEntry(zipFile, func(name string) bool {
+		return name == expectedPath
+	})
+	if err != nil {
+		return fmt.Errorf("invalid go module %s: error reading %s: %w", zipFile, expectedPath, err)
+	}
+
+	if declared := goModFileModulePath(goMod); declared != modulePath {
+		return fmt.Errorf("invalid go module %s: go.mod declares module %q, expected %q", zipFile, declared, modulePath)
+	}
+
+	if modFile != "" {
+		content, err := os.ReadFile(modFile)
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(content, goMod) {
+			return fmt.Errorf("invalid go module %s: %s does not match the go.mod in the module zip", zipFile, modFile)
+		}
+	}
+
+	return nil
+}
+
+func resourcePackageGoCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	pc := m.(*providerConfig)
+
+	zipFile := requiredString(d, "zip_file")
+	modulePath := requiredString(d, "module_path")
+	version := requiredString(d, "version")
+
+	if err := validateGoModule(zipFile, requiredString(d, "mod_file"), modulePath, version); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := createUploadedPackage(ctx, d, pc, "go", zipFile); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourcePackageGoRead(ctx, d, m)
+}
+
+func resourcePackageGoRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	pc := m.(*providerConfig)
+
+	// The module version is configured rather than read from the package file,
+	// so the configured value is kept in case the API normalises it.
+	version := requiredString(d, "version")
+
+	pkg, err := readUploadedPackage(d, pc)
+	if err != nil || pkg == nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("version", version)
+	d.Set("proxy_url", fmt.Sprintf(
+		"https://dl.cloudsmith.io/basic/%s/%s/go/%s/@v/%s.zip",
+		pkg.GetNamespace(), pkg.GetRepository(), escapeGoModulePath(requiredString(d, "module_path")), version,
+	))
+
+	return nil
+}
+
+// resourcePackageGoUpdate only handles changes to sync_timeout, as every
+// other argument forces a new module to be uploaded.
+func resourcePackageGoUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return resourcePackageGoRead(ctx, d, m)
+}
+
+//nolint:funlen
+func resourcePackageGo() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcePackageGoCreate,
+		ReadContext:   resourcePackageGoRead,
+		UpdateContext: resourcePackageGoUpdate,
+		DeleteContext: resourcePackageUploadDelete,
+
+		Schema: packageResourceSchema(nil, map[string]*schema.Schema{
+			"mod_file": {
+				Type: schema.TypeString,
+				Description: "Path to the local .mod file of the module. If set, it must match the go.mod " +
+					"contained in zip_file.",
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: packageFileValidateFunc(".mod"),
+			},
+			"module_path": {
+				Type:         schema.TypeString,
+				Description:  "The path of the module, e.g. `example.com/my/module`.",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"package_file": nil,
+			"proxy_url": {
+				Type:        schema.TypeString,
+				Description: "The URL from which the module zip can be downloaded through the Cloudsmith module proxy.",
+				Computed:    true,
+			},
+			"version": {
+				Type:         schema.TypeString,
+				Description:  "The version of the module, e.g. `v1.0.0`.",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringMatch(goModuleVersionRegexp, "must be a semantic version prefixed with v, e.g. v1.0.0"),
+			},
+			"zip_file": {
+				Type:         schema.TypeString,
+				Description:  "Path to the local .zip file of the module, in the format served by a module proxy.",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: packageFileValidateFunc(".zip"),
+			},
+		}),
+	}
+}
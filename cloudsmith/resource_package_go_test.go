@@ -0,0 +1,132 @@
+//nolint:testpackage
+package cloudsmith
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+const testGoModulePath = "example.com/TerraformAccTest/module"
+
+// TestAccPackageGo_basic spins up a repository, uploads a minimal Go module
+// zip built by the test and verifies the module proxy URL, before tearing down
+// the resources and verifying deletion.
+func TestAccPackageGo_basic(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	zipFile := filepath.Join(dir, "v1.0.0.zip")
+	goMod := fmt.Sprintf("module %s\n\ngo 1.19\n", testGoModulePath)
+	content := testZip(t, map[string]string{
+		testGoModulePath + "@v1.0.0/go.mod":  goMod,
+		testGoModulePath + "@v1.0.0/main.go": "package module\n",
+	})
+	if err := os.WriteFile(zipFile, content, 0o600); err != nil {
+		t.Fatalf("unable to write module zip: %s", err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccPackageUploadCheckDestroy("cloudsmith_go_module.test"),
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccPackageGoConfig(zipFile, "1.0.0"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("must be a semantic version prefixed with v"),
+			},
+			{
+				Config: testAccPackageGoConfig(zipFile, "v1.0.0"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccPackageUploadCheckExists("cloudsmith_go_module.test"),
+					resource.TestCheckResourceAttr("cloudsmith_go_module.test", "version", "v1.0.0"),
+					resource.TestMatchResourceAttr("cloudsmith_go_module.test", "proxy_url", regexp.MustCompile(
+						`^https://dl\.cloudsmith\.io/basic/.+/go/example\.com/!terraform!acc!test/module/@v/v1\.0\.0\.zip$`,
+					)),
+				),
+			},
+		},
+	})
+}
+
+func TestEscapeGoModulePath(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"github.com/BurntSushi/toml": "github.com/!burnt!sushi/toml",
+		"example.com/module":         "example.com/module",
+	}
+
+	for modulePath, expected := range tests {
+		if actual := escapeGoModulePath(modulePath); actual != expected {
+			t.Errorf("%s: expected %q, got %q", modulePath, expected, actual)
+		}
+	}
+}
+
+func TestValidateGoModule(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	goMod := "module example.com/module\n\ngo 1.19\n"
+
+	modFile := filepath.Join(dir, "v1.0.0.mod")
+	if err := os.WriteFile(modFile, []byte(goMod), 0o600); err != nil {
+		t.Fatalf("unable to write mod file: %s", err)
+	}
+	otherModFile := filepath.Join(dir, "other.mod")
+	if err := os.WriteFile(otherModFile, []byte("module example.com/module\n"), 0o600); err != nil {
+		t.Fatalf("unable to write mod file: %s", err)
+	}
+
+	tests := []struct {
+		name    string
+		files   map[string]string
+		modFile string
+		err     string
+	}{
+		{name: "valid", files: map[string]string{"example.com/module@v1.0.0/go.mod": goMod}, modFile: modFile},
+		{name: "wrong-path", files: map[string]string{"module/go.mod": goMod}, err: "error reading example.com/module@v1.0.0/go.mod"},
+		{name: "wrong-module", files: map[string]string{"example.com/module@v1.0.0/go.mod": "module example.com/other\n"}, err: `declares module "example.com/other"`},
+		{name: "mod-mismatch", files: map[string]string{"example.com/module@v1.0.0/go.mod": goMod}, modFile: otherModFile, err: "does not match the go.mod"},
+	}
+
+	for _, tt := range tests {
+		zipFile := filepath.Join(dir, tt.name+".zip")
+		if err := os.WriteFile(zipFile, testZip(t, tt.files), 0o600); err != nil {
+			t.Fatalf("unable to write module zip: %s", err)
+		}
+
+		err := validateGoModule(zipFile, tt.modFile, "example.com/module", "v1.0.0")
+		if tt.err == "" {
+			if err != nil {
+				t.Errorf("%s: unexpected error: %s", tt.name, err)
+			}
+		} else if err == nil || !strings.Contains(err.Error(), tt.err) {
+			t.Errorf("%s: expected error containing %q, got %v", tt.name, tt.err, err)
+		}
+	}
+}
+
+func testAccPackageGoConfig(zipFile, version string) string {
+	return fmt.Sprintf(`
+resource "cloudsmith_repository" "test" {
+	name      = "terraform-acc-test-go-module"
+	namespace = "%s"
+}
+
+resource "cloudsmith_go_module" "test" {
+	namespace   = "${cloudsmith_repository.test.namespace}"
+	repository  = "${cloudsmith_repository.test.slug_perm}"
+	module_path = "%s"
+	version     = "%s"
+	zip_file    = "%s"
+}
+`, os.Getenv("CLOUDSMITH_NAMESPACE"), testGoModulePath, version, zipFile)
+}
@@ -0,0 +1,141 @@
+package cloudsmith
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// helmChart holds the fields of a Chart.yaml that are exposed by the helm
+// package resource.
+type helmChart struct {
+	Name       string
+	Version    string
+	AppVersion string
+}
+
+// parseChartYAML reads the top-level scalar fields of a Chart.yaml. Only the
+// fields needed to validate a chart are read, so nested values (such as
+// dependencies and maintainers) are skipped rather than parsed.
+func parseChartYAML(content []byte) helmChart {
+	fields := map[string]string{}
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" || line[0] == ' ' || line[0] == '\t' || line[0] == '#' || line[0] == '-' {
+			continue
+		}
+
+		key, value, found := strings.Cut(line, ":")
+		if !found {
+			continue
+		}
+		if i := strings.Index(value, " #"); i >= 0 {
+			value = value[:i]
+		}
+		fields[strings.TrimSpace(key)] = strings.Trim(strings.TrimSpace(value), `"'`)
+	}
+
+	return helmChart{
+		Name:       fields["name"],
+		Version:    fields["version"],
+		AppVersion: fields["appVersion"],
+	}
+}
+
+// readHelmChart reads the Chart.yaml from a chart archive, as created by
+// `helm package`, returning an error if the archive is not a valid chart.
+func readHelmChart(filePath string) (helmChart, error) {
+	// helm package places the chart in a single top-level directory named
+	// after the chart.
+	content, err := readTarGzEntry(filePath, func(name string) bool {
+		parts := strings.Split(strings.TrimPrefix(name, "./"), "/")
+		return len(parts) == 2 && parts[1] == "Chart.yaml"
+	})
+	if err != nil {
+		return helmChart{}, fmt.Errorf("invalid helm chart %s: error reading Chart.yaml: %w", filePath, err)
+	}
+
+	chart := parseChartYAML(content)
+	if chart.Name == "" || chart.Version == "" {
+		return chart, fmt.Errorf("invalid helm chart %s: Chart.yaml must contain a name and version", filePath)
+	}
+
+	return chart, nil
+}
+
+// helmRepositoryURL returns the URL of the chart repository containing a
+// chart, for use with `helm repo add`, given the chart's download URL.
+func helmRepositoryURL(cdnURL string) string {
+	i := strings.LastIndex(cdnURL, "/")
+	if i < 0 {
+		return ""
+	}
+	return cdnURL[:i+1]
+}
+
+func resourcePackageHelmCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	pc := m.(*providerConfig)
+
+	chart, err := readHelmChart(requiredString(d, "package_file"))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := createUploadedPackage(ctx, d, pc, "helm", requiredString(d, "package_file")); err != nil {
+		return diag.FromErr(err)
+	}
+
+	// The package API doesn't return the app version of a chart, so it's taken
+	// from the Chart.yaml that was uploaded.
+	d.Set("app_version", chart.AppVersion)
+
+	return resourcePackageHelmRead(ctx, d, m)
+}
+
+func resourcePackageHelmRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	pc := m.(*providerConfig)
+
+	pkg, err := readUploadedPackage(d, pc)
+	if err != nil || pkg == nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("helm_repository_url", helmRepositoryURL(pkg.GetCdnUrl()))
+
+	return nil
+}
+
+// resourcePackageHelmUpdate only handles changes to sync_timeout, as every
+// other argument forces a new package to be uploaded.
+func resourcePackageHelmUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return resourcePackageHelmRead(ctx, d, m)
+}
+
+func resourcePackageHelm() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcePackageHelmCreate,
+		ReadContext:   resourcePackageHelmRead,
+		UpdateContext: resourcePackageHelmUpdate,
+		DeleteContext: resourcePackageUploadDelete,
+
+		Schema: packageResourceSchema([]string{".tgz"}, map[string]*schema.Schema{
+			"app_version": {
+				Type:        schema.TypeString,
+				Description: "The version of the application packaged by the chart, as read from its Chart.yaml.",
+				Computed:    true,
+			},
+			"helm_repository_url": {
+				Type:        schema.TypeString,
+				Description: "The URL of the chart repository containing the chart, for use with `helm repo add`.",
+				Computed:    true,
+			},
+		}),
+	}
+}
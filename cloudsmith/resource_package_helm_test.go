@@ -0,0 +1,118 @@
+//nolint:testpackage
+package cloudsmith
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+const testChartYAML = `# Chart for Terraform acceptance tests
+apiVersion: v2
+name: terraform-acc-test-helm
+description: "A chart: for testing"
+version: 1.0.0 # the chart version
+appVersion: "2.3.4"
+maintainers:
+  - name: Terraform
+    email: terraform@example.com
+`
+
+// TestAccPackageHelm_basic spins up a repository, uploads a minimal Helm chart
+// built by the test and verifies the attributes read from its Chart.yaml,
+// before tearing down the resources and verifying deletion.
+func TestAccPackageHelm_basic(t *testing.T) {
+	t.Parallel()
+
+	packageFile := filepath.Join(t.TempDir(), "terraform-acc-test-helm-1.0.0.tgz")
+	content := testTarGz(t, map[string]string{"terraform-acc-test-helm/Chart.yaml": testChartYAML})
+	if err := os.WriteFile(packageFile, content, 0o600); err != nil {
+		t.Fatalf("unable to write package file: %s", err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccPackageUploadCheckDestroy("cloudsmith_helm_package.test"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPackageHelmConfig(packageFile),
+				Check: resource.ComposeTestCheckFunc(
+					testAccPackageUploadCheckExists("cloudsmith_helm_package.test"),
+					resource.TestCheckResourceAttr("cloudsmith_helm_package.test", "name", "terraform-acc-test-helm"),
+					resource.TestCheckResourceAttr("cloudsmith_helm_package.test", "version", "1.0.0"),
+					resource.TestCheckResourceAttr("cloudsmith_helm_package.test", "app_version", "2.3.4"),
+					resource.TestMatchResourceAttr("cloudsmith_helm_package.test", "helm_repository_url", regexp.MustCompile(`^https://.+/$`)),
+				),
+			},
+		},
+	})
+}
+
+func TestReadHelmChart(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	tests := []struct {
+		name    string
+		content []byte
+		err     string
+	}{
+		{name: "valid", content: testTarGz(t, map[string]string{"chart/Chart.yaml": testChartYAML})},
+		{name: "no-version", content: testTarGz(t, map[string]string{"chart/Chart.yaml": "apiVersion: v2\nname: chart\n"}), err: "must contain a name and version"},
+		{name: "nested", content: testTarGz(t, map[string]string{"chart/charts/dep/Chart.yaml": testChartYAML}), err: "file not found in archive"},
+	}
+
+	for _, tt := range tests {
+		packageFile := filepath.Join(dir, tt.name+".tgz")
+		if err := os.WriteFile(packageFile, tt.content, 0o600); err != nil {
+			t.Fatalf("unable to write package file: %s", err)
+		}
+
+		chart, err := readHelmChart(packageFile)
+		if tt.err == "" {
+			if err != nil {
+				t.Errorf("%s: unexpected error: %s", tt.name, err)
+			} else if chart != (helmChart{Name: "terraform-acc-test-helm", Version: "1.0.0", AppVersion: "2.3.4"}) {
+				t.Errorf("%s: unexpected chart %+v", tt.name, chart)
+			}
+		} else if err == nil || !strings.Contains(err.Error(), tt.err) {
+			t.Errorf("%s: expected error containing %q, got %v", tt.name, tt.err, err)
+		}
+	}
+}
+
+func TestHelmRepositoryURL(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"https://dl.cloudsmith.io/public/ns/repo/helm/charts/chart-1.0.0.tgz": "https://dl.cloudsmith.io/public/ns/repo/helm/charts/",
+		"": "",
+	}
+
+	for cdnURL, expected := range tests {
+		if actual := helmRepositoryURL(cdnURL); actual != expected {
+			t.Errorf("%s: expected %q, got %q", cdnURL, expected, actual)
+		}
+	}
+}
+
+func testAccPackageHelmConfig(packageFile string) string {
+	return fmt.Sprintf(`
+resource "cloudsmith_repository" "test" {
+	name      = "terraform-acc-test-helm-package"
+	namespace = "%s"
+}
+
+resource "cloudsmith_helm_package" "test" {
+	namespace    = "${cloudsmith_repository.test.namespace}"
+	repository   = "${cloudsmith_repository.test.slug_perm}"
+	package_file = "%s"
+}
+`, os.Getenv("CLOUDSMITH_NAMESPACE"), packageFile)
+}
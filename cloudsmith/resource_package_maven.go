@@ -0,0 +1,218 @@
+package cloudsmith
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/cloudsmith-io/cloudsmith-api-go"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const defaultMavenPackaging = "jar"
+
+// mavenPOM is a minimal Maven POM, containing only the coordinates of an
+// artifact, used when a package is uploaded without its own POM file.
+type mavenPOM struct {
+	XMLName      xml.Name `xml:"project"`
+	Xmlns        string   `xml:"xmlns,attr"`
+	ModelVersion string   `xml:"modelVersion"`
+	GroupID      string   `xml:"groupId"`
+	ArtifactID   string   `xml:"artifactId"`
+	Version      string   `xml:"version"`
+	Packaging    string   `xml:"packaging"`
+}
+
+// writeMavenPOM generates a minimal POM for the given coordinates in dir,
+// returning the path of the generated file.
+func writeMavenPOM(dir, groupID, artifactID, version, packaging string) (string, error) {
+	content, err := xml.MarshalIndent(mavenPOM{
+		Xmlns:        "http://maven.apache.org/POM/4.0.0",
+		ModelVersion: "4.0.0",
+		GroupID:      groupID,
+		ArtifactID:   artifactID,
+		Version:      version,
+		Packaging:    packaging,
+	}, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	path := filepath.Join(dir, fmt.Sprintf("%s-%s.pom", artifactID, version))
+	if err := os.WriteFile(path, append([]byte(xml.Header), content...), 0o600); err != nil {
+		return "", err
+	}
+
+	return path, nil
+}
+
+// mavenArtifactFilename returns the filename Maven uses for an artifact, which
+// is how the classifier of an artifact is conveyed. If the coordinates aren't
+// known the local filename is used as is.
+func mavenArtifactFilename(packageFile, artifactID, version, classifier string) string {
+	if artifactID == "" || version == "" {
+		return filepath.Base(packageFile)
+	}
+
+	filename := fmt.Sprintf("%s-%s", artifactID, version)
+	if classifier != "" {
+		filename += "-" + classifier
+	}
+
+	return filename + filepath.Ext(packageFile)
+}
+
+func resourcePackageMavenCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	pc := m.(*providerConfig)
+
+	namespace := requiredString(d, "namespace")
+	repository := requiredString(d, "repository")
+	packageFile := requiredString(d, "package_file")
+	pomFile := requiredString(d, "pom_file")
+	groupID := requiredString(d, "group_id")
+	artifactID := requiredString(d, "artifact_id")
+	version := requiredString(d, "version")
+
+	if pomFile == "" {
+		if groupID == "" || artifactID == "" || version == "" {
+			return diag.Errorf("group_id, artifact_id and version must be set when pom_file is not provided")
+		}
+
+		dir, err := os.MkdirTemp("", "terraform-provider-cloudsmith-")
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		defer os.RemoveAll(dir)
+
+		packaging := requiredString(d, "packaging")
+		if packaging == "" {
+			packaging = defaultMavenPackaging
+		}
+
+		pomFile, err = writeMavenPOM(dir, groupID, artifactID, version, packaging)
+		if err != nil {
+			return diag.Errorf("error generating pom file: %s", err)
+		}
+	}
+
+	filename := mavenArtifactFilename(packageFile, artifactID, version, requiredString(d, "classifier"))
+	fileID, err := uploadPackageFileAs(ctx, pc, namespace, repository, packageFile, filename)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	pomFileID, err := uploadPackageFile(ctx, pc, namespace, repository, pomFile)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	req := pc.APIClient.PackagesApi.PackagesUploadMaven(pc.Auth, namespace, repository)
+	req = req.Data(cloudsmith.MavenPackageUploadRequest{
+		ArtifactId:  nullableString(d, "artifact_id"),
+		GroupId:     nullableString(d, "group_id"),
+		PackageFile: fileID,
+		Packaging:   nullableString(d, "packaging"),
+		PomFile:     *cloudsmith.NewNullableString(&pomFileID),
+		Republish:   optionalBool(d, "republish"),
+		Version:     nullableString(d, "version"),
+	})
+	pkg, _, err := pc.APIClient.PackagesApi.PackagesUploadMavenExecute(req)
+	if err != nil {
+		return diag.Errorf("error creating maven package: %s", err)
+	}
+
+	d.SetId(pkg.GetSlugPerm())
+
+	timeout := time.Duration(d.Get("sync_timeout").(int)) * time.Second
+	if err := waitForPackageSync(ctx, pc, namespace, repository, d.Id(), timeout); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourcePackageMavenRead(ctx, d, m)
+}
+
+func resourcePackageMavenRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	pc := m.(*providerConfig)
+
+	pkg, err := readUploadedPackage(d, pc)
+	if err != nil || pkg == nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("checksum_sha1", pkg.GetChecksumSha1())
+
+	return nil
+}
+
+// resourcePackageMavenUpdate only handles changes to sync_timeout, as every
+// other argument forces a new package to be uploaded.
+func resourcePackageMavenUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return resourcePackageMavenRead(ctx, d, m)
+}
+
+//nolint:funlen
+func resourcePackageMaven() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcePackageMavenCreate,
+		ReadContext:   resourcePackageMavenRead,
+		UpdateContext: resourcePackageMavenUpdate,
+		DeleteContext: resourcePackageUploadDelete,
+
+		Schema: packageResourceSchema([]string{".jar"}, map[string]*schema.Schema{
+			"artifact_id": {
+				Type:         schema.TypeString,
+				Description:  "The artifact ID of the package. Required if pom_file is not set.",
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"checksum_sha1": {
+				Type:        schema.TypeString,
+				Description: "SHA1 hash of the package.",
+				Computed:    true,
+			},
+			"classifier": {
+				Type:         schema.TypeString,
+				Description:  "The classifier of the artifact, e.g. `sources`. Requires artifact_id and version.",
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+				RequiredWith: []string{"artifact_id", "version"},
+			},
+			"group_id": {
+				Type:         schema.TypeString,
+				Description:  "The group ID of the package. Required if pom_file is not set.",
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"packaging": {
+				Type:         schema.TypeString,
+				Description:  "The Maven packaging type of the package, e.g. `jar`.",
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"pom_file": {
+				Type: schema.TypeString,
+				Description: "Path to the local .pom file describing the package. If not set, a minimal POM " +
+					"is generated from group_id, artifact_id, version and packaging.",
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: packageFileValidateFunc(".pom"),
+			},
+			"version": {
+				Type:        schema.TypeString,
+				Description: "The version of the package. Required if pom_file is not set.",
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
+		}),
+	}
+}
@@ -0,0 +1,105 @@
+//nolint:testpackage
+package cloudsmith
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// TestAccPackageMaven_basic spins up a repository, uploads a minimal jar
+// built by the test without a POM file, so that one is generated from the
+// configured coordinates, and verifies the package was created, before
+// tearing down the resources and verifying deletion.
+func TestAccPackageMaven_basic(t *testing.T) {
+	t.Parallel()
+
+	packageFile := filepath.Join(t.TempDir(), "library.jar")
+	content := testZip(t, map[string]string{"META-INF/MANIFEST.MF": "Manifest-Version: 1.0\n"})
+	if err := os.WriteFile(packageFile, content, 0o600); err != nil {
+		t.Fatalf("unable to write package file: %s", err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccPackageUploadCheckDestroy("cloudsmith_maven_package.test"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPackageMavenConfig(packageFile),
+				Check: resource.ComposeTestCheckFunc(
+					testAccPackageUploadCheckExists("cloudsmith_maven_package.test"),
+					resource.TestCheckResourceAttr("cloudsmith_maven_package.test", "name", "terraform-acc-test-maven"),
+					resource.TestCheckResourceAttr("cloudsmith_maven_package.test", "version", "1.0.0"),
+					resource.TestCheckResourceAttrSet("cloudsmith_maven_package.test", "checksum_sha1"),
+					resource.TestCheckResourceAttrSet("cloudsmith_maven_package.test", "slug_perm"),
+				),
+			},
+		},
+	})
+}
+
+func TestWriteMavenPOM(t *testing.T) {
+	t.Parallel()
+
+	path, err := writeMavenPOM(t.TempDir(), "io.cloudsmith", "library&co", "1.0.0", "jar")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if filepath.Base(path) != "library&co-1.0.0.pom" {
+		t.Fatalf("unexpected pom filename %s", filepath.Base(path))
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("unable to read pom file: %s", err)
+	}
+
+	var pom mavenPOM
+	if err := xml.Unmarshal(content, &pom); err != nil {
+		t.Fatalf("generated pom is not valid xml: %s", err)
+	}
+	if pom.GroupID != "io.cloudsmith" || pom.ArtifactID != "library&co" || pom.Version != "1.0.0" || pom.Packaging != "jar" {
+		t.Fatalf("unexpected pom coordinates %+v", pom)
+	}
+}
+
+func TestMavenArtifactFilename(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		artifactID, version, classifier, expected string
+	}{
+		{"library", "1.0.0", "", "library-1.0.0.jar"},
+		{"library", "1.0.0", "sources", "library-1.0.0-sources.jar"},
+		{"", "1.0.0", "", "build-output.jar"},
+	}
+
+	for _, tt := range tests {
+		if actual := mavenArtifactFilename("/tmp/build-output.jar", tt.artifactID, tt.version, tt.classifier); actual != tt.expected {
+			t.Errorf("expected %s, got %s", tt.expected, actual)
+		}
+	}
+}
+
+func testAccPackageMavenConfig(packageFile string) string {
+	return fmt.Sprintf(`
+resource "cloudsmith_repository" "test" {
+	name      = "terraform-acc-test-maven-package"
+	namespace = "%s"
+}
+
+resource "cloudsmith_maven_package" "test" {
+	namespace    = "${cloudsmith_repository.test.namespace}"
+	repository   = "${cloudsmith_repository.test.slug_perm}"
+	package_file = "%s"
+	group_id     = "io.cloudsmith.terraform"
+	artifact_id  = "terraform-acc-test-maven"
+	version      = "1.0.0"
+}
+`, os.Getenv("CLOUDSMITH_NAMESPACE"), packageFile)
+}
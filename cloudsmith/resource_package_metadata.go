@@ -0,0 +1,152 @@
+package cloudsmith
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func importPackageMetadata(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	idParts := strings.Split(d.Id(), ".")
+	if len(idParts) != 3 {
+		return nil, fmt.Errorf(
+			"invalid import ID, must be of the form <namespace_slug>.<repository_slug>.<package_slug_perm>, got: %s", d.Id(),
+		)
+	}
+
+	d.Set("namespace", idParts[0])
+	d.Set("repository", idParts[1])
+	d.Set("slug_perm", idParts[2])
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourcePackageMetadataCreateUpdate(d *schema.ResourceData, m interface{}) error {
+	pc := m.(*providerConfig)
+
+	namespace := requiredString(d, "namespace")
+	repository := requiredString(d, "repository")
+	slugPerm := requiredString(d, "slug_perm")
+
+	// replacing rather than adding means tags removed from the configuration
+	// are also removed from the package.
+	if err := tagPackage(pc, namespace, repository, slugPerm, "Replace", expandStrings(d, "tags")); err != nil {
+		return err
+	}
+
+	d.SetId(fmt.Sprintf("%s.%s.%s", namespace, repository, slugPerm))
+
+	return resourcePackageMetadataRead(d, m)
+}
+
+func resourcePackageMetadataRead(d *schema.ResourceData, m interface{}) error {
+	pc := m.(*providerConfig)
+
+	namespace := requiredString(d, "namespace")
+	repository := requiredString(d, "repository")
+	slugPerm := requiredString(d, "slug_perm")
+
+	req := pc.APIClient.PackagesApi.PackagesRead(pc.Auth, namespace, repository, slugPerm)
+	pkg, resp, err := pc.APIClient.PackagesApi.PackagesReadExecute(req)
+	if err != nil {
+		if is404(resp) {
+			d.SetId("")
+			return nil
+		}
+
+		return err
+	}
+
+	d.Set("description", pkg.GetDescription())
+	d.Set("name", pkg.GetName())
+	d.Set("tags", flattenStrings(packageInfoTags(pkg.GetTags())))
+	d.Set("version", pkg.GetVersion())
+
+	// namespace, repository and slug_perm are not returned from the package
+	// read endpoint, so we can use the values stored in resource state. We
+	// rely on ForceNew to ensure if any changes a new resource is created.
+	d.Set("namespace", namespace)
+	d.Set("repository", repository)
+	d.Set("slug_perm", slugPerm)
+
+	return nil
+}
+
+func resourcePackageMetadataDelete(d *schema.ResourceData, m interface{}) error {
+	pc := m.(*providerConfig)
+
+	namespace := requiredString(d, "namespace")
+	repository := requiredString(d, "repository")
+	slugPerm := requiredString(d, "slug_perm")
+
+	req := pc.APIClient.PackagesApi.PackagesDelete(pc.Auth, namespace, repository, slugPerm)
+	if resp, err := pc.APIClient.PackagesApi.PackagesDeleteExecute(req); err != nil && !is404(resp) {
+		return fmt.Errorf("error deleting package (%s): %w", slugPerm, err)
+	}
+
+	return nil
+}
+
+func resourcePackageMetadata() *schema.Resource {
+	return &schema.Resource{
+		Create: resourcePackageMetadataCreateUpdate,
+		Read:   resourcePackageMetadataRead,
+		Update: resourcePackageMetadataCreateUpdate,
+		Delete: resourcePackageMetadataDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: importPackageMetadata,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"description": {
+				Type: schema.TypeString,
+				Description: "The description of the package. The API doesn't allow the description of an " +
+					"existing package to be changed, so it is read only.",
+				Computed: true,
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Description: "The name of the package.",
+				Computed:    true,
+			},
+			"namespace": {
+				Type:         schema.TypeString,
+				Description:  "Namespace to which the package belongs.",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"repository": {
+				Type:         schema.TypeString,
+				Description:  "Repository to which the package belongs.",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"slug_perm": {
+				Type:         schema.TypeString,
+				Description:  "The slug_perm of the package to manage.",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"tags": {
+				Type:        schema.TypeSet,
+				Description: "The tags to apply to the package. Any other tags on the package are removed.",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+				Optional: true,
+			},
+			"version": {
+				Type:        schema.TypeString,
+				Description: "The version of the package.",
+				Computed:    true,
+			},
+		},
+	}
+}
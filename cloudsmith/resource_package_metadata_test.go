@@ -0,0 +1,83 @@
+//nolint:testpackage
+package cloudsmith
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// TestAccPackageMetadata_basic uploads a raw package, manages its tags
+// through the metadata resource and verifies the package metadata is read
+// back, before importing the resource.
+func TestAccPackageMetadata_basic(t *testing.T) {
+	t.Parallel()
+
+	packageFile := filepath.Join(t.TempDir(), "terraform-acc-test-package-metadata.txt")
+	if err := os.WriteFile(packageFile, []byte("terraform-acc-test-package-metadata"), 0o600); err != nil {
+		t.Fatalf("unable to write package file: %s", err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccRepositoryCheckDestroy("cloudsmith_repository.test"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPackageMetadataConfig(packageFile, `["promoted"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("cloudsmith_package_metadata.test", "tags.#", "1"),
+					resource.TestCheckTypeSetElemAttr("cloudsmith_package_metadata.test", "tags.*", "promoted"),
+					resource.TestCheckResourceAttrSet("cloudsmith_package_metadata.test", "name"),
+				),
+			},
+			{
+				Config: testAccPackageMetadataConfig(packageFile, `[]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("cloudsmith_package_metadata.test", "tags.#", "0"),
+				),
+			},
+			{
+				ResourceName: "cloudsmith_package_metadata.test",
+				ImportState:  true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					resourceState := s.RootModule().Resources["cloudsmith_package_metadata.test"]
+					return fmt.Sprintf(
+						"%s.%s.%s",
+						resourceState.Primary.Attributes["namespace"],
+						resourceState.Primary.Attributes["repository"],
+						resourceState.Primary.Attributes["slug_perm"],
+					), nil
+				},
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccPackageMetadataConfig(packageFile, tags string) string {
+	return fmt.Sprintf(`
+resource "cloudsmith_repository" "test" {
+	name      = "terraform-acc-test-package-metadata"
+	namespace = "%s"
+}
+
+resource "cloudsmith_package_upload" "test" {
+	namespace      = cloudsmith_repository.test.namespace
+	repository     = cloudsmith_repository.test.slug_perm
+	package_format = "raw"
+	package_file   = "%s"
+}
+
+resource "cloudsmith_package_metadata" "test" {
+	namespace  = cloudsmith_repository.test.namespace
+	repository = cloudsmith_repository.test.slug_perm
+	slug_perm  = cloudsmith_package_upload.test.slug_perm
+	tags       = %s
+}
+`, os.Getenv("CLOUDSMITH_NAMESPACE"), packageFile, tags)
+}
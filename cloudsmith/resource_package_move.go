@@ -0,0 +1,126 @@
+package cloudsmith
+
+import (
+	"context"
+
+	"github.com/cloudsmith-io/cloudsmith-api-go"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourcePackageMoveCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	pc := m.(*providerConfig)
+
+	namespace := requiredString(d, "namespace")
+	sourceRepository := requiredString(d, "source_repository")
+	destinationRepository := requiredString(d, "destination_repository")
+	identifier := requiredString(d, "identifier")
+
+	req := pc.APIClient.PackagesApi.PackagesMove(pc.Auth, namespace, sourceRepository, identifier)
+	req = req.Data(cloudsmith.PackageMoveRequest{
+		Destination: destinationRepository,
+	})
+
+	pkg, _, err := pc.APIClient.PackagesApi.PackagesMoveExecute(req)
+	if err != nil {
+		return diag.Errorf("error moving package (%s) to %s: %s", identifier, destinationRepository, err)
+	}
+
+	d.SetId(pkg.GetSlugPerm())
+
+	if err := waitForPackageSync(ctx, pc, namespace, destinationRepository, d.Id(), defaultPackageSyncTimeout); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourcePackageMoveRead(ctx, d, m)
+}
+
+func resourcePackageMoveRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	pc := m.(*providerConfig)
+
+	namespace := requiredString(d, "namespace")
+	destinationRepository := requiredString(d, "destination_repository")
+
+	// once moved the package no longer exists in the source repository, so
+	// we only ever look for it in the destination.
+	req := pc.APIClient.PackagesApi.PackagesRead(pc.Auth, namespace, destinationRepository, d.Id())
+	pkg, resp, err := pc.APIClient.PackagesApi.PackagesReadExecute(req)
+	if err != nil {
+		if is404(resp) {
+			tflog.Warn(ctx, "Moved package not found, removing from state", map[string]interface{}{
+				"namespace":  namespace,
+				"repository": destinationRepository,
+				"slug_perm":  d.Id(),
+			})
+			d.SetId("")
+			return nil
+		}
+
+		return diag.FromErr(err)
+	}
+
+	d.Set("moved_slug_perm", pkg.GetSlugPerm())
+
+	// namespace, repositories and identifier are not returned from the
+	// package read endpoint, so we can use the values stored in resource
+	// state. We rely on ForceNew to ensure if any changes a new resource is
+	// created.
+	d.Set("namespace", namespace)
+	d.Set("destination_repository", destinationRepository)
+	d.Set("source_repository", requiredString(d, "source_repository"))
+	d.Set("identifier", requiredString(d, "identifier"))
+
+	return nil
+}
+
+// resourcePackageMoveDelete does nothing, as a move cannot be undone. The
+// package is left in the destination repository.
+func resourcePackageMoveDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return nil
+}
+
+func resourcePackageMove() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcePackageMoveCreate,
+		ReadContext:   resourcePackageMoveRead,
+		DeleteContext: resourcePackageMoveDelete,
+
+		Schema: map[string]*schema.Schema{
+			"destination_repository": {
+				Type:         schema.TypeString,
+				Description:  "Repository to which the package is moved.",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"identifier": {
+				Type:         schema.TypeString,
+				Description:  "The slug_perm of the package to move.",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"moved_slug_perm": {
+				Type:        schema.TypeString,
+				Description: "The slug_perm of the moved package in the destination repository.",
+				Computed:    true,
+			},
+			"namespace": {
+				Type:         schema.TypeString,
+				Description:  "Namespace to which both repositories belong.",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"source_repository": {
+				Type:         schema.TypeString,
+				Description:  "Repository containing the package to move.",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+		},
+	}
+}
@@ -0,0 +1,92 @@
+package cloudsmith
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// npmManifest holds the fields of an npm package.json that are required for
+// a package to be published.
+type npmManifest struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// readNpmManifest reads the package.json manifest from an npm tarball, as
+// created by `npm pack`, returning an error if the tarball is not a valid npm
+// package.
+func readNpmManifest(filePath string) (npmManifest, error) {
+	var manifest npmManifest
+
+	// npm pack places the package contents in a single top-level directory,
+	// which is conventionally named package/ but isn't required to be.
+	content, err := readTarGzEntry(filePath, func(name string) bool {
+		parts := strings.Split(strings.TrimPrefix(name, "./"), "/")
+		return len(parts) == 2 && parts[1] == "package.json"
+	})
+	if err != nil {
+		return manifest, fmt.Errorf("invalid npm package %s: error reading package.json: %w", filePath, err)
+	}
+
+	if err := json.Unmarshal(content, &manifest); err != nil {
+		return manifest, fmt.Errorf("invalid npm package %s: error parsing package.json: %w", filePath, err)
+	}
+	if manifest.Name == "" || manifest.Version == "" {
+		return manifest, fmt.Errorf("invalid npm package %s: package.json must contain a name and version", filePath)
+	}
+
+	return manifest, nil
+}
+
+func resourcePackageNpmCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	pc := m.(*providerConfig)
+
+	if _, err := readNpmManifest(requiredString(d, "package_file")); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := createUploadedPackage(ctx, d, pc, "npm", requiredString(d, "package_file")); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourcePackageNpmRead(ctx, d, m)
+}
+
+func resourcePackageNpmRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	pc := m.(*providerConfig)
+
+	_, err := readUploadedPackage(d, pc)
+	return diag.FromErr(err)
+}
+
+// resourcePackageNpmUpdate only handles changes to sync_timeout, as every
+// other argument forces a new package to be uploaded.
+func resourcePackageNpmUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return resourcePackageNpmRead(ctx, d, m)
+}
+
+func resourcePackageNpm() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcePackageNpmCreate,
+		ReadContext:   resourcePackageNpmRead,
+		UpdateContext: resourcePackageNpmUpdate,
+		DeleteContext: resourcePackageUploadDelete,
+
+		Schema: packageResourceSchema([]string{".tgz"}, map[string]*schema.Schema{
+			"dist_tag": {
+				Type: schema.TypeString,
+				Description: "The npm dist-tag to apply to the package, e.g. `next`. This will move the tag " +
+					"from any other version of the package using it.",
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+		}),
+	}
+}
@@ -0,0 +1,102 @@
+//nolint:testpackage
+package cloudsmith
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// TestAccPackageNpm_basic spins up a repository, uploads a minimal npm package
+// built by the test with a dist-tag and verifies the attributes read from its
+// manifest, before tearing down the resources and verifying deletion.
+func TestAccPackageNpm_basic(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	packageFile := filepath.Join(dir, "terraform-acc-test-npm-1.0.0.tgz")
+	manifest := `{"name": "terraform-acc-test-npm", "version": "1.0.0"}`
+	if err := os.WriteFile(packageFile, testTarGz(t, map[string]string{"package/package.json": manifest}), 0o600); err != nil {
+		t.Fatalf("unable to write package file: %s", err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccPackageUploadCheckDestroy("cloudsmith_npm_package.test"),
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccPackageNpmConfig(filepath.Join(dir, "package.tar")),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`must be a path to a \.tgz file`),
+			},
+			{
+				Config: testAccPackageNpmConfig(packageFile),
+				Check: resource.ComposeTestCheckFunc(
+					testAccPackageUploadCheckExists("cloudsmith_npm_package.test"),
+					resource.TestCheckResourceAttr("cloudsmith_npm_package.test", "name", "terraform-acc-test-npm"),
+					resource.TestCheckResourceAttr("cloudsmith_npm_package.test", "version", "1.0.0"),
+					resource.TestCheckResourceAttr("cloudsmith_npm_package.test", "dist_tag", "next"),
+					resource.TestCheckResourceAttrSet("cloudsmith_npm_package.test", "checksum_sha256"),
+				),
+			},
+		},
+	})
+}
+
+func TestReadNpmManifest(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	tests := []struct {
+		name    string
+		content []byte
+		err     string
+	}{
+		{name: "valid", content: testTarGz(t, map[string]string{"package/package.json": `{"name": "pkg", "version": "1.0.0"}`})},
+		{name: "custom-dir", content: testTarGz(t, map[string]string{"pkg/package.json": `{"name": "pkg", "version": "1.0.0"}`})},
+		{name: "nested", content: testTarGz(t, map[string]string{"package/lib/package.json": `{"name": "pkg", "version": "1.0.0"}`}), err: "file not found in archive"},
+		{name: "no-version", content: testTarGz(t, map[string]string{"package/package.json": `{"name": "pkg"}`}), err: "must contain a name and version"},
+		{name: "malformed", content: testTarGz(t, map[string]string{"package/package.json": `{`}), err: "error parsing package.json"},
+		{name: "not-gzip", content: []byte("not a tarball"), err: "error reading package.json"},
+	}
+
+	for _, tt := range tests {
+		packageFile := filepath.Join(dir, tt.name+".tgz")
+		if err := os.WriteFile(packageFile, tt.content, 0o600); err != nil {
+			t.Fatalf("unable to write package file: %s", err)
+		}
+
+		manifest, err := readNpmManifest(packageFile)
+		if tt.err == "" {
+			if err != nil {
+				t.Errorf("%s: unexpected error: %s", tt.name, err)
+			} else if manifest.Name != "pkg" || manifest.Version != "1.0.0" {
+				t.Errorf("%s: unexpected manifest %+v", tt.name, manifest)
+			}
+		} else if err == nil || !strings.Contains(err.Error(), tt.err) {
+			t.Errorf("%s: expected error containing %q, got %v", tt.name, tt.err, err)
+		}
+	}
+}
+
+func testAccPackageNpmConfig(packageFile string) string {
+	return fmt.Sprintf(`
+resource "cloudsmith_repository" "test" {
+	name      = "terraform-acc-test-npm-package"
+	namespace = "%s"
+}
+
+resource "cloudsmith_npm_package" "test" {
+	namespace    = "${cloudsmith_repository.test.namespace}"
+	repository   = "${cloudsmith_repository.test.slug_perm}"
+	package_file = "%s"
+	dist_tag     = "next"
+}
+`, os.Getenv("CLOUDSMITH_NAMESPACE"), packageFile)
+}
@@ -0,0 +1,107 @@
+package cloudsmith
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// nuspec holds the metadata of a NuGet package that is required for it to be
+// published, as read from the .nuspec manifest at the root of the package.
+type nuspec struct {
+	Metadata struct {
+		ID          string `xml:"id"`
+		Version     string `xml:"version"`
+		Authors     string `xml:"authors"`
+		Description string `xml:"description"`
+	} `xml:"metadata"`
+}
+
+// readNuspec reads the .nuspec manifest embedded in a NuGet package, returning
+// an error if the package is malformed or is missing required metadata.
+func readNuspec(filePath string) (nuspec, error) {
+	var manifest nuspec
+
+	content, err := readZipEntry(filePath, func(name string) bool {
+		return !strings.Contains(name, "/") && strings.HasSuffix(name, ".nuspec")
+	})
+	if err != nil {
+		return manifest, fmt.Errorf("invalid nuget package %s: error reading .nuspec: %w", filePath, err)
+	}
+
+	if err := xml.Unmarshal(content, &manifest); err != nil {
+		return manifest, fmt.Errorf("invalid nuget package %s: error parsing .nuspec: %w", filePath, err)
+	}
+
+	missing := []string{}
+	for field, value := range map[string]string{
+		"authors":     manifest.Metadata.Authors,
+		"description": manifest.Metadata.Description,
+		"id":          manifest.Metadata.ID,
+		"version":     manifest.Metadata.Version,
+	} {
+		if strings.TrimSpace(value) == "" {
+			missing = append(missing, field)
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return manifest, fmt.Errorf("invalid nuget package %s: .nuspec is missing required metadata: %s", filePath, strings.Join(missing, ", "))
+	}
+
+	return manifest, nil
+}
+
+func resourcePackageNugetCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	pc := m.(*providerConfig)
+
+	manifest, err := readNuspec(requiredString(d, "package_file"))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := createUploadedPackage(ctx, d, pc, "nuget", requiredString(d, "package_file")); err != nil {
+		return diag.FromErr(err)
+	}
+
+	// The package API doesn't return the authors of a package, so they're
+	// taken from the manifest that was uploaded.
+	d.Set("authors", manifest.Metadata.Authors)
+
+	return resourcePackageNugetRead(ctx, d, m)
+}
+
+func resourcePackageNugetRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	pc := m.(*providerConfig)
+
+	_, err := readUploadedPackage(d, pc)
+	return diag.FromErr(err)
+}
+
+// resourcePackageNugetUpdate only handles changes to sync_timeout, as every
+// other argument forces a new package to be uploaded.
+func resourcePackageNugetUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return resourcePackageNugetRead(ctx, d, m)
+}
+
+func resourcePackageNuget() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcePackageNugetCreate,
+		ReadContext:   resourcePackageNugetRead,
+		UpdateContext: resourcePackageNugetUpdate,
+		DeleteContext: resourcePackageUploadDelete,
+
+		Schema: packageResourceSchema([]string{".nupkg"}, map[string]*schema.Schema{
+			"authors": {
+				Type:        schema.TypeString,
+				Description: "The authors of the package, as read from the .nuspec manifest of the package file.",
+				Computed:    true,
+			},
+		}),
+	}
+}
@@ -0,0 +1,130 @@
+//nolint:testpackage
+package cloudsmith
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+const testNuspec = `<?xml version="1.0" encoding="utf-8"?>
+<package xmlns="http://schemas.microsoft.com/packaging/2013/05/nuspec.xsd">
+  <metadata>
+    <id>%s</id>
+    <version>1.0.0</version>
+    <authors>%s</authors>
+    <description>Terraform acceptance test package</description>
+  </metadata>
+</package>
+`
+
+// TestAccPackageNuget_basic spins up a repository, uploads a minimal NuGet
+// package built by the test and verifies the attributes read from its
+// manifest, before tearing down the resources and verifying deletion.
+func TestAccPackageNuget_basic(t *testing.T) {
+	t.Parallel()
+
+	packageFile := filepath.Join(t.TempDir(), "TerraformAccTestNuget.1.0.0.nupkg")
+	content := testZip(t, map[string]string{
+		"TerraformAccTestNuget.nuspec": fmt.Sprintf(testNuspec, "TerraformAccTestNuget", "Terraform"),
+	})
+	if err := os.WriteFile(packageFile, content, 0o600); err != nil {
+		t.Fatalf("unable to write package file: %s", err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccPackageUploadCheckDestroy("cloudsmith_nuget_package.test"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPackageNugetConfig(packageFile),
+				Check: resource.ComposeTestCheckFunc(
+					testAccPackageUploadCheckExists("cloudsmith_nuget_package.test"),
+					resource.TestCheckResourceAttr("cloudsmith_nuget_package.test", "name", "TerraformAccTestNuget"),
+					resource.TestCheckResourceAttr("cloudsmith_nuget_package.test", "version", "1.0.0"),
+					resource.TestCheckResourceAttr("cloudsmith_nuget_package.test", "authors", "Terraform"),
+					resource.TestCheckResourceAttrSet("cloudsmith_nuget_package.test", "checksum_sha256"),
+				),
+			},
+		},
+	})
+}
+
+func TestReadNuspec(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	tests := []struct {
+		name    string
+		content []byte
+		err     string
+	}{
+		{name: "valid", content: testZip(t, map[string]string{"Pkg.nuspec": fmt.Sprintf(testNuspec, "Pkg", "Author")})},
+		{name: "missing-metadata", content: testZip(t, map[string]string{"Pkg.nuspec": fmt.Sprintf(testNuspec, "", " ")}), err: "missing required metadata: authors, id"},
+		{name: "nested", content: testZip(t, map[string]string{"content/Pkg.nuspec": fmt.Sprintf(testNuspec, "Pkg", "Author")}), err: "file not found in archive"},
+		{name: "malformed", content: testZip(t, map[string]string{"Pkg.nuspec": "<package>"}), err: "error parsing .nuspec"},
+		{name: "not-zip", content: []byte("not a package"), err: "error reading .nuspec"},
+	}
+
+	for _, tt := range tests {
+		packageFile := filepath.Join(dir, tt.name+".nupkg")
+		if err := os.WriteFile(packageFile, tt.content, 0o600); err != nil {
+			t.Fatalf("unable to write package file: %s", err)
+		}
+
+		manifest, err := readNuspec(packageFile)
+		if tt.err == "" {
+			if err != nil {
+				t.Errorf("%s: unexpected error: %s", tt.name, err)
+			} else if manifest.Metadata.ID != "Pkg" || manifest.Metadata.Authors != "Author" {
+				t.Errorf("%s: unexpected manifest %+v", tt.name, manifest)
+			}
+		} else if err == nil || !strings.Contains(err.Error(), tt.err) {
+			t.Errorf("%s: expected error containing %q, got %v", tt.name, tt.err, err)
+		}
+	}
+}
+
+// testZip returns a zip archive containing the given files.
+func testZip(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range files {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatalf("unable to create zip entry: %s", err)
+		}
+		if _, err := w.Write([]byte(content)); err != nil {
+			t.Fatalf("unable to write zip entry: %s", err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatalf("unable to close zip writer: %s", err)
+	}
+
+	return buf.Bytes()
+}
+
+func testAccPackageNugetConfig(packageFile string) string {
+	return fmt.Sprintf(`
+resource "cloudsmith_repository" "test" {
+	name      = "terraform-acc-test-nuget-package"
+	namespace = "%s"
+}
+
+resource "cloudsmith_nuget_package" "test" {
+	namespace    = "${cloudsmith_repository.test.namespace}"
+	repository   = "${cloudsmith_repository.test.slug_perm}"
+	package_file = "%s"
+}
+`, os.Getenv("CLOUDSMITH_NAMESPACE"), packageFile)
+}
@@ -0,0 +1,258 @@
+package cloudsmith
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cloudsmith-io/cloudsmith-api-go"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// findPromotedPackage returns the package in a repository which is a copy of
+// the given package, i.e. has the same name, version and content, or nil if
+// the package hasn't been promoted to the repository yet.
+func findPromotedPackage(pc *providerConfig, namespace, repository string, pkg *cloudsmith.Package) (*cloudsmith.Package, error) {
+	// every page is searched, as several packages can share a name and
+	// version.
+	query := fmt.Sprintf("%s AND %s", exactPackageQuery("name", pkg.GetName()), exactPackageQuery("version", pkg.GetVersion()))
+	packages, err := retrievePackageListPages(pc, namespace, repository, query, -1, -1)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range packages {
+		if packages[i].GetName() == pkg.GetName() &&
+			packages[i].GetVersion() == pkg.GetVersion() &&
+			packages[i].GetChecksumSha256() == pkg.GetChecksumSha256() {
+			return &packages[i], nil
+		}
+	}
+	return nil, nil
+}
+
+func resourcePackagePromoteCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	pc := m.(*providerConfig)
+
+	namespace := requiredString(d, "namespace")
+	sourceRepository := requiredString(d, "source_repository")
+	destinationRepository := requiredString(d, "destination_repository")
+	identifier := requiredString(d, "identifier")
+
+	req := pc.APIClient.PackagesApi.PackagesRead(pc.Auth, namespace, sourceRepository, identifier)
+	source, _, err := pc.APIClient.PackagesApi.PackagesReadExecute(req)
+	if err != nil {
+		return diag.Errorf("error reading package (%s): %s", identifier, err)
+	}
+
+	// the destination may already contain a copy, e.g. from a previous apply
+	// which failed before it was stored in state, so an existing copy is
+	// adopted rather than copying the package again. Adopted copies weren't
+	// created by this resource, so they're left in place on destroy.
+	promoted, err := findPromotedPackage(pc, namespace, destinationRepository, source)
+	if err != nil {
+		return diag.Errorf("error searching %s for package (%s): %s", destinationRepository, identifier, err)
+	}
+
+	if promoted != nil {
+		d.SetId(promoted.GetSlugPerm())
+		d.Set("adopted", true)
+	} else {
+		req := pc.APIClient.PackagesApi.PackagesCopy(pc.Auth, namespace, sourceRepository, identifier)
+		req = req.Data(cloudsmith.PackageCopyRequest{
+			Destination: destinationRepository,
+		})
+
+		pkg, _, err := pc.APIClient.PackagesApi.PackagesCopyExecute(req)
+		if err != nil {
+			return diag.Errorf("error copying package (%s) to %s: %s", identifier, destinationRepository, err)
+		}
+
+		d.SetId(pkg.GetSlugPerm())
+		d.Set("adopted", false)
+
+		if err := waitForPackageSync(ctx, pc, namespace, destinationRepository, d.Id(), defaultPackageSyncTimeout); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	tags := packageTagSearchTags(d.Get("promotion_tags").(map[string]interface{}))
+	if len(tags) > 0 {
+		if err := tagPackage(pc, namespace, destinationRepository, d.Id(), "Add", tags); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourcePackagePromoteRead(ctx, d, m)
+}
+
+func resourcePackagePromoteRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	pc := m.(*providerConfig)
+
+	namespace := requiredString(d, "namespace")
+	destinationRepository := requiredString(d, "destination_repository")
+
+	// the promoted package is identified by its own slug_perm in the
+	// destination repository, so if it has been removed we plan to promote
+	// it again.
+	req := pc.APIClient.PackagesApi.PackagesRead(pc.Auth, namespace, destinationRepository, d.Id())
+	pkg, resp, err := pc.APIClient.PackagesApi.PackagesReadExecute(req)
+	if err != nil {
+		if is404(resp) {
+			d.SetId("")
+			return nil
+		}
+
+		return diag.FromErr(err)
+	}
+
+	// only the promotion tags are tracked, as the package may have other tags
+	// copied from the source package or applied elsewhere.
+	infoTags := map[string]bool{}
+	for _, tag := range packageInfoTags(pkg.GetTags()) {
+		infoTags[tag] = true
+	}
+	promotionTags := map[string]interface{}{}
+	for key, value := range d.Get("promotion_tags").(map[string]interface{}) {
+		tag := packageTagSearchTags(map[string]interface{}{key: value})[0]
+		if infoTags[tag] {
+			promotionTags[key] = value
+		}
+	}
+
+	d.Set("promoted_slug_perm", pkg.GetSlugPerm())
+	d.Set("promotion_tags", promotionTags)
+
+	// namespace, repositories and identifier are not returned from the
+	// package read endpoint, so we can use the values stored in resource
+	// state. We rely on ForceNew to ensure if any changes a new resource is
+	// created.
+	d.Set("namespace", namespace)
+	d.Set("destination_repository", destinationRepository)
+	d.Set("source_repository", requiredString(d, "source_repository"))
+	d.Set("identifier", requiredString(d, "identifier"))
+
+	return nil
+}
+
+// resourcePackagePromoteUpdate only handles changes to promotion_tags, as
+// every other argument forces the package to be promoted again.
+func resourcePackagePromoteUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	pc := m.(*providerConfig)
+
+	namespace := requiredString(d, "namespace")
+	destinationRepository := requiredString(d, "destination_repository")
+
+	o, n := d.GetChange("promotion_tags")
+	oldTags := packageTagSearchTags(o.(map[string]interface{}))
+	newTags := packageTagSearchTags(n.(map[string]interface{}))
+
+	added := map[string]bool{}
+	for _, tag := range newTags {
+		added[tag] = true
+	}
+	removed := []string{}
+	for _, tag := range oldTags {
+		if !added[tag] {
+			removed = append(removed, tag)
+		}
+	}
+
+	if len(removed) > 0 {
+		if err := tagPackage(pc, namespace, destinationRepository, d.Id(), "Remove", removed); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if len(newTags) > 0 {
+		if err := tagPackage(pc, namespace, destinationRepository, d.Id(), "Add", newTags); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourcePackagePromoteRead(ctx, d, m)
+}
+
+// resourcePackagePromoteDelete deletes the promoted package, unless an existing
+// copy was adopted, in which case it's only removed from state.
+func resourcePackagePromoteDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	pc := m.(*providerConfig)
+
+	if requiredBool(d, "adopted") {
+		tflog.Info(ctx, "Promoted package was adopted rather than copied, leaving it in place", map[string]interface{}{
+			"slug_perm": d.Id(),
+		})
+		return nil
+	}
+
+	namespace := requiredString(d, "namespace")
+	destinationRepository := requiredString(d, "destination_repository")
+
+	req := pc.APIClient.PackagesApi.PackagesDelete(pc.Auth, namespace, destinationRepository, d.Id())
+	resp, err := pc.APIClient.PackagesApi.PackagesDeleteExecute(req)
+	if err != nil && !is404(resp) {
+		return diag.Errorf("error deleting promoted package (%s): %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func resourcePackagePromote() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcePackagePromoteCreate,
+		ReadContext:   resourcePackagePromoteRead,
+		UpdateContext: resourcePackagePromoteUpdate,
+		DeleteContext: resourcePackagePromoteDelete,
+
+		Schema: map[string]*schema.Schema{
+			"adopted": {
+				Type: schema.TypeBool,
+				Description: "Whether a copy of the package already existed in the destination repository " +
+					"and was adopted instead of being copied. Adopted packages are not deleted when the " +
+					"resource is destroyed.",
+				Computed: true,
+			},
+			"destination_repository": {
+				Type:         schema.TypeString,
+				Description:  "Repository to which the package is promoted.",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"identifier": {
+				Type:         schema.TypeString,
+				Description:  "The slug_perm of the package to promote.",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"namespace": {
+				Type:         schema.TypeString,
+				Description:  "Namespace to which both repositories belong.",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"promoted_slug_perm": {
+				Type:        schema.TypeString,
+				Description: "The slug_perm of the promoted package in the destination repository.",
+				Computed:    true,
+			},
+			"promotion_tags": {
+				Type: schema.TypeMap,
+				Description: "Tags applied to the promoted package, each as `key:value`, or just `key` if the " +
+					"value is empty.",
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Optional: true,
+			},
+			"source_repository": {
+				Type:         schema.TypeString,
+				Description:  "Repository containing the package to promote.",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+		},
+	}
+}
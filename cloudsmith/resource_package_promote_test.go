@@ -0,0 +1,180 @@
+//nolint:testpackage
+package cloudsmith
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cloudsmith-io/cloudsmith-api-go"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// TestAccPackagePromote_basic spins up a source and destination repository,
+// uploads a raw package to the source and promotes it to the destination with
+// a tag, verifying the promoted package and its tags, then changes the tags
+// before tearing down the resources and verifying deletion.
+func TestAccPackagePromote_basic(t *testing.T) {
+	t.Parallel()
+
+	packageFile := filepath.Join(t.TempDir(), "terraform-acc-test-package-promote.txt")
+	if err := os.WriteFile(packageFile, []byte("terraform-acc-test-package-promote"), 0o600); err != nil {
+		t.Fatalf("unable to write package file: %s", err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccRepositoryCheckDestroy("cloudsmith_repository.destination"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPackagePromoteConfig(packageFile, "promoted"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccPackageCopyCheckExists("cloudsmith_package_promote.test"),
+					resource.TestCheckResourceAttrPair(
+						"cloudsmith_package_promote.test", "promoted_slug_perm",
+						"cloudsmith_package_promote.test", "id",
+					),
+					resource.TestCheckResourceAttr("cloudsmith_package_promote.test", "promotion_tags.stage", "promoted"),
+				),
+			},
+			{
+				Config: testAccPackagePromoteConfig(packageFile, "released"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccPackageCopyCheckExists("cloudsmith_package_promote.test"),
+					resource.TestCheckResourceAttr("cloudsmith_package_promote.test", "promotion_tags.stage", "released"),
+				),
+			},
+		},
+	})
+}
+
+// TestFindPromotedPackage serves packages with the same name and version as
+// a promoted package and verifies only one with the same content is found,
+// searching with the name and version escaped.
+func TestFindPromotedPackage(t *testing.T) {
+	t.Parallel()
+
+	var packages []map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if query := r.URL.Query().Get("query"); query != `name:^my-lib$ AND version:^1\.0\.0\+build\.1$` {
+			t.Errorf("unexpected query %q", query)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Pagination-Pagetotal", "1")
+		_ = json.NewEncoder(w).Encode(packages)
+	}))
+	defer server.Close()
+
+	pc := testProviderConfig(server.URL)
+
+	source := cloudsmith.Package{}
+	source.SetName("my-lib")
+	source.SetVersion("1.0.0+build.1")
+	source.SetChecksumSha256("abc")
+
+	packages = []map[string]string{
+		{"slug_perm": "other-name", "name": "my-lib-extra", "version": "1.0.0+build.1", "checksum_sha256": "abc"},
+		{"slug_perm": "other-content", "name": "my-lib", "version": "1.0.0+build.1", "checksum_sha256": "def"},
+	}
+	promoted, err := findPromotedPackage(pc, "namespace", "repository", &source)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if promoted != nil {
+		t.Errorf("expected no promoted package, got %s", promoted.GetSlugPerm())
+	}
+
+	packages = append(packages, map[string]string{"slug_perm": "promoted", "name": "my-lib", "version": "1.0.0+build.1", "checksum_sha256": "abc"})
+	promoted, err = findPromotedPackage(pc, "namespace", "repository", &source)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if promoted == nil || promoted.GetSlugPerm() != "promoted" {
+		t.Errorf("expected the promoted package to be found, got %v", promoted)
+	}
+}
+
+// TestResourcePackagePromoteDelete verifies that destroying the resource only
+// deletes promoted packages it copied, leaving adopted copies in place.
+func TestResourcePackagePromoteDelete(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		adopted       bool
+		expectDeleted bool
+	}{
+		{name: "Copied", adopted: false, expectDeleted: true},
+		{name: "Adopted", adopted: true, expectDeleted: false},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			deleted := false
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				deleted = r.Method == http.MethodDelete && r.URL.Path == "/packages/namespace/destination/slug-perm/"
+				w.WriteHeader(http.StatusNoContent)
+			}))
+			defer server.Close()
+
+			d := schema.TestResourceDataRaw(t, resourcePackagePromote().Schema, map[string]interface{}{
+				"namespace":              "namespace",
+				"source_repository":      "source",
+				"destination_repository": "destination",
+				"identifier":             "source-slug-perm",
+			})
+			d.SetId("slug-perm")
+			d.Set("adopted", tc.adopted)
+
+			if diags := resourcePackagePromoteDelete(context.Background(), d, testProviderConfig(server.URL)); diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			if deleted != tc.expectDeleted {
+				t.Errorf("expected package deleted to be %t, got %t", tc.expectDeleted, deleted)
+			}
+		})
+	}
+}
+
+func testAccPackagePromoteConfig(packageFile, stage string) string {
+	return fmt.Sprintf(`
+resource "cloudsmith_repository" "source" {
+	name      = "terraform-acc-test-package-promote-src"
+	namespace = "%[1]s"
+}
+
+resource "cloudsmith_repository" "destination" {
+	name      = "terraform-acc-test-package-promote-dst"
+	namespace = "%[1]s"
+}
+
+resource "cloudsmith_package_upload" "test" {
+	namespace      = cloudsmith_repository.source.namespace
+	repository     = cloudsmith_repository.source.slug_perm
+	package_format = "raw"
+	package_file   = "%[2]s"
+}
+
+resource "cloudsmith_package_promote" "test" {
+	namespace              = cloudsmith_repository.source.namespace
+	source_repository      = cloudsmith_repository.source.slug_perm
+	destination_repository = cloudsmith_repository.destination.slug_perm
+	identifier             = cloudsmith_package_upload.test.slug_perm
+
+	promotion_tags = {
+		stage = "%[3]s"
+	}
+}
+`, os.Getenv("CLOUDSMITH_NAMESPACE"), packageFile, stage)
+}
@@ -0,0 +1,73 @@
+package cloudsmith
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// pythonVersionFromFilename returns the Python version a distribution was
+// built for. Wheel filenames carry a python tag (e.g. `py3` or `cp311`) as
+// their third to last component, while source distributions are not tied to
+// any Python version and are reported as `source`, following PyPI.
+func pythonVersionFromFilename(filename string) string {
+	if !strings.HasSuffix(filename, ".whl") {
+		return "source"
+	}
+
+	parts := strings.Split(strings.TrimSuffix(filename, ".whl"), "-")
+	if len(parts) < 5 {
+		return ""
+	}
+
+	return parts[len(parts)-3]
+}
+
+func resourcePackagePythonCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	pc := m.(*providerConfig)
+
+	if err := createUploadedPackage(ctx, d, pc, "python", requiredString(d, "package_file")); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourcePackagePythonRead(ctx, d, m)
+}
+
+func resourcePackagePythonRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	pc := m.(*providerConfig)
+
+	pkg, err := readUploadedPackage(d, pc)
+	if err != nil || pkg == nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("python_version", pythonVersionFromFilename(pkg.GetFilename()))
+
+	return nil
+}
+
+// resourcePackagePythonUpdate only handles changes to sync_timeout, as every
+// other argument forces a new package to be uploaded.
+func resourcePackagePythonUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return resourcePackagePythonRead(ctx, d, m)
+}
+
+func resourcePackagePython() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcePackagePythonCreate,
+		ReadContext:   resourcePackagePythonRead,
+		UpdateContext: resourcePackagePythonUpdate,
+		DeleteContext: resourcePackageUploadDelete,
+
+		Schema: packageResourceSchema([]string{".whl", ".tar.gz"}, map[string]*schema.Schema{
+			"python_version": {
+				Type: schema.TypeString,
+				Description: "The Python version the package was built for, e.g. `py3`, " +
+					"or `source` for source distributions.",
+				Computed: true,
+			},
+		}),
+	}
+}
@@ -0,0 +1,84 @@
+//nolint:testpackage
+package cloudsmith
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// TestAccPackagePython_basic spins up a repository, uploads a minimal source
+// distribution built by the test and verifies the attributes read from its
+// metadata, before tearing down the resources and verifying deletion.
+func TestAccPackagePython_basic(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	packageFile := filepath.Join(dir, "terraform-acc-test-python-1.0.0.tar.gz")
+	pkgInfo := "Metadata-Version: 1.0\nName: terraform-acc-test-python\nVersion: 1.0.0\nSummary: Terraform acceptance test package\n"
+	content := testTarGz(t, map[string]string{"terraform-acc-test-python-1.0.0/PKG-INFO": pkgInfo})
+	if err := os.WriteFile(packageFile, content, 0o600); err != nil {
+		t.Fatalf("unable to write package file: %s", err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccPackageUploadCheckDestroy("cloudsmith_python_package.test"),
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccPackagePythonConfig(filepath.Join(dir, "package.zip")),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`must be a path to a \.whl or \.tar\.gz file`),
+			},
+			{
+				Config: testAccPackagePythonConfig(packageFile),
+				Check: resource.ComposeTestCheckFunc(
+					testAccPackageUploadCheckExists("cloudsmith_python_package.test"),
+					resource.TestCheckResourceAttr("cloudsmith_python_package.test", "name", "terraform-acc-test-python"),
+					resource.TestCheckResourceAttr("cloudsmith_python_package.test", "version", "1.0.0"),
+					resource.TestCheckResourceAttr("cloudsmith_python_package.test", "python_version", "source"),
+					resource.TestCheckResourceAttrSet("cloudsmith_python_package.test", "checksum_sha256"),
+					resource.TestCheckResourceAttrSet("cloudsmith_python_package.test", "slug_perm"),
+				),
+			},
+		},
+	})
+}
+
+func TestPythonVersionFromFilename(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"requests-2.31.0-py3-none-any.whl":                   "py3",
+		"numpy-1.26.0-cp311-cp311-manylinux_2_17_x86_64.whl": "cp311",
+		"package-1.0.0-1build-py2.py3-none-any.whl":          "py2.py3",
+		"requests-2.31.0.tar.gz":                             "source",
+		"malformed.whl":                                      "",
+	}
+
+	for filename, expected := range tests {
+		if actual := pythonVersionFromFilename(filename); actual != expected {
+			t.Errorf("%s: expected %q, got %q", filename, expected, actual)
+		}
+	}
+}
+
+func testAccPackagePythonConfig(packageFile string) string {
+	return fmt.Sprintf(`
+resource "cloudsmith_repository" "test" {
+	name      = "terraform-acc-test-python-package"
+	namespace = "%s"
+}
+
+resource "cloudsmith_python_package" "test" {
+	namespace    = "${cloudsmith_repository.test.namespace}"
+	repository   = "${cloudsmith_repository.test.slug_perm}"
+	package_file = "%s"
+}
+`, os.Getenv("CLOUDSMITH_NAMESPACE"), packageFile)
+}
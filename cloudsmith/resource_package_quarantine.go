@@ -0,0 +1,161 @@
+package cloudsmith
+
+import (
+	"fmt"
+
+	"github.com/cloudsmith-io/cloudsmith-api-go"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// quarantinePackage quarantines a package, or releases it from quarantine if
+// quarantined is false. Releasing a package which no longer exists isn't an
+// error, as it can't be quarantined either.
+func quarantinePackage(pc *providerConfig, namespace, repository, identifier string, quarantined bool) error {
+	req := pc.APIClient.PackagesApi.PackagesQuarantine(pc.Auth, namespace, repository, identifier)
+	req = req.Data(cloudsmith.PackageQuarantineRequest{
+		Release: cloudsmith.PtrBool(!quarantined),
+	})
+	if _, resp, err := pc.APIClient.PackagesApi.PackagesQuarantineExecute(req); err != nil {
+		if !quarantined && is404(resp) {
+			return nil
+		}
+		if quarantined {
+			return fmt.Errorf("error quarantining package (%s): %w", identifier, err)
+		}
+		return fmt.Errorf("error releasing package (%s) from quarantine: %w", identifier, err)
+	}
+	return nil
+}
+
+func resourcePackageQuarantineCreateUpdate(d *schema.ResourceData, m interface{}) error {
+	pc := m.(*providerConfig)
+
+	namespace := requiredString(d, "namespace")
+	repository := requiredString(d, "repository")
+	identifier := requiredString(d, "identifier")
+	quarantined := requiredBool(d, "is_quarantined")
+
+	req := pc.APIClient.PackagesApi.PackagesRead(pc.Auth, namespace, repository, identifier)
+	pkg, _, err := pc.APIClient.PackagesApi.PackagesReadExecute(req)
+	if err != nil {
+		return fmt.Errorf("error reading package (%s): %w", identifier, err)
+	}
+
+	// only call the quarantine endpoint when the package isn't already in the
+	// desired state, e.g. when it was quarantined by a policy. Whether the
+	// package was quarantined by this resource is tracked so that destroying
+	// the resource never releases a package quarantined elsewhere.
+	if pkg.GetIsQuarantined() != quarantined {
+		if err := quarantinePackage(pc, namespace, repository, identifier, quarantined); err != nil {
+			return err
+		}
+		d.Set("quarantined_by_resource", quarantined)
+	}
+
+	d.SetId(fmt.Sprintf("%s.%s.%s", namespace, repository, identifier))
+
+	return resourcePackageQuarantineRead(d, m)
+}
+
+func resourcePackageQuarantineRead(d *schema.ResourceData, m interface{}) error {
+	pc := m.(*providerConfig)
+
+	namespace := requiredString(d, "namespace")
+	repository := requiredString(d, "repository")
+	identifier := requiredString(d, "identifier")
+
+	req := pc.APIClient.PackagesApi.PackagesRead(pc.Auth, namespace, repository, identifier)
+	pkg, resp, err := pc.APIClient.PackagesApi.PackagesReadExecute(req)
+	if err != nil {
+		if is404(resp) {
+			d.SetId("")
+			return nil
+		}
+
+		return err
+	}
+
+	d.Set("is_quarantined", pkg.GetIsQuarantined())
+
+	// namespace, repository and identifier are not returned from the package
+	// read endpoint, so we can use the values stored in resource state. We
+	// rely on ForceNew to ensure if any changes a new resource is created.
+	d.Set("namespace", namespace)
+	d.Set("repository", repository)
+	d.Set("identifier", identifier)
+
+	return nil
+}
+
+// resourcePackageQuarantineDelete releases the package from quarantine only if
+// it was quarantined by this resource. A package which was already quarantined
+// when the resource was created or imported is left alone.
+func resourcePackageQuarantineDelete(d *schema.ResourceData, m interface{}) error {
+	pc := m.(*providerConfig)
+
+	if !requiredBool(d, "is_quarantined") || !requiredBool(d, "quarantined_by_resource") {
+		return nil
+	}
+
+	namespace := requiredString(d, "namespace")
+	repository := requiredString(d, "repository")
+	identifier := requiredString(d, "identifier")
+
+	return quarantinePackage(pc, namespace, repository, identifier, false)
+}
+
+func resourcePackageQuarantine() *schema.Resource {
+	return &schema.Resource{
+		Create: resourcePackageQuarantineCreateUpdate,
+		Read:   resourcePackageQuarantineRead,
+		Update: resourcePackageQuarantineCreateUpdate,
+		Delete: resourcePackageQuarantineDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: importPackageIdentifier,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"identifier": {
+				Type:         schema.TypeString,
+				Description:  "The slug_perm of the package to quarantine.",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"is_quarantined": {
+				Type:        schema.TypeBool,
+				Description: "If true, the package is quarantined. If false, it is released from quarantine.",
+				Required:    true,
+			},
+			"namespace": {
+				Type:         schema.TypeString,
+				Description:  "Namespace to which the package belongs.",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"quarantine_reason": {
+				Type: schema.TypeString,
+				Description: "The reason the package is quarantined, e.g. a vulnerability identifier. The reason " +
+					"is recorded in Terraform state only, as the API doesn't store it.",
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"quarantined_by_resource": {
+				Type: schema.TypeBool,
+				Description: "Whether the package was quarantined by this resource, in which case it is " +
+					"released from quarantine when the resource is destroyed.",
+				Computed: true,
+			},
+			"repository": {
+				Type:         schema.TypeString,
+				Description:  "Repository to which the package belongs.",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+		},
+	}
+}
@@ -0,0 +1,136 @@
+//nolint:testpackage
+package cloudsmith
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// TestAccPackageQuarantine_basic uploads a raw package, quarantines it, then
+// releases it and verifies the package's quarantine state after each step.
+func TestAccPackageQuarantine_basic(t *testing.T) {
+	t.Parallel()
+
+	packageFile := filepath.Join(t.TempDir(), "terraform-acc-test-package-quarantine.txt")
+	if err := os.WriteFile(packageFile, []byte("terraform-acc-test-package-quarantine"), 0o600); err != nil {
+		t.Fatalf("unable to write package file: %s", err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccRepositoryCheckDestroy("cloudsmith_repository.test"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPackageQuarantineConfig(packageFile, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("cloudsmith_package_quarantine.test", "is_quarantined", "true"),
+					resource.TestCheckResourceAttr("cloudsmith_package_quarantine.test", "quarantine_reason", "CVE-2023-0001"),
+				),
+			},
+			{
+				Config: testAccPackageQuarantineConfig(packageFile, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("cloudsmith_package_quarantine.test", "is_quarantined", "false"),
+				),
+			},
+			{
+				ResourceName: "cloudsmith_package_quarantine.test",
+				ImportState:  true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					resourceState := s.RootModule().Resources["cloudsmith_package_quarantine.test"]
+					return fmt.Sprintf(
+						"%s.%s.%s",
+						resourceState.Primary.Attributes["namespace"],
+						resourceState.Primary.Attributes["repository"],
+						resourceState.Primary.Attributes["identifier"],
+					), nil
+				},
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"quarantine_reason"},
+			},
+		},
+	})
+}
+
+func testAccPackageQuarantineConfig(packageFile string, quarantined bool) string {
+	return fmt.Sprintf(`
+resource "cloudsmith_repository" "test" {
+	name      = "terraform-acc-test-package-quarantine"
+	namespace = "%s"
+}
+
+resource "cloudsmith_package_upload" "test" {
+	namespace      = cloudsmith_repository.test.namespace
+	repository     = cloudsmith_repository.test.slug_perm
+	package_format = "raw"
+	package_file   = "%s"
+}
+
+resource "cloudsmith_package_quarantine" "test" {
+	namespace         = cloudsmith_repository.test.namespace
+	repository        = cloudsmith_repository.test.slug_perm
+	identifier        = cloudsmith_package_upload.test.slug_perm
+	is_quarantined    = %t
+	quarantine_reason = "CVE-2023-0001"
+}
+`, os.Getenv("CLOUDSMITH_NAMESPACE"), packageFile, quarantined)
+}
+
+// TestResourcePackageQuarantineDelete verifies that destroying the resource
+// only releases a package from quarantine if the resource quarantined it.
+func TestResourcePackageQuarantineDelete(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name                  string
+		quarantinedByResource bool
+		expectRelease         bool
+	}{
+		{name: "QuarantinedByResource", quarantinedByResource: true, expectRelease: true},
+		{name: "QuarantinedElsewhere", quarantinedByResource: false, expectRelease: false},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			released := false
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var body map[string]interface{}
+				_ = json.NewDecoder(r.Body).Decode(&body)
+				released = r.URL.Path == "/packages/namespace/repository/slug-perm/quarantine/" && body["release"] == true
+
+				w.Header().Set("Content-Type", "application/json")
+				_ = json.NewEncoder(w).Encode(map[string]interface{}{"slug_perm": "slug-perm"})
+			}))
+			defer server.Close()
+
+			d := schema.TestResourceDataRaw(t, resourcePackageQuarantine().Schema, map[string]interface{}{
+				"namespace":      "namespace",
+				"repository":     "repository",
+				"identifier":     "slug-perm",
+				"is_quarantined": true,
+			})
+			d.SetId("namespace.repository.slug-perm")
+			d.Set("quarantined_by_resource", tc.quarantinedByResource)
+
+			if err := resourcePackageQuarantineDelete(d, testProviderConfig(server.URL)); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if released != tc.expectRelease {
+				t.Errorf("expected package released to be %t, got %t", tc.expectRelease, released)
+			}
+		})
+	}
+}
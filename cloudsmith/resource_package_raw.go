@@ -0,0 +1,147 @@
+package cloudsmith
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// customizeDiffPackageRaw records the SHA256 hash of the local package file in
+// source_hash, forcing a new package to be uploaded whenever the content of
+// the file changes even though its path stays the same. If the file of an
+// existing package is missing, e.g. when applying from a different machine,
+// the stored hash is kept so the package isn't uploaded again.
+func customizeDiffPackageRaw(ctx context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	packageFile := d.Get("package_file").(string)
+	if packageFile == "" {
+		// package_file isn't known until apply, so neither is its hash.
+		return d.SetNewComputed("source_hash")
+	}
+
+	checksums, err := calculateChecksums(packageFile, false)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			if d.Id() == "" {
+				// The file may be created by another resource during apply.
+				return d.SetNewComputed("source_hash")
+			}
+			tflog.Warn(ctx, "Package file not found, keeping the stored source_hash", map[string]interface{}{
+				"package_file": packageFile,
+			})
+			return nil
+		}
+		return fmt.Errorf("error calculating checksums for %s: %w", packageFile, err)
+	}
+
+	if d.Get("source_hash").(string) == checksums.SHA256 {
+		return nil
+	}
+
+	if err := d.SetNew("source_hash", checksums.SHA256); err != nil {
+		return err
+	}
+	if d.Id() == "" {
+		return nil
+	}
+
+	return d.ForceNew("source_hash")
+}
+
+func resourcePackageRawCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	pc := m.(*providerConfig)
+
+	checksums, err := calculateChecksums(requiredString(d, "package_file"), false)
+	if err != nil {
+		return diag.Errorf("error calculating checksums for %s: %s", requiredString(d, "package_file"), err)
+	}
+	d.Set("source_hash", checksums.SHA256)
+
+	if err := createUploadedPackage(ctx, d, pc, "raw", requiredString(d, "package_file")); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourcePackageRawRead(ctx, d, m)
+}
+
+func resourcePackageRawRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	pc := m.(*providerConfig)
+
+	pkg, err := readUploadedPackage(d, pc)
+	if err != nil || pkg == nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("description", pkg.GetDescription())
+	d.Set("summary", pkg.GetSummary())
+
+	return nil
+}
+
+// resourcePackageRawUpdate only handles changes to sync_timeout, as every
+// other argument forces a new package to be uploaded.
+func resourcePackageRawUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return resourcePackageRawRead(ctx, d, m)
+}
+
+//nolint:funlen
+func resourcePackageRaw() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcePackageRawCreate,
+		ReadContext:   resourcePackageRawRead,
+		UpdateContext: resourcePackageRawUpdate,
+		DeleteContext: resourcePackageUploadDelete,
+
+		CustomizeDiff: customizeDiffPackageRaw,
+
+		Schema: packageResourceSchema(nil, map[string]*schema.Schema{
+			"content_type": {
+				Type:         schema.TypeString,
+				Description:  "A custom MIME content type for the package, e.g. `application/json`.",
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"description": {
+				Type:         schema.TypeString,
+				Description:  "A textual description of the package.",
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Description: "The name of the package. Defaults to the filename of the package file.",
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
+			"source_hash": {
+				Type:        schema.TypeString,
+				Description: "SHA256 hash of the local package file, used to upload the package again when the file changes.",
+				Computed:    true,
+			},
+			"summary": {
+				Type:         schema.TypeString,
+				Description:  "A one-liner synopsis of the package.",
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"version": {
+				Type:        schema.TypeString,
+				Description: "The version of the package.",
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
+		}),
+	}
+}
@@ -0,0 +1,97 @@
+//nolint:testpackage
+package cloudsmith
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// TestAccPackageRaw_basic spins up a repository, uploads a raw package from a
+// local file and verifies it exists and has the expected attributes. Then it
+// changes the content of the file without changing its path, which must force
+// a new upload. Finally it removes the file, which must not change the plan,
+// before tearing down the resources and verifying deletion.
+func TestAccPackageRaw_basic(t *testing.T) {
+	t.Parallel()
+
+	packageFile := filepath.Join(t.TempDir(), "terraform-acc-test-raw-package.txt")
+	writePackageFile := func(content string) {
+		if err := os.WriteFile(packageFile, []byte(content), 0o600); err != nil {
+			t.Fatalf("unable to write package file: %s", err)
+		}
+	}
+	writePackageFile("terraform-acc-test-raw-package")
+
+	var initialSlugPerm string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccPackageUploadCheckDestroy("cloudsmith_raw_package.test"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPackageRawConfig(packageFile),
+				Check: resource.ComposeTestCheckFunc(
+					testAccPackageUploadCheckExists("cloudsmith_raw_package.test"),
+					resource.TestCheckResourceAttr("cloudsmith_raw_package.test", "name", "terraform-acc-test-raw-package"),
+					resource.TestCheckResourceAttr("cloudsmith_raw_package.test", "version", "1.0.0"),
+					resource.TestCheckResourceAttr("cloudsmith_raw_package.test", "summary", "Terraform acceptance test package"),
+					resource.TestCheckResourceAttrPair("cloudsmith_raw_package.test", "source_hash", "cloudsmith_raw_package.test", "checksum_sha256"),
+					resource.TestCheckResourceAttrSet("cloudsmith_raw_package.test", "cdn_url"),
+					func(s *terraform.State) error {
+						initialSlugPerm = s.RootModule().Resources["cloudsmith_raw_package.test"].Primary.ID
+						return nil
+					},
+				),
+			},
+			{
+				PreConfig: func() { writePackageFile("terraform-acc-test-raw-package-updated") },
+				Config:    testAccPackageRawConfig(packageFile),
+				Check: resource.ComposeTestCheckFunc(
+					testAccPackageUploadCheckExists("cloudsmith_raw_package.test"),
+					resource.TestCheckResourceAttrPair("cloudsmith_raw_package.test", "source_hash", "cloudsmith_raw_package.test", "checksum_sha256"),
+					func(s *terraform.State) error {
+						//nolint:goerr113
+						if s.RootModule().Resources["cloudsmith_raw_package.test"].Primary.ID == initialSlugPerm {
+							return fmt.Errorf("expected package to be uploaded again after file content changed")
+						}
+						return nil
+					},
+				),
+			},
+			{
+				PreConfig: func() {
+					if err := os.Remove(packageFile); err != nil {
+						t.Fatalf("unable to remove package file: %s", err)
+					}
+				},
+				Config:   testAccPackageRawConfig(packageFile),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func testAccPackageRawConfig(packageFile string) string {
+	return fmt.Sprintf(`
+resource "cloudsmith_repository" "test" {
+	name      = "terraform-acc-test-raw-package"
+	namespace = "%s"
+}
+
+resource "cloudsmith_raw_package" "test" {
+	namespace    = "${cloudsmith_repository.test.namespace}"
+	repository   = "${cloudsmith_repository.test.slug_perm}"
+	package_file = "%s"
+	name         = "terraform-acc-test-raw-package"
+	version      = "1.0.0"
+	summary      = "Terraform acceptance test package"
+	content_type = "text/plain"
+}
+`, os.Getenv("CLOUDSMITH_NAMESPACE"), packageFile)
+}
@@ -0,0 +1,111 @@
+package cloudsmith
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourcePackageResyncCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	pc := m.(*providerConfig)
+
+	namespace := requiredString(d, "namespace")
+	repository := requiredString(d, "repository")
+	identifier := requiredString(d, "identifier")
+
+	req := pc.APIClient.PackagesApi.PackagesResync(pc.Auth, namespace, repository, identifier)
+	pkg, _, err := pc.APIClient.PackagesApi.PackagesResyncExecute(req)
+	if err != nil {
+		return diag.Errorf("error resyncing package (%s): %s", identifier, err)
+	}
+
+	d.SetId(pkg.GetSlugPerm())
+
+	if err := waitForPackageSync(ctx, pc, namespace, repository, d.Id(), defaultPackageSyncTimeout); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourcePackageResyncRead(ctx, d, m)
+}
+
+func resourcePackageResyncRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	pc := m.(*providerConfig)
+
+	namespace := requiredString(d, "namespace")
+	repository := requiredString(d, "repository")
+
+	// the resync is identified by the slug_perm of the resynced package, so if
+	// the package has been removed there is nothing left to track.
+	req := pc.APIClient.PackagesApi.PackagesRead(pc.Auth, namespace, repository, d.Id())
+	pkg, resp, err := pc.APIClient.PackagesApi.PackagesReadExecute(req)
+	if err != nil {
+		if is404(resp) {
+			d.SetId("")
+			return nil
+		}
+
+		return diag.FromErr(err)
+	}
+
+	d.Set("new_slug_perm", pkg.GetSlugPerm())
+
+	// namespace, repository and identifier are not returned from the package
+	// read endpoint, so we can use the values stored in resource state. We
+	// rely on ForceNew to ensure if any changes a new resync is triggered.
+	d.Set("namespace", namespace)
+	d.Set("repository", repository)
+	d.Set("identifier", requiredString(d, "identifier"))
+
+	return nil
+}
+
+// resourcePackageResyncDelete is a no-op, as a resync can't be undone.
+func resourcePackageResyncDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return nil
+}
+
+func resourcePackageResync() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcePackageResyncCreate,
+		ReadContext:   resourcePackageResyncRead,
+		DeleteContext: resourcePackageResyncDelete,
+
+		Schema: map[string]*schema.Schema{
+			"identifier": {
+				Type:         schema.TypeString,
+				Description:  "The slug_perm of the package to resync.",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"keepers": {
+				Type:        schema.TypeMap,
+				Description: "Arbitrary values that, when changed, trigger a new resync of the package.",
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"namespace": {
+				Type:         schema.TypeString,
+				Description:  "Namespace to which the package belongs.",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"new_slug_perm": {
+				Type:        schema.TypeString,
+				Description: "The slug_perm of the package after the resync, which may differ from identifier.",
+				Computed:    true,
+			},
+			"repository": {
+				Type:         schema.TypeString,
+				Description:  "Repository to which the package belongs.",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+		},
+	}
+}
@@ -0,0 +1,70 @@
+//nolint:testpackage
+package cloudsmith
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// TestAccPackageResync_basic uploads a raw package and resyncs it, then
+// changes the keepers to verify a second resync is triggered.
+func TestAccPackageResync_basic(t *testing.T) {
+	t.Parallel()
+
+	packageFile := filepath.Join(t.TempDir(), "terraform-acc-test-package-resync.txt")
+	if err := os.WriteFile(packageFile, []byte("terraform-acc-test-package-resync"), 0o600); err != nil {
+		t.Fatalf("unable to write package file: %s", err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccRepositoryCheckDestroy("cloudsmith_repository.test"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPackageResyncConfig(packageFile, "1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("cloudsmith_package_resync.test", "new_slug_perm"),
+					resource.TestCheckResourceAttr("cloudsmith_package_resync.test", "keepers.run", "1"),
+				),
+			},
+			{
+				Config: testAccPackageResyncConfig(packageFile, "2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("cloudsmith_package_resync.test", "new_slug_perm"),
+					resource.TestCheckResourceAttr("cloudsmith_package_resync.test", "keepers.run", "2"),
+				),
+			},
+		},
+	})
+}
+
+func testAccPackageResyncConfig(packageFile, run string) string {
+	return fmt.Sprintf(`
+resource "cloudsmith_repository" "test" {
+	name      = "terraform-acc-test-package-resync"
+	namespace = "%[1]s"
+}
+
+resource "cloudsmith_package_upload" "test" {
+	namespace      = cloudsmith_repository.test.namespace
+	repository     = cloudsmith_repository.test.slug_perm
+	package_format = "raw"
+	package_file   = "%[2]s"
+}
+
+resource "cloudsmith_package_resync" "test" {
+	namespace  = cloudsmith_repository.test.namespace
+	repository = cloudsmith_repository.test.slug_perm
+	identifier = cloudsmith_package_upload.test.slug_perm
+
+	keepers = {
+		run = "%[3]s"
+	}
+}
+`, os.Getenv("CLOUDSMITH_NAMESPACE"), packageFile, run)
+}
@@ -0,0 +1,147 @@
+package cloudsmith
+
+import (
+	"context"
+	"path/filepath"
+	"time"
+
+	"github.com/cloudsmith-io/cloudsmith-api-go"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourcePackageRpmCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	pc := m.(*providerConfig)
+
+	namespace := requiredString(d, "namespace")
+	repository := requiredString(d, "repository")
+	packageFile := requiredString(d, "package_file")
+
+	fileID, err := uploadPackageFile(ctx, pc, namespace, repository, packageFile)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	req := pc.APIClient.PackagesApi.PackagesUploadRpm(pc.Auth, namespace, repository)
+	req = req.Data(cloudsmith.RpmPackageUploadRequest{
+		Distribution: requiredString(d, "distribution"),
+		PackageFile:  fileID,
+		Republish:    optionalBool(d, "republish"),
+	})
+	pkg, resp, err := pc.APIClient.PackagesApi.PackagesUploadRpmExecute(req)
+	if err != nil {
+		if !is409(resp) {
+			return diag.Errorf("error creating rpm package: %s", err)
+		}
+
+		// The package has already been uploaded, so we adopt the existing
+		// package rather than failing. It was never uploaded by Terraform, so
+		// it's left in place when the resource is destroyed.
+		slugPerm, findErr := findDuplicatePackage(pc, namespace, repository, packageFile)
+		if findErr != nil {
+			return diag.FromErr(findErr)
+		}
+		if slugPerm == "" {
+			return diag.Errorf("error creating rpm package: %s", err)
+		}
+
+		tflog.Info(ctx, "Package already exists, adopting it", map[string]interface{}{
+			"package_file": filepath.Base(packageFile),
+			"slug_perm":    slugPerm,
+		})
+		d.SetId(slugPerm)
+		d.Set("adopted", true)
+
+		return resourcePackageRpmRead(ctx, d, m)
+	}
+
+	d.SetId(pkg.GetSlugPerm())
+	d.Set("adopted", false)
+
+	timeout := time.Duration(d.Get("sync_timeout").(int)) * time.Second
+	if err := waitForPackageSync(ctx, pc, namespace, repository, d.Id(), timeout); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourcePackageRpmRead(ctx, d, m)
+}
+
+func resourcePackageRpmRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	pc := m.(*providerConfig)
+
+	pkg, err := readUploadedPackage(d, pc)
+	if err != nil || pkg == nil {
+		return diag.FromErr(err)
+	}
+
+	arch := ""
+	if architectures := pkg.GetArchitectures(); len(architectures) > 0 {
+		arch = architectures[0].GetName()
+	}
+	d.Set("arch", arch)
+	d.Set("epoch", pkg.GetEpoch())
+	d.Set("release", pkg.GetRelease())
+
+	return nil
+}
+
+// resourcePackageRpmUpdate only handles changes to sync_timeout, as every
+// other argument forces a new package to be uploaded.
+func resourcePackageRpmUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return resourcePackageRpmRead(ctx, d, m)
+}
+
+// resourcePackageRpmDelete deletes the package, unless it already existed and
+// was adopted by the resource, in which case it's only removed from state.
+func resourcePackageRpmDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if requiredBool(d, "adopted") {
+		tflog.Info(ctx, "Package was adopted rather than uploaded, leaving it in place", map[string]interface{}{
+			"slug_perm": d.Id(),
+		})
+		return nil
+	}
+
+	return resourcePackageUploadDelete(ctx, d, m)
+}
+
+func resourcePackageRpm() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcePackageRpmCreate,
+		ReadContext:   resourcePackageRpmRead,
+		UpdateContext: resourcePackageRpmUpdate,
+		DeleteContext: resourcePackageRpmDelete,
+
+		Schema: packageResourceSchema([]string{".rpm"}, map[string]*schema.Schema{
+			"adopted": {
+				Type: schema.TypeBool,
+				Description: "Whether an identical package already existed in the repository and was adopted " +
+					"instead of being uploaded. Adopted packages are not deleted when the resource is destroyed.",
+				Computed: true,
+			},
+			"arch": {
+				Type:        schema.TypeString,
+				Description: "The architecture the package was built for, e.g. `x86_64`.",
+				Computed:    true,
+			},
+			"distribution": {
+				Type:         schema.TypeString,
+				Description:  "The distribution to store the package for, e.g. `el/8`.",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"epoch": {
+				Type:        schema.TypeInt,
+				Description: "The epoch of the package, as read from the package file.",
+				Computed:    true,
+			},
+			"release": {
+				Type:        schema.TypeString,
+				Description: "The release of the package, as read from the package file.",
+				Computed:    true,
+			},
+		}),
+	}
+}
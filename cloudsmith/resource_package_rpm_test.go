@@ -0,0 +1,112 @@
+//nolint:testpackage
+package cloudsmith
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// TestFindDuplicatePackage serves a list of packages sharing a filename and
+// verifies that only the package with the same content as the local file is
+// treated as a duplicate. An acceptance test is not provided as building a
+// valid RPM requires tooling that isn't available to the test suite.
+func TestFindDuplicatePackage(t *testing.T) {
+	t.Parallel()
+
+	packageFile := filepath.Join(t.TempDir(), "terraform-acc-test-1.0.0-1.x86_64.rpm")
+	if err := os.WriteFile(packageFile, []byte("terraform-acc-test-rpm"), 0o600); err != nil {
+		t.Fatalf("unable to write package file: %s", err)
+	}
+	checksums, err := calculateChecksums(packageFile, false)
+	if err != nil {
+		t.Fatalf("unable to calculate checksums: %s", err)
+	}
+
+	var query string
+	var packages []map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query().Get("query")
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Pagination-Pagetotal", "1")
+		_ = json.NewEncoder(w).Encode(packages)
+	}))
+	defer server.Close()
+
+	pc := testProviderConfig(server.URL)
+
+	packages = []map[string]string{
+		{"slug_perm": "different-content", "checksum_sha256": "0000"},
+		{"slug_perm": "same-content", "checksum_sha256": checksums.SHA256},
+	}
+	slugPerm, err := findDuplicatePackage(pc, "namespace", "repository", packageFile)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if slugPerm != "same-content" {
+		t.Fatalf("expected duplicate same-content, got %q", slugPerm)
+	}
+	if query != "filename:terraform-acc-test-1.0.0-1.x86_64.rpm" {
+		t.Fatalf("unexpected query %q", query)
+	}
+
+	packages = packages[:1]
+	slugPerm, err = findDuplicatePackage(pc, "namespace", "repository", packageFile)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if slugPerm != "" {
+		t.Fatalf("expected no duplicate, got %q", slugPerm)
+	}
+}
+
+// TestResourcePackageRpmDelete verifies that destroying the resource only
+// deletes packages it uploaded, leaving adopted packages in place.
+func TestResourcePackageRpmDelete(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		adopted       bool
+		expectDeleted bool
+	}{
+		{name: "Uploaded", adopted: false, expectDeleted: true},
+		{name: "Adopted", adopted: true, expectDeleted: false},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			deleted := false
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				deleted = r.Method == http.MethodDelete && r.URL.Path == "/packages/namespace/repository/slug-perm/"
+				w.WriteHeader(http.StatusNoContent)
+			}))
+			defer server.Close()
+
+			d := schema.TestResourceDataRaw(t, resourcePackageRpm().Schema, map[string]interface{}{
+				"namespace":    "namespace",
+				"repository":   "repository",
+				"distribution": "el/8",
+				"package_file": "package.rpm",
+			})
+			d.SetId("slug-perm")
+			d.Set("adopted", tc.adopted)
+
+			if diags := resourcePackageRpmDelete(context.Background(), d, testProviderConfig(server.URL)); diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			if deleted != tc.expectDeleted {
+				t.Errorf("expected package deleted to be %t, got %t", tc.expectDeleted, deleted)
+			}
+		})
+	}
+}
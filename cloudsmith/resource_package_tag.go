@@ -0,0 +1,170 @@
+package cloudsmith
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/cloudsmith-io/cloudsmith-api-go"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// packageTagType is the tag type under which tags applied by users (rather
+// than generated automatically by Cloudsmith) are returned.
+const packageTagType = "info"
+
+// packageInfoTags returns the user-applied tags from a package's tags.
+func packageInfoTags(tags map[string]interface{}) []string {
+	values, ok := tags[packageTagType].([]interface{})
+	if !ok {
+		return []string{}
+	}
+
+	infoTags := make([]string, 0, len(values))
+	for _, value := range values {
+		infoTags = append(infoTags, fmt.Sprint(value))
+	}
+	return infoTags
+}
+
+// tagPackage applies the given action to a package's tags.
+func tagPackage(pc *providerConfig, namespace, repository, identifier, action string, tags []string) error {
+	req := pc.APIClient.PackagesApi.PackagesTag(pc.Auth, namespace, repository, identifier)
+	req = req.Data(cloudsmith.PackageTagRequest{
+		Action: *cloudsmith.NewNullableString(cloudsmith.PtrString(action)),
+		Tags:   tags,
+	})
+	if _, _, err := pc.APIClient.PackagesApi.PackagesTagExecute(req); err != nil {
+		return fmt.Errorf("error tagging package (%s): %w", identifier, err)
+	}
+	return nil
+}
+
+// importPackageIdentifier imports resources which are identified by the
+// namespace, repository and slug_perm of a package.
+func importPackageIdentifier(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	idParts := strings.Split(d.Id(), ".")
+	if len(idParts) != 3 {
+		return nil, fmt.Errorf(
+			"invalid import ID, must be of the form <namespace_slug>.<repository_slug>.<package_slug_perm>, got: %s", d.Id(),
+		)
+	}
+
+	d.Set("namespace", idParts[0])
+	d.Set("repository", idParts[1])
+	d.Set("identifier", idParts[2])
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourcePackageTagCreateUpdate(d *schema.ResourceData, m interface{}) error {
+	pc := m.(*providerConfig)
+
+	namespace := requiredString(d, "namespace")
+	repository := requiredString(d, "repository")
+	identifier := requiredString(d, "identifier")
+
+	// replacing rather than adding means tags removed from the configuration
+	// are also removed from the package.
+	if err := tagPackage(pc, namespace, repository, identifier, "Replace", expandStrings(d, "tags")); err != nil {
+		return err
+	}
+
+	d.SetId(fmt.Sprintf("%s.%s.%s", namespace, repository, identifier))
+
+	return resourcePackageTagRead(d, m)
+}
+
+func resourcePackageTagRead(d *schema.ResourceData, m interface{}) error {
+	pc := m.(*providerConfig)
+
+	namespace := requiredString(d, "namespace")
+	repository := requiredString(d, "repository")
+	identifier := requiredString(d, "identifier")
+
+	req := pc.APIClient.PackagesApi.PackagesRead(pc.Auth, namespace, repository, identifier)
+	pkg, resp, err := pc.APIClient.PackagesApi.PackagesReadExecute(req)
+	if err != nil {
+		if is404(resp) {
+			d.SetId("")
+			return nil
+		}
+
+		return err
+	}
+
+	d.Set("tags", flattenStrings(packageInfoTags(pkg.GetTags())))
+
+	// namespace, repository and identifier are not returned from the package
+	// read endpoint, so we can use the values stored in resource state. We
+	// rely on ForceNew to ensure if any changes a new resource is created.
+	d.Set("namespace", namespace)
+	d.Set("repository", repository)
+	d.Set("identifier", identifier)
+
+	return nil
+}
+
+func resourcePackageTagDelete(d *schema.ResourceData, m interface{}) error {
+	pc := m.(*providerConfig)
+
+	namespace := requiredString(d, "namespace")
+	repository := requiredString(d, "repository")
+	identifier := requiredString(d, "identifier")
+
+	req := pc.APIClient.PackagesApi.PackagesTag(pc.Auth, namespace, repository, identifier)
+	req = req.Data(cloudsmith.PackageTagRequest{
+		Action: *cloudsmith.NewNullableString(cloudsmith.PtrString("Clear")),
+	})
+	if _, resp, err := pc.APIClient.PackagesApi.PackagesTagExecute(req); err != nil && !is404(resp) {
+		return fmt.Errorf("error clearing tags of package (%s): %w", identifier, err)
+	}
+
+	return nil
+}
+
+func resourcePackageTag() *schema.Resource {
+	return &schema.Resource{
+		Create: resourcePackageTagCreateUpdate,
+		Read:   resourcePackageTagRead,
+		Update: resourcePackageTagCreateUpdate,
+		Delete: resourcePackageTagDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: importPackageIdentifier,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"identifier": {
+				Type:         schema.TypeString,
+				Description:  "The slug_perm of the package to tag.",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"namespace": {
+				Type:         schema.TypeString,
+				Description:  "Namespace to which the package belongs.",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"repository": {
+				Type:         schema.TypeString,
+				Description:  "Repository to which the package belongs.",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"tags": {
+				Type:        schema.TypeSet,
+				Description: "The tags to apply to the package. Any other tags on the package are removed.",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+				Required: true,
+			},
+		},
+	}
+}
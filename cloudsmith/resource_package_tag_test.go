@@ -0,0 +1,83 @@
+//nolint:testpackage
+package cloudsmith
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// TestAccPackageTag_basic uploads a raw package, tags it, replaces its tags
+// and verifies the package carries only the configured tags after each step.
+func TestAccPackageTag_basic(t *testing.T) {
+	t.Parallel()
+
+	packageFile := filepath.Join(t.TempDir(), "terraform-acc-test-package-tag.txt")
+	if err := os.WriteFile(packageFile, []byte("terraform-acc-test-package-tag"), 0o600); err != nil {
+		t.Fatalf("unable to write package file: %s", err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccRepositoryCheckDestroy("cloudsmith_repository.test"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPackageTagConfig(packageFile, `["promoted", "prod"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("cloudsmith_package_tag.test", "tags.#", "2"),
+					resource.TestCheckTypeSetElemAttr("cloudsmith_package_tag.test", "tags.*", "promoted"),
+					resource.TestCheckTypeSetElemAttr("cloudsmith_package_tag.test", "tags.*", "prod"),
+				),
+			},
+			{
+				Config: testAccPackageTagConfig(packageFile, `["staging"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("cloudsmith_package_tag.test", "tags.#", "1"),
+					resource.TestCheckTypeSetElemAttr("cloudsmith_package_tag.test", "tags.*", "staging"),
+				),
+			},
+			{
+				ResourceName: "cloudsmith_package_tag.test",
+				ImportState:  true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					resourceState := s.RootModule().Resources["cloudsmith_package_tag.test"]
+					return fmt.Sprintf(
+						"%s.%s.%s",
+						resourceState.Primary.Attributes["namespace"],
+						resourceState.Primary.Attributes["repository"],
+						resourceState.Primary.Attributes["identifier"],
+					), nil
+				},
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccPackageTagConfig(packageFile, tags string) string {
+	return fmt.Sprintf(`
+resource "cloudsmith_repository" "test" {
+	name      = "terraform-acc-test-package-tag"
+	namespace = "%s"
+}
+
+resource "cloudsmith_package_upload" "test" {
+	namespace      = cloudsmith_repository.test.namespace
+	repository     = cloudsmith_repository.test.slug_perm
+	package_format = "raw"
+	package_file   = "%s"
+}
+
+resource "cloudsmith_package_tag" "test" {
+	namespace  = cloudsmith_repository.test.namespace
+	repository = cloudsmith_repository.test.slug_perm
+	identifier = cloudsmith_package_upload.test.slug_perm
+	tags       = %s
+}
+`, os.Getenv("CLOUDSMITH_NAMESPACE"), packageFile, tags)
+}
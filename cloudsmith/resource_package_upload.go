@@ -0,0 +1,304 @@
+package cloudsmith
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cloudsmith-io/cloudsmith-api-go"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/samber/lo"
+)
+
+// packageUploadFunc finalizes the upload of a previously uploaded file as a
+// package of a specific format, returning the slug_perm of the new package.
+type packageUploadFunc func(pc *providerConfig, d *schema.ResourceData, namespace, repository, fileID string) (string, error)
+
+// packageUploaders maps each supported package format to the function that
+// creates a package of that format from an uploaded file.
+var packageUploaders = map[string]packageUploadFunc{
+	"cargo": func(pc *providerConfig, d *schema.ResourceData, namespace, repository, fileID string) (string, error) {
+		req := pc.APIClient.PackagesApi.PackagesUploadCargo(pc.Auth, namespace, repository)
+		req = req.Data(cloudsmith.CargoPackageUploadRequest{
+			PackageFile: fileID,
+			Republish:   optionalBool(d, "republish"),
+		})
+		pkg, _, err := pc.APIClient.PackagesApi.PackagesUploadCargoExecute(req)
+		return pkg.GetSlugPerm(), err
+	},
+	"deb": func(pc *providerConfig, d *schema.ResourceData, namespace, repository, fileID string) (string, error) {
+		req := pc.APIClient.PackagesApi.PackagesUploadDeb(pc.Auth, namespace, repository)
+		req = req.Data(cloudsmith.DebPackageUploadRequest{
+			Component:    optionalString(d, "component"),
+			Distribution: requiredString(d, "distribution"),
+			PackageFile:  fileID,
+			Republish:    optionalBool(d, "republish"),
+		})
+		pkg, _, err := pc.APIClient.PackagesApi.PackagesUploadDebExecute(req)
+		return pkg.GetSlugPerm(), err
+	},
+	"docker": func(pc *providerConfig, d *schema.ResourceData, namespace, repository, fileID string) (string, error) {
+		req := pc.APIClient.PackagesApi.PackagesUploadDocker(pc.Auth, namespace, repository)
+		req = req.Data(cloudsmith.DockerPackageUploadRequest{
+			PackageFile: fileID,
+			Republish:   optionalBool(d, "republish"),
+		})
+		pkg, _, err := pc.APIClient.PackagesApi.PackagesUploadDockerExecute(req)
+		return pkg.GetSlugPerm(), err
+	},
+	"go": func(pc *providerConfig, d *schema.ResourceData, namespace, repository, fileID string) (string, error) {
+		req := pc.APIClient.PackagesApi.PackagesUploadGo(pc.Auth, namespace, repository)
+		req = req.Data(cloudsmith.GoPackageUploadRequest{
+			PackageFile: fileID,
+			Republish:   optionalBool(d, "republish"),
+		})
+		pkg, _, err := pc.APIClient.PackagesApi.PackagesUploadGoExecute(req)
+		return pkg.GetSlugPerm(), err
+	},
+	"helm": func(pc *providerConfig, d *schema.ResourceData, namespace, repository, fileID string) (string, error) {
+		req := pc.APIClient.PackagesApi.PackagesUploadHelm(pc.Auth, namespace, repository)
+		req = req.Data(cloudsmith.HelmPackageUploadRequest{
+			PackageFile: fileID,
+			Republish:   optionalBool(d, "republish"),
+		})
+		pkg, _, err := pc.APIClient.PackagesApi.PackagesUploadHelmExecute(req)
+		return pkg.GetSlugPerm(), err
+	},
+	"npm": func(pc *providerConfig, d *schema.ResourceData, namespace, repository, fileID string) (string, error) {
+		req := pc.APIClient.PackagesApi.PackagesUploadNpm(pc.Auth, namespace, repository)
+		req = req.Data(cloudsmith.NpmPackageUploadRequest{
+			NpmDistTag:  optionalString(d, "dist_tag"),
+			PackageFile: fileID,
+			Republish:   optionalBool(d, "republish"),
+		})
+		pkg, _, err := pc.APIClient.PackagesApi.PackagesUploadNpmExecute(req)
+		return pkg.GetSlugPerm(), err
+	},
+	"nuget": func(pc *providerConfig, d *schema.ResourceData, namespace, repository, fileID string) (string, error) {
+		req := pc.APIClient.PackagesApi.PackagesUploadNuget(pc.Auth, namespace, repository)
+		req = req.Data(cloudsmith.NugetPackageUploadRequest{
+			PackageFile: fileID,
+			Republish:   optionalBool(d, "republish"),
+		})
+		pkg, _, err := pc.APIClient.PackagesApi.PackagesUploadNugetExecute(req)
+		return pkg.GetSlugPerm(), err
+	},
+	"python": func(pc *providerConfig, d *schema.ResourceData, namespace, repository, fileID string) (string, error) {
+		req := pc.APIClient.PackagesApi.PackagesUploadPython(pc.Auth, namespace, repository)
+		req = req.Data(cloudsmith.PythonPackageUploadRequest{
+			PackageFile: fileID,
+			Republish:   optionalBool(d, "republish"),
+		})
+		pkg, _, err := pc.APIClient.PackagesApi.PackagesUploadPythonExecute(req)
+		return pkg.GetSlugPerm(), err
+	},
+	"raw": func(pc *providerConfig, d *schema.ResourceData, namespace, repository, fileID string) (string, error) {
+		req := pc.APIClient.PackagesApi.PackagesUploadRaw(pc.Auth, namespace, repository)
+		req = req.Data(cloudsmith.RawPackageUploadRequest{
+			ContentType: nullableString(d, "content_type"),
+			Description: nullableString(d, "description"),
+			Name:        nullableString(d, "name"),
+			PackageFile: fileID,
+			Republish:   optionalBool(d, "republish"),
+			Summary:     nullableString(d, "summary"),
+			Version:     nullableString(d, "version"),
+		})
+		pkg, _, err := pc.APIClient.PackagesApi.PackagesUploadRawExecute(req)
+		return pkg.GetSlugPerm(), err
+	},
+	"rpm": func(pc *providerConfig, d *schema.ResourceData, namespace, repository, fileID string) (string, error) {
+		req := pc.APIClient.PackagesApi.PackagesUploadRpm(pc.Auth, namespace, repository)
+		req = req.Data(cloudsmith.RpmPackageUploadRequest{
+			Distribution: requiredString(d, "distribution"),
+			PackageFile:  fileID,
+			Republish:    optionalBool(d, "republish"),
+		})
+		pkg, _, err := pc.APIClient.PackagesApi.PackagesUploadRpmExecute(req)
+		return pkg.GetSlugPerm(), err
+	},
+}
+
+func resourcePackageUploadCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	pc := m.(*providerConfig)
+
+	if err := validatePackageUploadFormat(d); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := createUploadedPackage(ctx, d, pc, requiredString(d, "package_format"), requiredString(d, "package_file")); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourcePackageUploadRead(ctx, d, m)
+}
+
+func resourcePackageUploadRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	pc := m.(*providerConfig)
+
+	_, err := readUploadedPackage(d, pc)
+	return diag.FromErr(err)
+}
+
+// resourcePackageUploadUpdate only handles changes to arguments that don't
+// affect the uploaded package (such as sync_timeout), as every other argument
+// forces a new package to be uploaded.
+func resourcePackageUploadUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return resourcePackageUploadRead(ctx, d, m)
+}
+
+func resourcePackageUploadDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	pc := m.(*providerConfig)
+
+	namespace := requiredString(d, "namespace")
+	repository := requiredString(d, "repository")
+
+	req := pc.APIClient.PackagesApi.PackagesDelete(pc.Auth, namespace, repository, d.Id())
+	resp, err := pc.APIClient.PackagesApi.PackagesDeleteExecute(req)
+	if err != nil && !is404(resp) {
+		return diag.Errorf("error deleting package (%s): %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+// validatePackageUploadFormat ensures the format-specific arguments required
+// by the selected package format have been provided.
+func validatePackageUploadFormat(d *schema.ResourceData) error {
+	format := requiredString(d, "package_format")
+	if (format == "deb" || format == "rpm") && requiredString(d, "distribution") == "" {
+		return fmt.Errorf("distribution must be set when uploading %s packages", format)
+	}
+	return nil
+}
+
+//nolint:funlen
+func resourcePackageUpload() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcePackageUploadCreate,
+		ReadContext:   resourcePackageUploadRead,
+		UpdateContext: resourcePackageUploadUpdate,
+		DeleteContext: resourcePackageUploadDelete,
+
+		Schema: map[string]*schema.Schema{
+			"cdn_url": {
+				Type:        schema.TypeString,
+				Description: "The URL from which the package can be downloaded.",
+				Computed:    true,
+			},
+			"checksum_sha256": {
+				Type:        schema.TypeString,
+				Description: "SHA256 hash of the package.",
+				Computed:    true,
+			},
+			"component": {
+				Type:         schema.TypeString,
+				Description:  "The component (channel) for the package (deb only), e.g. `main`.",
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"content_type": {
+				Type:         schema.TypeString,
+				Description:  "A custom MIME content type for the package, e.g. `application/json` (raw only).",
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"description": {
+				Type:         schema.TypeString,
+				Description:  "A textual description of the package (raw only).",
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"distribution": {
+				Type: schema.TypeString,
+				Description: "The distribution to store the package for, e.g. `ubuntu/focal` or `el/8`. " +
+					"Required for deb and rpm packages.",
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"filename": {
+				Type:        schema.TypeString,
+				Description: "The filename of the package.",
+				Computed:    true,
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Description: "The name of the package. Can only be set for raw packages, otherwise it is read from the package file.",
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
+			"namespace": {
+				Type:         schema.TypeString,
+				Description:  "Namespace to which the package will be uploaded.",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"package_file": {
+				Type:         schema.TypeString,
+				Description:  "Path to the local file to upload.",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"package_format": {
+				Type:         schema.TypeString,
+				Description:  "The format of the package being uploaded.",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice(lo.Keys(packageUploaders), false),
+			},
+			"republish": {
+				Type: schema.TypeBool,
+				Description: "If true, the uploaded package will overwrite any others with the same " +
+					"attributes (e.g. same version).",
+				Optional: true,
+				ForceNew: true,
+			},
+			"repository": {
+				Type:         schema.TypeString,
+				Description:  "Repository to which the package will be uploaded.",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"slug": {
+				Type:        schema.TypeString,
+				Description: "The slug identifies the package in URIs.",
+				Computed:    true,
+			},
+			"slug_perm": {
+				Type: schema.TypeString,
+				Description: "The slug_perm immutably identifies the package. " +
+					"It will never change once a package has been created.",
+				Computed: true,
+			},
+			"summary": {
+				Type:         schema.TypeString,
+				Description:  "A one-liner synopsis of the package (raw only).",
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"sync_timeout": {
+				Type:         schema.TypeInt,
+				Description:  "The time in seconds to wait for the package to finish synchronising.",
+				Optional:     true,
+				Default:      int(defaultPackageSyncTimeout.Seconds()),
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"version": {
+				Type:        schema.TypeString,
+				Description: "The version of the package. Can only be set for raw packages, otherwise it is read from the package file.",
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
+		},
+	}
+}
@@ -0,0 +1,138 @@
+//nolint:testpackage
+package cloudsmith
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// TestAccPackageUpload_basic spins up a repository, uploads a raw package from
+// a local file and verifies it exists and has the expected attributes. Then it
+// changes the version, which forces a new upload, before tearing down the
+// resources and verifying deletion.
+func TestAccPackageUpload_basic(t *testing.T) {
+	t.Parallel()
+
+	packageFile := filepath.Join(t.TempDir(), "terraform-acc-test-package-upload.txt")
+	if err := os.WriteFile(packageFile, []byte("terraform-acc-test-package-upload"), 0o600); err != nil {
+		t.Fatalf("unable to write package file: %s", err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccPackageUploadCheckDestroy("cloudsmith_package_upload.test"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPackageUploadConfig(packageFile, "1.0.0"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccPackageUploadCheckExists("cloudsmith_package_upload.test"),
+					resource.TestCheckResourceAttr("cloudsmith_package_upload.test", "name", "terraform-acc-test-package-upload"),
+					resource.TestCheckResourceAttr("cloudsmith_package_upload.test", "version", "1.0.0"),
+					resource.TestCheckResourceAttr("cloudsmith_package_upload.test", "filename", "terraform-acc-test-package-upload.txt"),
+					resource.TestCheckResourceAttrSet("cloudsmith_package_upload.test", "checksum_sha256"),
+					resource.TestCheckResourceAttrSet("cloudsmith_package_upload.test", "slug_perm"),
+				),
+			},
+			{
+				Config: testAccPackageUploadConfig(packageFile, "1.0.1"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccPackageUploadCheckExists("cloudsmith_package_upload.test"),
+					resource.TestCheckResourceAttr("cloudsmith_package_upload.test", "version", "1.0.1"),
+				),
+			},
+		},
+	})
+}
+
+//nolint:goerr113
+func testAccPackageUploadCheckDestroy(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		resourceState, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", resourceName)
+		}
+
+		if resourceState.Primary.ID == "" {
+			return fmt.Errorf("resource id not set")
+		}
+
+		pc := testAccProvider.Meta().(*providerConfig)
+
+		namespace := os.Getenv("CLOUDSMITH_NAMESPACE")
+		repository := resourceState.Primary.Attributes["repository"]
+		pkg := resourceState.Primary.ID
+
+		req := pc.APIClient.PackagesApi.PackagesRead(pc.Auth, namespace, repository, pkg)
+		_, resp, err := pc.APIClient.PackagesApi.PackagesReadExecute(req)
+		if err != nil && !is404(resp) {
+			return fmt.Errorf("unable to verify package deletion: %w", err)
+		} else if is200(resp) {
+			return fmt.Errorf("unable to verify package deletion: still exists: %s/%s/%s", namespace, repository, pkg)
+		}
+		defer resp.Body.Close()
+
+		rreq := pc.APIClient.ReposApi.ReposRead(pc.Auth, namespace, repository)
+		_, resp, err = pc.APIClient.ReposApi.ReposReadExecute(rreq)
+		if err != nil && !is404(resp) {
+			return fmt.Errorf("unable to verify repository deletion: %w", err)
+		} else if is200(resp) {
+			return fmt.Errorf("unable to verify repository deletion: still exists: %s/%s", namespace, repository)
+		}
+		defer resp.Body.Close()
+
+		return nil
+	}
+}
+
+//nolint:goerr113
+func testAccPackageUploadCheckExists(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		resourceState, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", resourceName)
+		}
+
+		if resourceState.Primary.ID == "" {
+			return fmt.Errorf("resource id not set")
+		}
+
+		pc := testAccProvider.Meta().(*providerConfig)
+
+		namespace := os.Getenv("CLOUDSMITH_NAMESPACE")
+		repository := resourceState.Primary.Attributes["repository"]
+		pkg := resourceState.Primary.ID
+
+		req := pc.APIClient.PackagesApi.PackagesRead(pc.Auth, namespace, repository, pkg)
+		_, resp, err := pc.APIClient.PackagesApi.PackagesReadExecute(req)
+		if err != nil {
+			return fmt.Errorf("unable to verify package existence: %w", err)
+		}
+		defer resp.Body.Close()
+
+		return nil
+	}
+}
+
+func testAccPackageUploadConfig(packageFile, version string) string {
+	return fmt.Sprintf(`
+resource "cloudsmith_repository" "test" {
+	name      = "terraform-acc-test-package-upload"
+	namespace = "%s"
+}
+
+resource "cloudsmith_package_upload" "test" {
+	namespace      = "${cloudsmith_repository.test.namespace}"
+	repository     = "${cloudsmith_repository.test.slug_perm}"
+	package_format = "raw"
+	package_file   = "%s"
+	name           = "terraform-acc-test-package-upload"
+	version        = "%s"
+}
+`, os.Getenv("CLOUDSMITH_NAMESPACE"), packageFile, version)
+}
@@ -24,6 +24,77 @@ func importRepository(ctx context.Context, d *schema.ResourceData, m interface{}
 	return []*schema.ResourceData{d}, nil
 }
 
+// immutableRepositorySettings are the package settings enforced on immutable
+// repositories, so that only admins can delete, move or replace packages once
+// they've been published, and the API rejects anyone else doing so.
+var immutableRepositorySettings = []struct {
+	name  string
+	value interface{}
+}{
+	{name: "delete_own", value: false},
+	{name: "delete_packages", value: "Admin"},
+	{name: "move_own", value: false},
+	{name: "move_packages", value: "Admin"},
+	{name: "replace_packages", value: "Admin"},
+	{name: "replace_packages_by_default", value: false},
+}
+
+// immutableRepositorySetting returns the value enforced for the named setting
+// if the repository is immutable.
+func immutableRepositorySetting(d *schema.ResourceData, name string) (interface{}, bool) {
+	if !requiredBool(d, "immutable") {
+		return nil, false
+	}
+	for _, setting := range immutableRepositorySettings {
+		if setting.name == name {
+			return setting.value, true
+		}
+	}
+	return nil, false
+}
+
+// repositoryBoolSetting retrieves an optional boolean repository setting,
+// overridden by the enforced value if the repository is immutable.
+func repositoryBoolSetting(d *schema.ResourceData, name string) *bool {
+	if value, ok := immutableRepositorySetting(d, name); ok {
+		return cloudsmith.PtrBool(value.(bool))
+	}
+	return optionalBool(d, name)
+}
+
+// repositoryStringSetting retrieves an optional string repository setting,
+// overridden by the enforced value if the repository is immutable.
+func repositoryStringSetting(d *schema.ResourceData, name string) *string {
+	if value, ok := immutableRepositorySetting(d, name); ok {
+		return cloudsmith.PtrString(value.(string))
+	}
+	return optionalString(d, name)
+}
+
+// customizeDiffRepository plans the package settings enforced on immutable
+// repositories, and rejects configurations which set them to anything else.
+func customizeDiffRepository(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if !d.Get("immutable").(bool) {
+		return nil
+	}
+
+	config := d.GetRawConfig()
+	for _, setting := range immutableRepositorySettings {
+		if d.Get(setting.name) == setting.value {
+			continue
+		}
+		if !config.IsNull() {
+			if v := config.GetAttr(setting.name); v.IsKnown() && !v.IsNull() {
+				return fmt.Errorf("`%s` must not be set to %v when `immutable` is true", setting.name, d.Get(setting.name))
+			}
+		}
+		if err := d.SetNew(setting.name, setting.value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func resourceRepositoryStorageRegionUpdate(d *schema.ResourceData, m interface{}) error {
 	pc := m.(*providerConfig)
 
@@ -51,20 +122,20 @@ func resourceRepositoryCreate(d *schema.ResourceData, m interface{}) error {
 		CopyOwn:                          optionalBool(d, "copy_own"),
 		CopyPackages:                     optionalString(d, "copy_packages"),
 		DefaultPrivilege:                 optionalString(d, "default_privilege"),
-		DeleteOwn:                        optionalBool(d, "delete_own"),
-		DeletePackages:                   optionalString(d, "delete_packages"),
+		DeleteOwn:                        repositoryBoolSetting(d, "delete_own"),
+		DeletePackages:                   repositoryStringSetting(d, "delete_packages"),
 		Description:                      optionalString(d, "description"),
 		DockerRefreshTokensEnabled:       optionalBool(d, "docker_refresh_tokens_enabled"),
 		IndexFiles:                       optionalBool(d, "index_files"),
-		MoveOwn:                          optionalBool(d, "move_own"),
-		MovePackages:                     optionalString(d, "move_packages"),
+		MoveOwn:                          repositoryBoolSetting(d, "move_own"),
+		MovePackages:                     repositoryStringSetting(d, "move_packages"),
 		Name:                             requiredString(d, "name"),
 		ProxyNpmjs:                       optionalBool(d, "proxy_npmjs"),
 		ProxyPypi:                        optionalBool(d, "proxy_pypi"),
 		RawPackageIndexEnabled:           optionalBool(d, "raw_package_index_enabled"),
 		RawPackageIndexSignaturesEnabled: optionalBool(d, "raw_package_index_signatures_enabled"),
-		ReplacePackages:                  optionalString(d, "replace_packages"),
-		ReplacePackagesByDefault:         optionalBool(d, "replace_packages_by_default"),
+		ReplacePackages:                  repositoryStringSetting(d, "replace_packages"),
+		ReplacePackagesByDefault:         repositoryBoolSetting(d, "replace_packages_by_default"),
 		RepositoryTypeStr:                optionalString(d, "repository_type"),
 		ResyncOwn:                        optionalBool(d, "resync_own"),
 		ResyncPackages:                   optionalString(d, "resync_packages"),
@@ -208,20 +279,20 @@ func resourceRepositoryUpdate(d *schema.ResourceData, m interface{}) error {
 		CopyOwn:                          optionalBool(d, "copy_own"),
 		CopyPackages:                     optionalString(d, "copy_packages"),
 		DefaultPrivilege:                 optionalString(d, "default_privilege"),
-		DeleteOwn:                        optionalBool(d, "delete_own"),
-		DeletePackages:                   optionalString(d, "delete_packages"),
+		DeleteOwn:                        repositoryBoolSetting(d, "delete_own"),
+		DeletePackages:                   repositoryStringSetting(d, "delete_packages"),
 		Description:                      optionalString(d, "description"),
 		DockerRefreshTokensEnabled:       optionalBool(d, "docker_refresh_tokens_enabled"),
 		IndexFiles:                       optionalBool(d, "index_files"),
-		MoveOwn:                          optionalBool(d, "move_own"),
-		MovePackages:                     optionalString(d, "move_packages"),
+		MoveOwn:                          repositoryBoolSetting(d, "move_own"),
+		MovePackages:                     repositoryStringSetting(d, "move_packages"),
 		Name:                             optionalString(d, "name"),
 		ProxyNpmjs:                       optionalBool(d, "proxy_npmjs"),
 		ProxyPypi:                        optionalBool(d, "proxy_pypi"),
 		RawPackageIndexEnabled:           optionalBool(d, "raw_package_index_enabled"),
 		RawPackageIndexSignaturesEnabled: optionalBool(d, "raw_package_index_signatures_enabled"),
-		ReplacePackages:                  optionalString(d, "replace_packages"),
-		ReplacePackagesByDefault:         optionalBool(d, "replace_packages_by_default"),
+		ReplacePackages:                  repositoryStringSetting(d, "replace_packages"),
+		ReplacePackagesByDefault:         repositoryBoolSetting(d, "replace_packages_by_default"),
 		ResyncOwn:                        optionalBool(d, "resync_own"),
 		ResyncPackages:                   optionalString(d, "resync_packages"),
 		ScanOwn:                          optionalBool(d, "scan_own"),
@@ -260,11 +331,51 @@ func resourceRepositoryUpdate(d *schema.ResourceData, m interface{}) error {
 	return resourceRepositoryRead(d, m)
 }
 
+// resourceRepositoryDeletePackages removes every package from the given
+// repository, waiting between each page of deletions until the repository is
+// empty. Packages are always fetched from the first page, as each deletion
+// shifts the remaining packages forward in the listing.
+func resourceRepositoryDeletePackages(pc *providerConfig, namespace, repository string, timeout, interval time.Duration) error {
+	checkerFunc := func() error {
+		packages, _, err := retrievePackageListPage(pc, namespace, repository, "", 100, 1)
+		if err != nil {
+			return fmt.Errorf("error listing packages in repository (%s): %w", repository, err)
+		}
+
+		if len(packages) == 0 {
+			return nil
+		}
+
+		for _, pkg := range packages {
+			req := pc.APIClient.PackagesApi.PackagesDelete(pc.Auth, namespace, repository, pkg.GetSlugPerm())
+			if resp, err := pc.APIClient.PackagesApi.PackagesDeleteExecute(req); err != nil && !is404(resp) {
+				return fmt.Errorf("error deleting package (%s) from repository (%s): %w", pkg.GetSlugPerm(), repository, err)
+			}
+		}
+		return errKeepWaiting
+	}
+	if err := waiter(checkerFunc, timeout, interval); err != nil {
+		return fmt.Errorf("error waiting for packages in repository (%s) to be deleted: %w", repository, err)
+	}
+
+	return nil
+}
+
 func resourceRepositoryDelete(d *schema.ResourceData, m interface{}) error {
 	pc := m.(*providerConfig)
 
 	namespace := requiredString(d, "namespace")
 
+	if requiredBool(d, "immutable") && !requiredBool(d, "force_destroy") {
+		return fmt.Errorf("repository %s.%s is immutable, set force_destroy to true to delete it", namespace, d.Id())
+	}
+
+	if requiredBool(d, "force_destroy") {
+		if err := resourceRepositoryDeletePackages(pc, namespace, d.Id(), defaultDeletionTimeout, defaultDeletionInterval); err != nil {
+			return err
+		}
+	}
+
 	req := pc.APIClient.ReposApi.ReposDelete(pc.Auth, namespace, d.Id())
 	_, err := pc.APIClient.ReposApi.ReposDeleteExecute(req)
 	if err != nil {
@@ -306,6 +417,8 @@ func resourceRepository() *schema.Resource {
 		Update: resourceRepositoryUpdate,
 		Delete: resourceRepositoryDelete,
 
+		CustomizeDiff: customizeDiffRepository,
+
 		Importer: &schema.ResourceImporter{
 			StateContext: importRepository,
 		},
@@ -405,6 +518,22 @@ func resourceRepository() *schema.Resource {
 				Optional: true,
 				Computed: true,
 			},
+			"force_destroy": {
+				Type: schema.TypeBool,
+				Description: "If true, all packages in the repository will be deleted before the " +
+					"repository itself is deleted.",
+				Optional: true,
+				Default:  false,
+			},
+			"immutable": {
+				Type: schema.TypeBool,
+				Description: "If true, only admins can delete, move or replace packages in the repository, " +
+					"which the API enforces by setting delete_own, move_own and replace_packages_by_default to " +
+					"false and delete_packages, move_packages and replace_packages to Admin. The repository " +
+					"also can't be destroyed unless force_destroy is true.",
+				Optional: true,
+				Default:  false,
+			},
 			"index_files": {
 				Type: schema.TypeBool,
 				Description: "If checked, files contained in packages will be indexed, which increase the " +
@@ -213,7 +213,7 @@ func resourceRepositoryGeoIpRules() *schema.Resource {
 				Optional:    true,
 				Elem: &schema.Schema{
 					Type:         schema.TypeString,
-					ValidateFunc: validation.StringIsNotEmpty,
+					ValidateFunc: validation.StringInSlice(countryCodes, false),
 				},
 			},
 			CountryCodeDeny: {
@@ -222,7 +222,7 @@ func resourceRepositoryGeoIpRules() *schema.Resource {
 				Optional:    true,
 				Elem: &schema.Schema{
 					Type:         schema.TypeString,
-					ValidateFunc: validation.StringIsNotEmpty,
+					ValidateFunc: validation.StringInSlice(countryCodes, false),
 				},
 			},
 			Namespace: {
@@ -4,6 +4,7 @@ package cloudsmith
 import (
 	"fmt"
 	"os"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -63,6 +64,11 @@ func TestAccRepositoryGeoIpRules_basic(t *testing.T) {
 		Providers:    testAccProviders,
 		CheckDestroy: testAccRepositoryGeoIpRulesCheckDestroy(ResourceName),
 		Steps: []resource.TestStep{
+			{
+				Config:      fmt.Sprintf(configTemplateWithRules, namespace, InitialCidrAllow, InitialCidrDeny, "XX", InitialCountryCodeDeny),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`expected country_code_allow.* to be one of`),
+			},
 			{
 				Config: testAccRepositoryGeoIpRulesConfigCreate,
 				Check: resource.ComposeTestCheckFunc(
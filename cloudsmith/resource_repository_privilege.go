@@ -0,0 +1,309 @@
+package cloudsmith
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/cloudsmith-io/cloudsmith-api-go"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/samber/lo"
+)
+
+// listRepositoryPrivileges retrieves every privilege entry for a repository,
+// following pagination until all pages have been read. The returned response
+// is that of the last request made, so callers can check it for a 404.
+func listRepositoryPrivileges(pc *providerConfig, organization, repository string) ([]cloudsmith.RepositoryPrivilegeDict, *http.Response, error) {
+	var allPrivileges []cloudsmith.RepositoryPrivilegeDict
+	page := int64(1)
+	pageSize := int64(1000)
+
+	for {
+		req := pc.APIClient.ReposApi.ReposPrivilegesList(pc.Auth, organization, repository)
+		req = req.Page(page)
+		req = req.PageSize(pageSize)
+		privileges, resp, err := pc.APIClient.ReposApi.ReposPrivilegesListExecute(req)
+		if err != nil {
+			return nil, resp, err
+		}
+
+		allPrivileges = append(allPrivileges, privileges.GetPrivileges()...)
+
+		// Check if we have retrieved all pages
+		if int64(len(privileges.GetPrivileges())) < pageSize {
+			return allPrivileges, resp, nil
+		}
+		page++
+	}
+}
+
+// normalizeRepositoryPrivilege converts a case-insensitive privilege level
+// into the form expected by the Cloudsmith API, e.g. "read" to "Read".
+func normalizeRepositoryPrivilege(privilege string) string {
+	normalized, _ := lo.Find(repositoryPrivileges, func(p string) bool {
+		return strings.EqualFold(p, privilege)
+	})
+	return normalized
+}
+
+// repositoryPrivilegeSlugs returns the slugs of all users and teams holding
+// the given privilege level.
+func repositoryPrivilegeSlugs(privs []cloudsmith.RepositoryPrivilegeDict, privilege string) ([]string, []string) {
+	var members, teams []string
+	for _, p := range privs {
+		if !strings.EqualFold(p.GetPrivilege(), privilege) {
+			continue
+		}
+		if p.HasUser() {
+			members = append(members, p.GetUser())
+		}
+		if p.HasTeam() {
+			teams = append(teams, p.GetTeam())
+		}
+	}
+	return members, teams
+}
+
+// buildRepositoryPrivileges converts member and team slugs into privilege
+// entries at the given privilege level.
+func buildRepositoryPrivileges(privilege string, members, teams []string) []cloudsmith.RepositoryPrivilegeDict {
+	privs := []cloudsmith.RepositoryPrivilegeDict{}
+	for _, member := range members {
+		p := cloudsmith.RepositoryPrivilegeDict{Privilege: privilege}
+		p.SetUser(member)
+		privs = append(privs, p)
+	}
+	for _, team := range teams {
+		p := cloudsmith.RepositoryPrivilegeDict{Privilege: privilege}
+		p.SetTeam(team)
+		privs = append(privs, p)
+	}
+	return privs
+}
+
+// removeRepositoryPrivileges returns privs without any entries at the given
+// privilege level that reference one of the given members or teams.
+func removeRepositoryPrivileges(privs []cloudsmith.RepositoryPrivilegeDict, privilege string, members, teams []string) []cloudsmith.RepositoryPrivilegeDict {
+	return lo.Reject(privs, func(p cloudsmith.RepositoryPrivilegeDict, index int) bool {
+		if !strings.EqualFold(p.GetPrivilege(), privilege) {
+			return false
+		}
+		return (p.HasUser() && lo.Contains(members, p.GetUser())) ||
+			(p.HasTeam() && lo.Contains(teams, p.GetTeam()))
+	})
+}
+
+// applyRepositoryPrivilegeChanges grants the added members and teams the given
+// privilege level and revokes it from the removed ones. Grants are made with
+// a partial update so that unrelated privileges are left untouched, whereas
+// revocations require the full privilege list to be replaced.
+func applyRepositoryPrivilegeChanges(pc *providerConfig, organization, repository, privilege string, addMembers, addTeams, removeMembers, removeTeams []string) error {
+	if len(removeMembers) > 0 || len(removeTeams) > 0 {
+		userReq := pc.APIClient.UserApi.UserSelf(pc.Auth)
+		userSelf, _, err := pc.APIClient.UserApi.UserSelfExecute(userReq)
+		if err != nil {
+			return fmt.Errorf("error retrieving authenticated account for lockout prevention: %w", err)
+		}
+		if lo.Contains(removeMembers, userSelf.GetSlug()) {
+			return fmt.Errorf(
+				"repository_privilege (%s.%s): refusing to revoke %s privilege from authenticated account '%s' to avoid potential lockout",
+				organization, repository, privilege, userSelf.GetSlug(),
+			)
+		}
+
+		current, _, err := listRepositoryPrivileges(pc, organization, repository)
+		if err != nil {
+			return err
+		}
+
+		req := pc.APIClient.ReposApi.ReposPrivilegesUpdate(pc.Auth, organization, repository)
+		req = req.Data(cloudsmith.RepositoryPrivilegeInputRequest{
+			Privileges: removeRepositoryPrivileges(current, privilege, removeMembers, removeTeams),
+		})
+		if _, err := pc.APIClient.ReposApi.ReposPrivilegesUpdateExecute(req); err != nil {
+			return err
+		}
+	}
+
+	if len(addMembers) > 0 || len(addTeams) > 0 {
+		req := pc.APIClient.ReposApi.ReposPrivilegesPartialUpdate(pc.Auth, organization, repository)
+		req = req.Data(cloudsmith.RepositoryPrivilegeInputRequestPatch{
+			Privileges: buildRepositoryPrivileges(privilege, addMembers, addTeams),
+		})
+		if _, err := pc.APIClient.ReposApi.ReposPrivilegesPartialUpdateExecute(req); err != nil {
+			return err
+		}
+	}
+
+	checkerFunc := func() error {
+		current, _, err := listRepositoryPrivileges(pc, organization, repository)
+		if err != nil {
+			return err
+		}
+		members, teams := repositoryPrivilegeSlugs(current, privilege)
+		if !lo.Every(members, addMembers) || !lo.Every(teams, addTeams) ||
+			lo.Some(members, removeMembers) || lo.Some(teams, removeTeams) {
+			return errKeepWaiting
+		}
+		return nil
+	}
+	if err := waiter(checkerFunc, defaultUpdateTimeout, defaultUpdateInterval); err != nil {
+		return fmt.Errorf("error waiting for %s privileges (%s.%s) to be updated: %w", privilege, organization, repository, err)
+	}
+
+	return nil
+}
+
+func importRepositoryPrivilege(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	idParts := strings.Split(d.Id(), ".")
+	if len(idParts) != 3 {
+		return nil, fmt.Errorf(
+			"invalid import ID, must be of the form <namespace_slug>.<repository_slug>.<privilege>, got: %s", d.Id(),
+		)
+	}
+
+	d.Set("namespace", idParts[0])
+	d.Set("repository", idParts[1])
+	d.Set("privilege", idParts[2])
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceRepositoryPrivilegeCreateUpdate(d *schema.ResourceData, m interface{}) error {
+	pc := m.(*providerConfig)
+
+	namespace := requiredString(d, "namespace")
+	repository := requiredString(d, "repository")
+	privilege := normalizeRepositoryPrivilege(requiredString(d, "privilege"))
+
+	oldMembers, newMembers := d.GetChange("members")
+	oldTeams, newTeams := d.GetChange("teams")
+
+	addMembers := setToStrings(newMembers.(*schema.Set).Difference(oldMembers.(*schema.Set)))
+	removeMembers := setToStrings(oldMembers.(*schema.Set).Difference(newMembers.(*schema.Set)))
+	addTeams := setToStrings(newTeams.(*schema.Set).Difference(oldTeams.(*schema.Set)))
+	removeTeams := setToStrings(oldTeams.(*schema.Set).Difference(newTeams.(*schema.Set)))
+
+	if err := applyRepositoryPrivilegeChanges(pc, namespace, repository, privilege, addMembers, addTeams, removeMembers, removeTeams); err != nil {
+		return err
+	}
+
+	d.SetId(fmt.Sprintf("%s.%s.%s", namespace, repository, requiredString(d, "privilege")))
+
+	return resourceRepositoryPrivilegeRead(d, m)
+}
+
+func resourceRepositoryPrivilegeRead(d *schema.ResourceData, m interface{}) error {
+	pc := m.(*providerConfig)
+
+	namespace := requiredString(d, "namespace")
+	repository := requiredString(d, "repository")
+	privilege := requiredString(d, "privilege")
+
+	privileges, resp, err := listRepositoryPrivileges(pc, namespace, repository)
+	if err != nil {
+		if is404(resp) {
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+
+	members, teams := repositoryPrivilegeSlugs(privileges, privilege)
+
+	// Other users and teams may hold the same privilege level, whether granted
+	// outside of Terraform or by another instance of this resource, so only
+	// those already managed by this resource are tracked. When nothing is
+	// tracked yet, as after an import, every holder of the level is adopted.
+	stateMembers := expandStrings(d, "members")
+	stateTeams := expandStrings(d, "teams")
+	if len(stateMembers) > 0 || len(stateTeams) > 0 {
+		members = lo.Intersect(stateMembers, members)
+		teams = lo.Intersect(stateTeams, teams)
+	}
+
+	d.Set("members", flattenStrings(members))
+	d.Set("teams", flattenStrings(teams))
+
+	// namespace, repository and privilege are not returned from the
+	// privileges read endpoint, so we can use the values stored in resource
+	// state. We rely on ForceNew to ensure if any changes a new resource is
+	// created.
+	d.Set("namespace", namespace)
+	d.Set("repository", repository)
+	d.Set("privilege", privilege)
+
+	return nil
+}
+
+func resourceRepositoryPrivilegeDelete(d *schema.ResourceData, m interface{}) error {
+	pc := m.(*providerConfig)
+
+	namespace := requiredString(d, "namespace")
+	repository := requiredString(d, "repository")
+	privilege := normalizeRepositoryPrivilege(requiredString(d, "privilege"))
+
+	members := expandStrings(d, "members")
+	teams := expandStrings(d, "teams")
+
+	return applyRepositoryPrivilegeChanges(pc, namespace, repository, privilege, nil, nil, members, teams)
+}
+
+//nolint:funlen
+func resourceRepositoryPrivilege() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceRepositoryPrivilegeCreateUpdate,
+		Read:   resourceRepositoryPrivilegeRead,
+		Update: resourceRepositoryPrivilegeCreateUpdate,
+		Delete: resourceRepositoryPrivilegeDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: importRepositoryPrivilege,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"members": {
+				Type:        schema.TypeSet,
+				Description: "Slugs of the users to grant the privilege to.",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+				Optional:     true,
+				AtLeastOneOf: []string{"members", "teams"},
+			},
+			"namespace": {
+				Type:         schema.TypeString,
+				Description:  "Namespace to which this repository belongs.",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"privilege": {
+				Type:         schema.TypeString,
+				Description:  "The privilege level to grant, one of `Read`, `Write` or `Admin` (case-insensitive).",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice(repositoryPrivileges, true),
+			},
+			"repository": {
+				Type:         schema.TypeString,
+				Description:  "Repository to which the privilege is granted.",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"teams": {
+				Type:        schema.TypeSet,
+				Description: "Slugs of the teams to grant the privilege to.",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+				Optional:     true,
+				AtLeastOneOf: []string{"members", "teams"},
+			},
+		},
+	}
+}
@@ -0,0 +1,180 @@
+//nolint:testpackage
+package cloudsmith
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// TestAccRepositoryPrivilege_basic spins up a repository with default options
+// and a couple of teams, granting one team read privileges before adding the
+// second team and then revoking the first, verifying the privileges at each
+// step before tearing down and verifying deletion.
+func TestAccRepositoryPrivilege_basic(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccRepositoryCheckDestroy("cloudsmith_repository.test"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRepositoryPrivilegeConfig(`[cloudsmith_team.test_1.slug]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("cloudsmith_repository_privilege.test", "teams.#", "1"),
+					resource.TestCheckTypeSetElemAttr("cloudsmith_repository_privilege.test", "teams.*", "tf-test-team-priv-1"),
+				),
+			},
+			{
+				Config: testAccRepositoryPrivilegeConfig(`[cloudsmith_team.test_1.slug, cloudsmith_team.test_2.slug]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("cloudsmith_repository_privilege.test", "teams.#", "2"),
+					resource.TestCheckTypeSetElemAttr("cloudsmith_repository_privilege.test", "teams.*", "tf-test-team-priv-1"),
+					resource.TestCheckTypeSetElemAttr("cloudsmith_repository_privilege.test", "teams.*", "tf-test-team-priv-2"),
+				),
+			},
+			{
+				Config: testAccRepositoryPrivilegeConfig(`[cloudsmith_team.test_2.slug]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("cloudsmith_repository_privilege.test", "teams.#", "1"),
+					resource.TestCheckTypeSetElemAttr("cloudsmith_repository_privilege.test", "teams.*", "tf-test-team-priv-2"),
+				),
+			},
+			{
+				ResourceName: "cloudsmith_repository_privilege.test",
+				ImportState:  true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					resourceState := s.RootModule().Resources["cloudsmith_repository_privilege.test"]
+					return fmt.Sprintf(
+						"%s.%s.%s",
+						resourceState.Primary.Attributes["namespace"],
+						resourceState.Primary.Attributes["repository"],
+						resourceState.Primary.Attributes["privilege"],
+					), nil
+				},
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+// TestResourceRepositoryPrivilegeRead verifies that reading the resource only
+// tracks the members and teams it already manages, so that holders of the same
+// privilege level granted elsewhere are not reported as drift, and that every
+// holder of the level is adopted when nothing is tracked yet, as after import.
+func TestResourceRepositoryPrivilegeRead(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		attributes  map[string]string
+		wantMembers []string
+		wantTeams   []string
+	}{
+		{
+			name: "Tracked",
+			attributes: map[string]string{
+				"members.#": "2",
+				"members.0": "alice",
+				"members.1": "dave",
+			},
+			wantMembers: []string{"alice"},
+			wantTeams:   []string{},
+		},
+		{
+			name:        "Imported",
+			attributes:  map[string]string{},
+			wantMembers: []string{"alice", "bob"},
+			wantTeams:   []string{"other-team"},
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/repos/my-org/my-repo/privileges" {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				w.Header().Set("Content-Type", "application/json")
+				_ = json.NewEncoder(w).Encode(map[string]interface{}{
+					"privileges": []map[string]string{
+						{"privilege": "Read", "user": "alice"},
+						{"privilege": "Read", "user": "bob"},
+						{"privilege": "Read", "team": "other-team"},
+						{"privilege": "Write", "user": "carol"},
+					},
+				})
+			}))
+			defer server.Close()
+
+			attributes := map[string]string{
+				"namespace":  "my-org",
+				"repository": "my-repo",
+				"privilege":  "Read",
+			}
+			for k, v := range tc.attributes {
+				attributes[k] = v
+			}
+			state := &terraform.InstanceState{ID: "my-org.my-repo.Read", Attributes: attributes}
+			d, err := schema.InternalMap(resourceRepositoryPrivilege().Schema).Data(state, nil)
+			if err != nil {
+				t.Fatalf("unable to build resource data: %s", err)
+			}
+
+			if err := resourceRepositoryPrivilegeRead(d, testProviderConfig(server.URL)); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			members := expandStrings(d, "members")
+			teams := expandStrings(d, "teams")
+			sort.Strings(members)
+			sort.Strings(teams)
+			if !reflect.DeepEqual(members, tc.wantMembers) {
+				t.Errorf("expected members %v, got %v", tc.wantMembers, members)
+			}
+			if !reflect.DeepEqual(teams, tc.wantTeams) {
+				t.Errorf("expected teams %v, got %v", tc.wantTeams, teams)
+			}
+		})
+	}
+}
+
+func testAccRepositoryPrivilegeConfig(teams string) string {
+	return fmt.Sprintf(`
+resource "cloudsmith_repository" "test" {
+	name      = "terraform-acc-test-priv"
+	namespace = "%s"
+}
+
+resource "cloudsmith_team" "test_1" {
+	name         = "TF Test Team Priv 1"
+	organization = cloudsmith_repository.test.namespace
+}
+
+resource "cloudsmith_team" "test_2" {
+	name         = "TF Test Team Priv 2"
+	organization = cloudsmith_repository.test.namespace
+}
+
+resource "cloudsmith_repository_privilege" "test" {
+	namespace  = cloudsmith_repository.test.namespace
+	repository = cloudsmith_repository.test.slug
+	privilege  = "Read"
+	teams      = %s
+}
+`, os.Getenv("CLOUDSMITH_NAMESPACE"), teams)
+}
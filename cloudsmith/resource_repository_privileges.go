@@ -3,11 +3,12 @@ package cloudsmith
 import (
 	"context"
 	"fmt"
-	"log"
 	"strings"
 	"time"
 
 	"github.com/cloudsmith-io/cloudsmith-api-go"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/samber/lo"
@@ -177,7 +178,7 @@ func importRepositoryPrivileges(ctx context.Context, d *schema.ResourceData, m i
 	return []*schema.ResourceData{d}, nil
 }
 
-func resourceRepositoryPrivilegesCreateUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceRepositoryPrivilegesCreateUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	pc := m.(*providerConfig)
 
 	organization := requiredString(d, "organization")
@@ -193,18 +194,22 @@ func resourceRepositoryPrivilegesCreateUpdate(d *schema.ResourceData, m interfac
 	userReq := pc.APIClient.UserApi.UserSelf(pc.Auth)
 	userSelf, _, err := pc.APIClient.UserApi.UserSelfExecute(userReq)
 	if err != nil {
-		return fmt.Errorf("error retrieving authenticated account for lockout prevention: %w", err)
+		return diag.Errorf("error retrieving authenticated account for lockout prevention: %s", err)
 	}
 	currentSlug := userSelf.GetSlug()
 
 	if !containsAccountSlug(privileges, currentSlug) {
 		if !containsTeam(privileges) {
-			return fmt.Errorf(
+			return diag.Errorf(
 				"repository_privileges (%s.%s): configuration must include authenticated account slug '%s' (user or service block) OR at least one team block to avoid potential lockout",
 				organization, repository, currentSlug,
 			)
 		}
-		log.Printf("[WARN] repository_privileges (%s.%s): authenticated account slug '%s' not explicitly included via user/service; ensure access via configured teams to avoid lockout.", organization, repository, currentSlug)
+		tflog.Warn(ctx, "Authenticated account not explicitly included via user/service; ensure access via configured teams to avoid lockout", map[string]interface{}{
+			"namespace":  organization,
+			"repository": repository,
+			"slug":       currentSlug,
+		})
 	}
 
 	req := pc.APIClient.ReposApi.ReposPrivilegesUpdate(pc.Auth, organization, repository)
@@ -214,7 +219,7 @@ func resourceRepositoryPrivilegesCreateUpdate(d *schema.ResourceData, m interfac
 
 	_, err = pc.APIClient.ReposApi.ReposPrivilegesUpdateExecute(req)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(fmt.Sprintf("%s.%s", organization, repository))
@@ -226,10 +231,10 @@ func resourceRepositoryPrivilegesCreateUpdate(d *schema.ResourceData, m interfac
 		return nil
 	}
 	if err := waiter(checkerFunc, defaultUpdateTimeout, defaultUpdateInterval); err != nil {
-		return fmt.Errorf("error waiting for privileges (%s) to be updated: %w", d.Id(), err)
+		return diag.Errorf("error waiting for privileges (%s) to be updated: %s", d.Id(), err)
 	}
 
-	return resourceRepositoryPrivilegesRead(d, m)
+	return diag.FromErr(resourceRepositoryPrivilegesRead(d, m))
 }
 
 func resourceRepositoryPrivilegesRead(d *schema.ResourceData, m interface{}) error {
@@ -238,30 +243,13 @@ func resourceRepositoryPrivilegesRead(d *schema.ResourceData, m interface{}) err
 	organization := requiredString(d, "organization")
 	repository := requiredString(d, "repository")
 
-	var allPrivileges []cloudsmith.RepositoryPrivilegeDict
-	page := int64(1)
-	pageSize := int64(1000)
-
-	for {
-		req := pc.APIClient.ReposApi.ReposPrivilegesList(pc.Auth, organization, repository)
-		req = req.Page(page)
-		req = req.PageSize(pageSize)
-		privileges, resp, err := pc.APIClient.ReposApi.ReposPrivilegesListExecute(req)
-		if err != nil {
-			if is404(resp) {
-				d.SetId("")
-				return nil
-			}
-			return err
-		}
-
-		allPrivileges = append(allPrivileges, privileges.GetPrivileges()...)
-
-		// Check if we have retrieved all pages
-		if int64(len(privileges.GetPrivileges())) < pageSize {
-			break
+	allPrivileges, resp, err := listRepositoryPrivileges(pc, organization, repository)
+	if err != nil {
+		if is404(resp) {
+			d.SetId("")
+			return nil
 		}
-		page++
+		return err
 	}
 
 	d.Set("service", flattenRepositoryPrivilegeServices(allPrivileges))
@@ -309,10 +297,10 @@ func resourceRepositoryPrivilegesDelete(d *schema.ResourceData, m interface{}) e
 //nolint:funlen
 func resourceRepositoryPrivileges() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceRepositoryPrivilegesCreateUpdate,
-		Read:   resourceRepositoryPrivilegesRead,
-		Update: resourceRepositoryPrivilegesCreateUpdate,
-		Delete: resourceRepositoryPrivilegesDelete,
+		CreateContext: resourceRepositoryPrivilegesCreateUpdate,
+		Read:          resourceRepositoryPrivilegesRead,
+		UpdateContext: resourceRepositoryPrivilegesCreateUpdate,
+		Delete:        resourceRepositoryPrivilegesDelete,
 
 		// Plan-time validation to surface lockout risk earlier than apply. We still
 		// keep the apply-time safety net in Create/Update for defense in depth.
@@ -349,7 +337,9 @@ func resourceRepositoryPrivileges() *schema.Resource {
 				if teamCount == 0 {
 					return fmt.Errorf("repository_privileges: authenticated account slug '%s' must be included (user or service block) OR at least one team block must be defined to avoid potential lockout", currentSlug)
 				}
-				log.Printf("[WARN] repository_privileges (plan): authenticated account slug '%s' not explicitly included via user/service; ensure team-based access is sufficient to avoid lockout.", currentSlug)
+				tflog.Warn(ctx, "Authenticated account not explicitly included via user/service; ensure team-based access is sufficient to avoid lockout", map[string]interface{}{
+					"slug": currentSlug,
+				})
 			}
 
 			return nil
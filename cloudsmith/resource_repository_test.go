@@ -2,12 +2,22 @@
 package cloudsmith
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"path"
+	"path/filepath"
 	"regexp"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
@@ -64,6 +74,11 @@ func TestAccRepository_basic(t *testing.T) {
 					resource.TestCheckResourceAttr("cloudsmith_repository.test", "tag_pre_releases_as_latest", "true"),
 					resource.TestCheckResourceAttr("cloudsmith_repository.test", "use_entitlements_privilege", "Admin"),
 					resource.TestCheckResourceAttr("cloudsmith_repository.test", "broadcast_state", "Private"),
+					resource.TestCheckResourceAttr("cloudsmith_repository.test", "delete_own", "false"),
+					resource.TestCheckResourceAttr("cloudsmith_repository.test", "delete_packages", "Admin"),
+					resource.TestCheckResourceAttr("cloudsmith_repository.test", "move_own", "false"),
+					resource.TestCheckResourceAttr("cloudsmith_repository.test", "move_packages", "Admin"),
+					resource.TestCheckResourceAttr("cloudsmith_repository.test", "view_statistics", "Write"),
 				),
 			},
 			{
@@ -78,7 +93,7 @@ func TestAccRepository_basic(t *testing.T) {
 					), nil
 				},
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"wait_for_deletion"},
+				ImportStateVerifyIgnore: []string{"wait_for_deletion", "force_destroy"},
 			},
 		},
 	})
@@ -181,5 +196,165 @@ resource "cloudsmith_repository" "test" {
 	tag_pre_releases_as_latest = true
 	use_entitlements_privilege = "Admin"
 	broadcast_state = "Private"
+
+	delete_own      = false
+	delete_packages = "Admin"
+	move_own        = false
+	move_packages   = "Admin"
+	view_statistics = "Write"
 }
 `, os.Getenv("CLOUDSMITH_NAMESPACE"))
+
+// TestAccRepository_forceDestroy spins up an immutable repository with
+// force_destroy set and a package in it, verifying that only admins can delete
+// or replace its packages and that the repository can still be torn down.
+func TestAccRepository_forceDestroy(t *testing.T) {
+	t.Parallel()
+
+	packageFile := filepath.Join(t.TempDir(), "terraform-acc-test-force-destroy.txt")
+	if err := os.WriteFile(packageFile, []byte("terraform-acc-test-force-destroy"), 0o600); err != nil {
+		t.Fatalf("unable to write package file: %s", err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccRepositoryCheckDestroy("cloudsmith_repository.test"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRepositoryConfigForceDestroy(packageFile),
+				Check: resource.ComposeTestCheckFunc(
+					testAccRepositoryCheckExists("cloudsmith_repository.test"),
+					testAccPackageUploadCheckExists("cloudsmith_raw_package.test"),
+					resource.TestCheckResourceAttr("cloudsmith_repository.test", "delete_own", "false"),
+					resource.TestCheckResourceAttr("cloudsmith_repository.test", "delete_packages", "Admin"),
+					resource.TestCheckResourceAttr("cloudsmith_repository.test", "replace_packages", "Admin"),
+				),
+			},
+		},
+	})
+}
+
+func testAccRepositoryConfigForceDestroy(packageFile string) string {
+	return fmt.Sprintf(`
+resource "cloudsmith_repository" "test" {
+	name          = "terraform-acc-test-force-destroy"
+	namespace     = "%s"
+	immutable     = true
+	force_destroy = true
+}
+
+resource "cloudsmith_raw_package" "test" {
+	namespace    = "${cloudsmith_repository.test.namespace}"
+	repository   = "${cloudsmith_repository.test.slug_perm}"
+	package_file = "%s"
+	name         = "terraform-acc-test-force-destroy"
+	version      = "1.0.0"
+}
+`, os.Getenv("CLOUDSMITH_NAMESPACE"), packageFile)
+}
+
+// TestResourceRepositoryDelete_immutable verifies that an immutable repository
+// is not deleted unless force_destroy is set.
+func TestResourceRepositoryDelete_immutable(t *testing.T) {
+	t.Parallel()
+
+	d := schema.TestResourceDataRaw(t, resourceRepository().Schema, map[string]interface{}{
+		"name":      "release",
+		"namespace": "namespace",
+		"immutable": true,
+	})
+	d.SetId("release")
+
+	err := resourceRepositoryDelete(d, testProviderConfig(""))
+	if err == nil || !strings.Contains(err.Error(), "is immutable") {
+		t.Fatalf("expected immutable repository error, got %v", err)
+	}
+}
+
+// TestRepositorySettings_immutable verifies that the package settings of an
+// immutable repository are overridden so that only admins can delete, move or
+// replace packages, while other repositories use the configured settings.
+func TestRepositorySettings_immutable(t *testing.T) {
+	t.Parallel()
+
+	for _, immutable := range []bool{false, true} {
+		d := schema.TestResourceDataRaw(t, resourceRepository().Schema, map[string]interface{}{
+			"name":             "release",
+			"namespace":        "namespace",
+			"immutable":        immutable,
+			"delete_own":       true,
+			"delete_packages":  "Write",
+			"replace_packages": "Write",
+		})
+
+		expectedOwn, expectedPrivilege := true, "Write"
+		if immutable {
+			expectedOwn, expectedPrivilege = false, "Admin"
+		}
+		if v := repositoryBoolSetting(d, "delete_own"); v == nil || *v != expectedOwn {
+			t.Errorf("immutable=%t: expected delete_own %t, got %v", immutable, expectedOwn, v)
+		}
+		for _, name := range []string{"delete_packages", "replace_packages"} {
+			if v := repositoryStringSetting(d, name); v == nil || *v != expectedPrivilege {
+				t.Errorf("immutable=%t: expected %s %s, got %v", immutable, name, expectedPrivilege, v)
+			}
+		}
+	}
+}
+
+// TestResourceRepositoryDeletePackages serves a repository with more than a
+// page of packages and verifies that force_destroy deletes all of them, and
+// that it gives up if packages are never removed.
+func TestResourceRepositoryDeletePackages(t *testing.T) {
+	t.Parallel()
+
+	newServer := func(packages map[string]bool, deletable bool) *httptest.Server {
+		var mu sync.Mutex
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			if r.Method == http.MethodDelete {
+				if deletable {
+					delete(packages, path.Base(strings.TrimSuffix(r.URL.Path, "/")))
+				}
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+
+			page := []map[string]string{}
+			for slug := range packages {
+				if len(page) == 100 {
+					break
+				}
+				page = append(page, map[string]string{"slug_perm": slug})
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("X-Pagination-Pagetotal", "1")
+			_ = json.NewEncoder(w).Encode(page)
+		}))
+	}
+
+	packages := map[string]bool{}
+	for i := 0; i < 150; i++ {
+		packages[fmt.Sprintf("package-%d", i)] = true
+	}
+	server := newServer(packages, true)
+	defer server.Close()
+
+	if err := resourceRepositoryDeletePackages(testProviderConfig(server.URL), "namespace", "repository", time.Second, time.Millisecond); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(packages) != 0 {
+		t.Errorf("expected all packages to be deleted, %d remain", len(packages))
+	}
+
+	stuck := newServer(map[string]bool{"package-0": true}, false)
+	defer stuck.Close()
+
+	err := resourceRepositoryDeletePackages(testProviderConfig(stuck.URL), "namespace", "repository", 50*time.Millisecond, 10*time.Millisecond)
+	if !errors.Is(err, errTimedOut) {
+		t.Errorf("expected a timeout error, got %v", err)
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/cloudsmith-io/cloudsmith-api-go"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/samber/lo"
 )
 
 // upstream types
@@ -1245,6 +1246,61 @@ func validateUpstreamUrl(v interface{}, k string) (warnings []string, errors []e
 	return
 }
 
+// upstreamTypeFields lists the attributes which only apply to particular
+// upstream types, along with the types they apply to.
+var upstreamTypeFields = map[string][]string{
+	AuthCertificate:      {Docker},
+	AuthCertificateKey:   {Docker},
+	Component:            {Deb},
+	DistroVersion:        {Rpm},
+	DistroVersions:       {Deb},
+	UpstreamDistribution: {Deb},
+}
+
+// customizeDiffUpstream validates constraints which depend on the upstream
+// type or authentication mode, and so can't be expressed in the schema alone.
+func customizeDiffUpstream(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	upstreamType := d.Get(UpstreamType).(string)
+
+	for field, types := range upstreamTypeFields {
+		if _, ok := d.GetOk(field); ok && !lo.Contains(types, upstreamType) {
+			return fmt.Errorf("`%s` is only supported for %s upstreams", field, strings.Join(types, "/"))
+		}
+	}
+
+	if upstreamType == Rpm {
+		if _, ok := d.GetOk(DistroVersion); !ok && d.NewValueKnown(DistroVersion) {
+			return fmt.Errorf("`%s` is required for %s upstreams", DistroVersion, Rpm)
+		}
+	}
+
+	// auth_mode is computed, so only validate credentials against it when it
+	// has been set explicitly rather than inherited from state.
+	authMode := ""
+	if config := d.GetRawConfig(); !config.IsNull() {
+		if v := config.GetAttr(AuthMode); v.IsKnown() && !v.IsNull() {
+			authMode = v.AsString()
+		}
+	}
+
+	var required []string
+	switch authMode {
+	case "Username and Password":
+		required = []string{AuthUsername, AuthSecret}
+	case "Token":
+		required = []string{AuthSecret}
+	case "Certificate and Key":
+		required = []string{AuthCertificate, AuthCertificateKey}
+	}
+	for _, field := range required {
+		if _, ok := d.GetOk(field); !ok && d.NewValueKnown(field) {
+			return fmt.Errorf("`%s` is required when `%s` is %q", field, AuthMode, authMode)
+		}
+	}
+
+	return nil
+}
+
 func resourceRepositoryUpstream() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceRepositoryUpstreamCreate,
@@ -1252,6 +1308,8 @@ func resourceRepositoryUpstream() *schema.Resource {
 		Update: resourceRepositoryUpstreamUpdate,
 		Delete: resourceRepositoryUpstreamDelete,
 
+		CustomizeDiff: customizeDiffUpstream,
+
 		Importer: &schema.ResourceImporter{
 			StateContext: importUpstream,
 		},
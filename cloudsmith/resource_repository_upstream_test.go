@@ -11,6 +11,7 @@ import (
 	"math/big"
 	"net/http"
 	"os"
+	"regexp"
 	"testing"
 	"time"
 
@@ -2012,3 +2013,52 @@ func waitForIsActiveTrue(resourceName string) resource.TestCheckFunc {
 		}
 	}
 }
+
+// TestAccRepositoryUpstream_customizeDiff verifies that type and
+// authentication specific constraints are rejected at plan time.
+func TestAccRepositoryUpstream_customizeDiff(t *testing.T) {
+	t.Parallel()
+
+	testAccRepositoryUpstreamConfigInvalid := func(attributes string) string {
+		return fmt.Sprintf(`
+resource "cloudsmith_repository_upstream" "invalid" {
+	namespace    = "%s"
+	repository   = "terraform-acc-test-upstream-invalid"
+	name         = "terraform-acc-test-upstream-invalid"
+	upstream_url = "https://example.com"
+	%s
+}
+`, os.Getenv("CLOUDSMITH_NAMESPACE"), attributes)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRepositoryUpstreamConfigInvalid(`
+	upstream_type   = "python"
+	distro_versions = ["ubuntu/focal"]
+`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("`distro_versions` is only supported for deb upstreams"),
+			},
+			{
+				Config: testAccRepositoryUpstreamConfigInvalid(`
+	upstream_type = "rpm"
+`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("`distro_version` is required for rpm upstreams"),
+			},
+			{
+				Config: testAccRepositoryUpstreamConfigInvalid(`
+	upstream_type = "npm"
+	auth_mode     = "Username and Password"
+	auth_username = "jonny.tables"
+`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("`auth_secret` is required when `auth_mode` is \"Username and Password\""),
+			},
+		},
+	})
+}
@@ -0,0 +1,201 @@
+package cloudsmith
+
+import (
+	"context"
+	"time"
+
+	"github.com/cloudsmith-io/cloudsmith-api-go"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const tokenListPageSize = 100
+
+// retrieveUserToken finds the token with the given slug_perm in the list of
+// tokens belonging to the authenticated user. Returns nil if no such token
+// exists.
+func retrieveUserToken(pc *providerConfig, slugPerm string) (*cloudsmith.UserAuthenticationToken, error) {
+	for page := int64(1); ; page++ {
+		req := pc.APIClient.UserApi.UserTokensList(pc.Auth)
+		req = req.Page(page)
+		req = req.PageSize(tokenListPageSize)
+
+		tokens, resp, err := pc.APIClient.UserApi.UserTokensListExecute(req)
+		if err != nil {
+			if is404(resp) {
+				return nil, nil
+			}
+			return nil, err
+		}
+
+		for _, token := range tokens.GetResults() {
+			if token.GetSlugPerm() == slugPerm {
+				return &token, nil
+			}
+		}
+
+		if len(tokens.GetResults()) < tokenListPageSize {
+			return nil, nil
+		}
+	}
+}
+
+func importToken(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	// it's not possible to retrieve a token's value via API after creation, so
+	// when we import a token the value is unavailable. Setting to a known
+	// sentinel value here allows us to detect this case later and warn the
+	// user that things may not work as expected.
+	d.Set("token", importSentinel)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceTokenCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	pc := m.(*providerConfig)
+
+	req := pc.APIClient.UserApi.UserTokensCreate(pc.Auth)
+	token, _, err := pc.APIClient.UserApi.UserTokensCreateExecute(req)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(token.GetSlugPerm())
+
+	// normally we'd read this value back on read, but it's only returned over
+	// the API when the token is created, otherwise it's obfuscated.
+	d.Set("token", token.GetKey())
+
+	return resourceTokenRead(ctx, d, m)
+}
+
+func resourceTokenRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	pc := m.(*providerConfig)
+
+	token, err := retrieveUserToken(pc, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if token == nil {
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("created_at", timeToString(token.GetCreated()))
+	d.Set("slug_perm", token.GetSlugPerm())
+
+	// since we don't get the full token when reading it back from the API, we
+	// need to check if it has changed and if so warn the user that they'll
+	// need to recreate the resource if they want to pull the new value into
+	// Terraform. This can be accomplished by tainting.
+
+	var diags diag.Diagnostics
+	existingToken := requiredString(d, "token")
+	if existingToken == importSentinel {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Token value unavailable for imported tokens",
+			Detail: "Token values are only available via the Cloudsmith API at the time a token " +
+				"is created, and therefore it is not possible to retrieve the value of a token " +
+				"which has been imported. If the token value is needed within Terraform then " +
+				"change the keepers of the resource to refresh the token and store the new value.",
+			AttributePath: cty.Path{cty.GetAttrStep{Name: "token"}},
+		})
+	} else if len(existingToken) >= 4 && len(token.GetKey()) >= 4 {
+		existingLastFour := existingToken[len(existingToken)-4:]
+		newLastFour := token.GetKey()[len(token.GetKey())-4:]
+		if existingLastFour != newLastFour {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  "Token has changed",
+				Detail: "This token has been refreshed outside of Terraform. If the token is used " +
+					"within Terraform change the keepers of the resource to refresh the token " +
+					"again and store the new value.",
+				AttributePath: cty.Path{cty.GetAttrStep{Name: "token"}},
+			})
+		}
+	}
+
+	return diags
+}
+
+// resourceTokenUpdate refreshes the token whenever its keepers change, storing
+// the new value as it's only returned by the API at the time of the refresh.
+func resourceTokenUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	pc := m.(*providerConfig)
+
+	if d.HasChange("keepers") {
+		req := pc.APIClient.UserApi.UserTokensRefresh(pc.Auth, d.Id())
+		token, _, err := pc.APIClient.UserApi.UserTokensRefreshExecute(req)
+		if err != nil {
+			return diag.Errorf("error refreshing token (%s): %s", d.Id(), err)
+		}
+
+		d.SetId(token.GetSlugPerm())
+		d.Set("token", token.GetKey())
+		d.Set("refreshed_at", timeToString(time.Now().UTC()))
+	}
+
+	return resourceTokenRead(ctx, d, m)
+}
+
+func resourceTokenDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// the Cloudsmith API does not provide a way to delete user tokens, so all
+	// we can do is remove the token from Terraform state.
+	d.SetId("")
+
+	return diag.Diagnostics{
+		{
+			Severity: diag.Warning,
+			Summary:  "Token not deleted",
+			Detail: "The Cloudsmith API does not support deleting API tokens. The token has been " +
+				"removed from Terraform state but remains valid until it is refreshed or revoked " +
+				"via the Cloudsmith web UI.",
+		},
+	}
+}
+
+func resourceToken() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceTokenCreate,
+		ReadContext:   resourceTokenRead,
+		UpdateContext: resourceTokenUpdate,
+		DeleteContext: resourceTokenDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: importToken,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"created_at": {
+				Type:        schema.TypeString,
+				Description: "ISO 8601 timestamp at which the token was created.",
+				Computed:    true,
+			},
+			"keepers": {
+				Type:        schema.TypeMap,
+				Description: "Arbitrary values which, when changed, cause the token to be refreshed.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Optional:    true,
+			},
+			"refreshed_at": {
+				Type:        schema.TypeString,
+				Description: "ISO 8601 timestamp at which the token was last refreshed by Terraform.",
+				Computed:    true,
+			},
+			"slug_perm": {
+				Type:        schema.TypeString,
+				Description: "The slug_perm immutably identifies the token.",
+				Computed:    true,
+			},
+			"token": {
+				Type: schema.TypeString,
+				Description: "The value of the API token. This is only available when the token " +
+					"is created or refreshed, and cannot be recovered for imported tokens until " +
+					"they are refreshed.",
+				Computed:  true,
+				Sensitive: true,
+			},
+		},
+	}
+}
@@ -0,0 +1,118 @@
+//nolint:testpackage
+package cloudsmith
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// TestRetrieveUserToken serves a paginated list of tokens and verifies that a
+// token is found regardless of which page it appears on, and that a missing
+// token is reported as nil rather than an error. An acceptance test is not
+// provided as creating tokens would affect the credentials used by the test
+// suite itself.
+func TestRetrieveUserToken(t *testing.T) {
+	t.Parallel()
+
+	const totalTokens = tokenListPageSize + 10
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		results := []map[string]string{}
+		for i := (page - 1) * tokenListPageSize; i < page*tokenListPageSize && i < totalTokens; i++ {
+			results = append(results, map[string]string{
+				"key":       "****",
+				"slug_perm": fmt.Sprintf("token-%d", i),
+			})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"results": results})
+	}))
+	defer server.Close()
+
+	pc := testProviderConfig(server.URL)
+
+	tests := []struct {
+		slugPerm string
+		found    bool
+	}{
+		{slugPerm: "token-0", found: true},
+		{slugPerm: fmt.Sprintf("token-%d", totalTokens-1), found: true},
+		{slugPerm: "token-missing", found: false},
+	}
+
+	for _, tt := range tests {
+		token, err := retrieveUserToken(pc, tt.slugPerm)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", tt.slugPerm, err)
+		}
+		if found := token != nil; found != tt.found {
+			t.Fatalf("%s: expected found=%t, got found=%t", tt.slugPerm, tt.found, found)
+		}
+		if token != nil && token.GetSlugPerm() != tt.slugPerm {
+			t.Fatalf("expected token %s, got %s", tt.slugPerm, token.GetSlugPerm())
+		}
+	}
+}
+
+// TestResourceTokenUpdate verifies that changing the keepers of a token
+// refreshes it and stores the new value, which is otherwise unavailable.
+func TestResourceTokenUpdate(t *testing.T) {
+	t.Parallel()
+
+	refreshed := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodPut && r.URL.Path == "/user/tokens/token-1/refresh/":
+			refreshed = true
+			_ = json.NewEncoder(w).Encode(map[string]string{"key": "refreshed-key", "slug_perm": "token-1"})
+		case r.Method == http.MethodGet && r.URL.Path == "/user/tokens/":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"results": []map[string]string{{"key": "****-key", "slug_perm": "token-1"}},
+			})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	state := &terraform.InstanceState{
+		ID: "token-1",
+		Attributes: map[string]string{
+			"token":            "original-key",
+			"keepers.%":        "1",
+			"keepers.rotation": "1",
+		},
+	}
+	diff := &terraform.InstanceDiff{
+		Attributes: map[string]*terraform.ResourceAttrDiff{
+			"keepers.rotation": {Old: "1", New: "2"},
+		},
+	}
+	d, err := schema.InternalMap(resourceToken().Schema).Data(state, diff)
+	if err != nil {
+		t.Fatalf("unable to build resource data: %s", err)
+	}
+
+	if diags := resourceTokenUpdate(context.Background(), d, testProviderConfig(server.URL)); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if !refreshed {
+		t.Fatal("expected token to be refreshed")
+	}
+	if token := d.Get("token").(string); token != "refreshed-key" {
+		t.Errorf("expected token refreshed-key, got %q", token)
+	}
+	if d.Get("refreshed_at").(string) == "" {
+		t.Error("expected refreshed_at to be set")
+	}
+}
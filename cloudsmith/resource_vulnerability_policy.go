@@ -7,6 +7,8 @@ import (
 	"time"
 
 	"github.com/cloudsmith-io/cloudsmith-api-go"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
@@ -29,7 +31,7 @@ func importVulnerabilityPolicy(ctx context.Context, d *schema.ResourceData, m in
 	return []*schema.ResourceData{d}, nil
 }
 
-func resourceVulnerabilityPolicyCreate(d *schema.ResourceData, m interface{}) error {
+func resourceVulnerabilityPolicyCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	pc := m.(*providerConfig)
 
 	org := requiredString(d, Organization)
@@ -46,7 +48,7 @@ func resourceVulnerabilityPolicyCreate(d *schema.ResourceData, m interface{}) er
 
 	vulnerabilityPolicy, _, err := pc.APIClient.OrgsApi.OrgsVulnerabilityPolicyCreateExecute(req)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(vulnerabilityPolicy.GetSlugPerm())
@@ -62,13 +64,13 @@ func resourceVulnerabilityPolicyCreate(d *schema.ResourceData, m interface{}) er
 		return nil
 	}
 	if err := waiter(checkerFunc, defaultCreationTimeout, defaultCreationInterval); err != nil {
-		return fmt.Errorf("error waiting for vulnerability policy (%s) to be created: %s", d.Id(), err)
+		return diag.Errorf("error waiting for vulnerability policy (%s) to be created: %s", d.Id(), err)
 	}
 
-	return resourceVulnerabilityPolicyRead(d, m)
+	return resourceVulnerabilityPolicyRead(ctx, d, m)
 }
 
-func resourceVulnerabilityPolicyUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceVulnerabilityPolicyUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	pc := m.(*providerConfig)
 
 	org := requiredString(d, Organization)
@@ -85,7 +87,7 @@ func resourceVulnerabilityPolicyUpdate(d *schema.ResourceData, m interface{}) er
 
 	vulnerabilityPolicy, _, err := pc.APIClient.OrgsApi.OrgsVulnerabilityPolicyUpdateExecute(req)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(vulnerabilityPolicy.GetSlugPerm())
@@ -97,10 +99,10 @@ func resourceVulnerabilityPolicyUpdate(d *schema.ResourceData, m interface{}) er
 		return nil
 	}
 	if err := waiter(checkerFunc, defaultUpdateTimeout, defaultUpdateInterval); err != nil {
-		return fmt.Errorf("error waiting for vulnerability policy (%s) to be updated: %w", d.Id(), err)
+		return diag.Errorf("error waiting for vulnerability policy (%s) to be updated: %s", d.Id(), err)
 	}
 
-	return resourceVulnerabilityPolicyRead(d, m)
+	return resourceVulnerabilityPolicyRead(ctx, d, m)
 }
 
 func resourceVulnerabilityPolicyDelete(d *schema.ResourceData, m interface{}) error {
@@ -131,7 +133,7 @@ func resourceVulnerabilityPolicyDelete(d *schema.ResourceData, m interface{}) er
 	return nil
 }
 
-func resourceVulnerabilityPolicyRead(d *schema.ResourceData, m interface{}) error {
+func resourceVulnerabilityPolicyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	pc := m.(*providerConfig)
 
 	org := requiredString(d, Organization)
@@ -141,11 +143,15 @@ func resourceVulnerabilityPolicyRead(d *schema.ResourceData, m interface{}) erro
 	vulnerabilityPolicy, resp, err := pc.APIClient.OrgsApi.OrgsVulnerabilityPolicyReadExecute(req)
 	if err != nil {
 		if is404(resp) {
+			tflog.Warn(ctx, "Vulnerability policy not found, removing from state", map[string]interface{}{
+				"namespace": org,
+				"slug_perm": d.Id(),
+			})
 			d.SetId("")
 			return nil
 		}
 
-		return err
+		return diag.FromErr(err)
 	}
 
 	_ = d.Set(CreatedAt, vulnerabilityPolicy.GetCreatedAt().String())
@@ -169,10 +175,10 @@ func resourceVulnerabilityPolicyRead(d *schema.ResourceData, m interface{}) erro
 //nolint:funlen
 func resourceVulnerabilityPolicy() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceVulnerabilityPolicyCreate,
-		Read:   resourceVulnerabilityPolicyRead,
-		Update: resourceVulnerabilityPolicyUpdate,
-		Delete: resourceVulnerabilityPolicyDelete,
+		CreateContext: resourceVulnerabilityPolicyCreate,
+		ReadContext:   resourceVulnerabilityPolicyRead,
+		UpdateContext: resourceVulnerabilityPolicyUpdate,
+		Delete:        resourceVulnerabilityPolicyDelete,
 
 		Importer: &schema.ResourceImporter{
 			StateContext: importVulnerabilityPolicy,
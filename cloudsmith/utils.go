@@ -43,6 +43,14 @@ func expandStrings(d *schema.ResourceData, key string) []string {
 	})
 }
 
+// setToStrings converts a *schema.Set of strings, such as the result of a set
+// difference, to a slice of strings.
+func setToStrings(set *schema.Set) []string {
+	return lo.Map(set.List(), func(item interface{}, _ int) string {
+		return item.(string)
+	})
+}
+
 // flattenStrings converts a slice of strings such as might be returned by the
 // API bindings to a *schema.Set which can be stored in TF state.
 func flattenStrings(strings []string) *schema.Set {
@@ -69,6 +77,14 @@ func is404(resp *http.Response) bool {
 	return resp.StatusCode == http.StatusNotFound
 }
 
+func is409(resp *http.Response) bool {
+	if resp == nil {
+		return false
+	}
+
+	return resp.StatusCode == http.StatusConflict
+}
+
 func nullableInt64(d *schema.ResourceData, name string) cloudsmith.NullableInt64 {
 	i := optionalInt64(d, name)
 	return *cloudsmith.NewNullableInt64(i)